@@ -67,13 +67,13 @@ printf "======================================\n"
 
 # products service
 printf "Building products-service...\n"
-docker build -t products-service:latest ./services/products
+docker build -t products-service:latest -f services/products/Dockerfile .
 # inventory service
 printf "Building inventory-service...\n"
-docker build -t inventory-service:latest ./services/inventory
+docker build -t inventory-service:latest -f services/inventory/Dockerfile .
 # orders service
 printf "Building orders-service...\n"
-docker build -t orders-service:latest ./services/orders
+docker build -t orders-service:latest -f services/orders/Dockerfile .
 # frontend
 printf "Building frontend...\n"
 docker build -t frontend:latest ./frontend
@@ -185,16 +185,21 @@ function displayOrders(orders) {
             <p><strong>Status:</strong> <span class="status ${order.status}">${order.status}</span></p>
             <p><strong>Total:</strong> $${order.total_amount.toFixed(2)}</p>
             <p><strong>Created:</strong> ${new Date(order.created_at).toLocaleDateString()}</p>
+            ${order.cancellation_reason ? `<p><strong>Cancellation reason:</strong> ${order.cancellation_reason}</p>` : ''}
             <div><strong>Items:</strong></div>
             ${order.items.map(item => {
                 const product = products.find(p => p.id === item.product_id);
                 const productName = product ? product.name : `Product ${item.product_id}`;
-                return `<p>• ${productName} x${item.quantity}</p>`;
+                const fulfilled = item.fulfilled_quantity ? ` (${item.fulfilled_quantity} fulfilled)` : '';
+                return `<p>• ${productName} x${item.quantity}${fulfilled}</p>`;
             }).join('')}
-            ${order.status === 'pending' ? `
+            ${['pending', 'confirmed', 'partially_fulfilled'].includes(order.status) ? `
                 <button onclick="fulfillOrder(${order.id})" style="margin-top: 10px; padding: 5px 10px; background: #27ae60; color: white; border: none; border-radius: 3px; cursor: pointer;">
                     Fulfill Order
                 </button>
+                <button onclick="cancelOrder(${order.id})" style="margin-top: 10px; padding: 5px 10px; background: #e74c3c; color: white; border: none; border-radius: 3px; cursor: pointer;">
+                    Cancel Order
+                </button>
             ` : ''}
         </div>
     `).join('');
@@ -302,6 +307,19 @@ async function fulfillOrder(orderId) {
     }
 }
 
+async function cancelOrder(orderId) {
+    const reason = prompt('Cancellation reason (optional):');
+    if (reason === null) {
+        return; // dialog dismissed
+    }
+    const result = await postData(`${ORDERS_API}/orders/${orderId}/cancel`, { reason: reason });
+    if (result) {
+        showMessage('Order cancelled successfully!', 'success');
+        loadOrders();
+        loadInventory(); // Refresh inventory to show released items
+    }
+}
+
 // Utility functions
 function showMessage(text, type) {
     messageDiv.textContent = text;
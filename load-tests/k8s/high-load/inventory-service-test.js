@@ -72,7 +72,7 @@ export default function () {
   // Reserve and fulfill inventory (less frequently to avoid depleting stock)
   if (__ITER % 3 === 0) {
     const quantity = Math.floor(Math.random() * 3) + 1;
-    const reservePayload = JSON.stringify({ quantity: quantity });
+    const reservePayload = JSON.stringify({ stock: quantity, owner: `k6-vu-${__VU}` });
     const params = { headers: { 'Content-Type': 'application/json' } };
 
     // Reserve inventory
@@ -82,11 +82,14 @@ export default function () {
     });
     errorRate.add(!reserveSuccess);
 
-    // Fulfill reservation
-    res = http.post(`${BASE_URL}/inventory/${productId}/fulfill`, reservePayload, params);
-    check(res, {
-      'fulfill inventory successful': (r) => r.status === 200,
-    });
+    // Fulfill the whole reservation
+    if (reserveSuccess) {
+      const reservationId = JSON.parse(res.body).reservation.id;
+      res = http.post(`${BASE_URL}/inventory/reservations/${reservationId}/fulfill`, null, params);
+      check(res, {
+        'fulfill inventory successful': (r) => r.status === 200,
+      });
+    }
   }
 
   // Minimal sleep for high throughput
@@ -64,7 +64,7 @@ export default function () {
   sleep(0.1);
 
   // Reserve inventory for a specific product
-  const reservePayload = JSON.stringify({ quantity: 2 });
+  const reservePayload = JSON.stringify({ stock: 2, owner: `k6-vu-${__VU}` });
   const postParams = {
     headers: {
       'Content-Type': 'application/json',
@@ -74,17 +74,20 @@ export default function () {
   };
 
   res = http.post(`${BASE_URL}/inventory/1/reserve`, reservePayload, postParams);
-  check(res, {
+  const reserved = check(res, {
     'reserve inventory successful': (r) => r.status === 200,
   });
 
   sleep(0.1);
 
-  // Fulfill a reservation
-  res = http.post(`${BASE_URL}/inventory/1/fulfill`, reservePayload, postParams);
-  check(res, {
-    'fulfill inventory successful': (r) => r.status === 200,
-  });
+  // Fulfill the whole reservation
+  if (reserved) {
+    const reservationId = JSON.parse(res.body).reservation.id;
+    res = http.post(`${BASE_URL}/inventory/reservations/${reservationId}/fulfill`, null, postParams);
+    check(res, {
+      'fulfill inventory successful': (r) => r.status === 200,
+    });
+  }
 
   sleep(0.1);
 }
@@ -1,27 +1,328 @@
--- creation of db tables
+-- creation of db tables (for a database created by an earlier version of this file, run
+-- db_upgrade.sql first)
 -- products
 CREATE TABLE IF NOT EXISTS products (
     id SERIAL PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     description TEXT,
     price DECIMAL(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
     category VARCHAR(100),
+    version INTEGER NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
--- inventory
+-- locations (warehouses holding stock; a lower priority number is preferred when
+-- reservations are allocated by priority)
+CREATE TABLE IF NOT EXISTS locations (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 100,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- inventory (stock of a product at a location; in_transit counts the units shipped to the
+-- location by transfers that it has not received yet; the item is low on stock while
+-- stock - reserved < reorder_point, and low_stock holds the state of its last stock alert)
 CREATE TABLE IF NOT EXISTS inventory (
-    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
     stock INTEGER NOT NULL DEFAULT 0,
     reserved INTEGER NOT NULL DEFAULT 0,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    damaged INTEGER NOT NULL DEFAULT 0,
+    in_transit INTEGER NOT NULL DEFAULT 0,
+    reorder_point INTEGER NOT NULL DEFAULT 0 CHECK (reorder_point >= 0),
+    reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0),
+    low_stock BOOLEAN NOT NULL DEFAULT false,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (location_id, product_id)
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_product ON inventory(product_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_reorder ON inventory(product_id, location_id) WHERE reorder_point > 0;
+-- stock_alerts (outbox of the alerts raised when an item crosses its reorder point,
+-- written in the same transaction as the change and delivered by a background worker)
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    available INTEGER NOT NULL,
+    reorder_point INTEGER NOT NULL,
+    reorder_quantity INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    claimed_until TIMESTAMP,
+    delivered_at TIMESTAMP,
+    FOREIGN KEY (location_id, product_id) REFERENCES inventory(location_id, product_id) ON DELETE CASCADE,
+    CONSTRAINT stock_alerts_kind_check CHECK (kind IN ('low_stock', 'recovered'))
+);
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_pending ON stock_alerts(id) WHERE delivered_at IS NULL;
+-- reservations (units of a product held for an owner; inventory.reserved is the sum of
+-- the quantities still held by active reservations, updated in the same transaction;
+-- cost_of_goods_sold is the cost of the units fulfilled so far, in currency)
+CREATE TABLE IF NOT EXISTS reservations (
+    id SERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    fulfilled_quantity INTEGER NOT NULL DEFAULT 0,
+    released_quantity INTEGER NOT NULL DEFAULT 0,
+    owner VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'active',
+    cost_of_goods_sold DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT reservations_quantity_check CHECK (
+        quantity > 0 AND fulfilled_quantity >= 0 AND released_quantity >= 0
+        AND fulfilled_quantity + released_quantity <= quantity
+    ),
+    CONSTRAINT reservations_state_check CHECK (state IN ('active', 'fulfilled', 'released', 'expired')),
+    FOREIGN KEY (location_id, product_id) REFERENCES inventory(location_id, product_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_reservations_active ON reservations(product_id) WHERE state = 'active';
+CREATE INDEX IF NOT EXISTS idx_reservations_owner ON reservations(owner);
+CREATE INDEX IF NOT EXISTS idx_reservations_expiry ON reservations(expires_at) WHERE state = 'active';
+-- inventory_lots (the part of an item's stock and reserved quantities received under a lot
+-- number; units of expired lots that are not reserved cannot be reserved)
+CREATE TABLE IF NOT EXISTS inventory_lots (
+    id SERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP,
+    stock INTEGER NOT NULL DEFAULT 0,
+    reserved INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (location_id, product_id, lot_number),
+    CONSTRAINT inventory_lots_quantity_check CHECK (reserved >= 0 AND reserved <= stock),
+    FOREIGN KEY (location_id, product_id) REFERENCES inventory(location_id, product_id) ON DELETE CASCADE
+);
+-- reservation_lots (units of lots allocated to a reservation, first-expired-first-out;
+-- quantity is what the reservation still holds of the lot)
+CREATE TABLE IF NOT EXISTS reservation_lots (
+    reservation_id INTEGER NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    lot_id INTEGER NOT NULL REFERENCES inventory_lots(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    fulfilled_quantity INTEGER NOT NULL DEFAULT 0 CHECK (fulfilled_quantity >= 0),
+    PRIMARY KEY (reservation_id, lot_id)
+);
+-- transfers (units moved between locations: draft -> in_transit, when the source's stock is
+-- shipped, -> received, when it is added to the destination's stock)
+CREATE TABLE IF NOT EXISTS transfers (
+    id SERIAL PRIMARY KEY,
+    source_location_id INTEGER NOT NULL REFERENCES locations(id),
+    destination_location_id INTEGER NOT NULL REFERENCES locations(id),
+    state VARCHAR(20) NOT NULL DEFAULT 'draft',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP,
+    received_at TIMESTAMP,
+    CONSTRAINT transfers_locations_check CHECK (source_location_id <> destination_location_id),
+    CONSTRAINT transfers_state_check CHECK (state IN ('draft', 'in_transit', 'received'))
+);
+CREATE INDEX IF NOT EXISTS idx_transfers_state ON transfers(state);
+-- transfer_lines
+CREATE TABLE IF NOT EXISTS transfer_lines (
+    transfer_id INTEGER NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (transfer_id, product_id)
 );
+-- transfer_line_lots (units of lots each transfer line moves: the lots named on creation,
+-- then, once shipped, every lot its units were taken from, with the lot's expiry date)
+CREATE TABLE IF NOT EXISTS transfer_line_lots (
+    transfer_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (transfer_id, product_id, lot_number),
+    FOREIGN KEY (transfer_id, product_id) REFERENCES transfer_lines(transfer_id, product_id) ON DELETE CASCADE
+);
+-- stocktakes (physical counts of products at a location: open, while counts are submitted
+-- and reviewed, -> posted, when the approved variances are added to the stock, or cancelled)
+CREATE TABLE IF NOT EXISTS stocktakes (
+    id SERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    state VARCHAR(20) NOT NULL DEFAULT 'open',
+    reference VARCHAR(255),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP,
+    CONSTRAINT stocktakes_state_check CHECK (state IN ('open', 'posted', 'cancelled'))
+);
+CREATE INDEX IF NOT EXISTS idx_stocktakes_state ON stocktakes(state);
+-- stocktake_lines (expected is the item's stock when the line was last counted, or when the
+-- stocktake was opened until then)
+CREATE TABLE IF NOT EXISTS stocktake_lines (
+    stocktake_id INTEGER NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    expected INTEGER NOT NULL CHECK (expected >= 0),
+    state VARCHAR(20) NOT NULL DEFAULT 'pending',
+    PRIMARY KEY (stocktake_id, product_id),
+    CONSTRAINT stocktake_lines_state_check CHECK (state IN ('pending', 'counted', 'approved', 'rejected'))
+);
+CREATE INDEX IF NOT EXISTS idx_stocktake_lines_product ON stocktake_lines(product_id);
+-- stocktake_counts (the last count of each counter; a line's counted quantity is their sum)
+CREATE TABLE IF NOT EXISTS stocktake_counts (
+    stocktake_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    counter VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    counted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (stocktake_id, product_id, counter),
+    FOREIGN KEY (stocktake_id, product_id) REFERENCES stocktake_lines(stocktake_id, product_id) ON DELETE CASCADE
+);
+-- suppliers
+CREATE TABLE IF NOT EXISTS suppliers (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- purchase_orders (units ordered from a supplier for a location; open -> partially_received
+-- -> received as goods arrive, or cancelled when nothing more is expected)
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id SERIAL PRIMARY KEY,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id),
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    state VARCHAR(20) NOT NULL DEFAULT 'open',
+    reference VARCHAR(255),
+    expected_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP,
+    CONSTRAINT purchase_orders_state_check CHECK (state IN ('open', 'partially_received', 'received', 'cancelled'))
+);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_state ON purchase_orders(state);
+-- purchase_order_lines (received_quantity exceeds quantity after an over-receipt)
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_cost DECIMAL(12, 2) NOT NULL DEFAULT 0 CHECK (unit_cost >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    PRIMARY KEY (purchase_order_id, product_id)
+);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_lines_product ON purchase_order_lines(product_id);
+-- purchase_receipts (every delivery of goods received for a purchase order line)
+CREATE TABLE IF NOT EXISTS purchase_receipts (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    lot_number VARCHAR(100),
+    expires_at TIMESTAMP,
+    unit_cost DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    reference VARCHAR(255),
+    actor VARCHAR(255) NOT NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_purchase_receipts_order ON purchase_receipts(purchase_order_id);
+-- serial_tracked_products (products whose every unit is registered by serial number when
+-- it is received, and named when it is shipped)
+CREATE TABLE IF NOT EXISTS serial_tracked_products (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- inventory_serials (one row per serialized unit: available -> reserved, when picked for a
+-- reservation, -> shipped, when the reservation is fulfilled, -> returned or damaged, when it
+-- comes back restocked or damaged with a customer return; available -> in_transit, when a
+-- transfer ships it, -> available, at the destination, when the transfer is received)
+CREATE TABLE IF NOT EXISTS inventory_serials (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    state VARCHAR(20) NOT NULL DEFAULT 'available',
+    reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    purchase_order_id INTEGER REFERENCES purchase_orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, serial_number)
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_serials_serial ON inventory_serials(serial_number);
+CREATE INDEX IF NOT EXISTS idx_inventory_serials_reservation ON inventory_serials(reservation_id) WHERE reservation_id IS NOT NULL;
+-- transfer_line_serials (the serial numbers of the units each transfer line moves)
+CREATE TABLE IF NOT EXISTS transfer_line_serials (
+    transfer_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    PRIMARY KEY (transfer_id, product_id, serial_number),
+    FOREIGN KEY (transfer_id, product_id) REFERENCES transfer_lines(transfer_id, product_id) ON DELETE CASCADE
+);
+-- inventory_movements (append-only ledger of every change to the stock, reserved and
+-- damaged quantities of an item, written in the same transaction as the change; a change
+-- to the stock records the cost of each unit and the value it added to the stock, in the
+-- currency of the product's costs, so summing value_delta up to any point gives the value
+-- of the stock then)
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL,
+    product_id INTEGER NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    stock_delta INTEGER NOT NULL DEFAULT 0,
+    reserved_delta INTEGER NOT NULL DEFAULT 0,
+    damaged_delta INTEGER NOT NULL DEFAULT 0,
+    stock_after INTEGER NOT NULL,
+    reserved_after INTEGER NOT NULL,
+    damaged_after INTEGER NOT NULL,
+    reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    transfer_id INTEGER REFERENCES transfers(id) ON DELETE SET NULL,
+    purchase_order_id INTEGER REFERENCES purchase_orders(id) ON DELETE SET NULL,
+    stocktake_id INTEGER REFERENCES stocktakes(id) ON DELETE SET NULL,
+    reference_id VARCHAR(255),
+    actor VARCHAR(255) NOT NULL,
+    unit_cost DECIMAL(12, 2),
+    value_delta DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (location_id, product_id) REFERENCES inventory(location_id, product_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product ON inventory_movements(product_id, created_at);
+-- the last movement of each item by a point in time holds its balances then
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_item ON inventory_movements(location_id, product_id, id);
+-- product_costing (costing method of a product; fifo when it has no row)
+CREATE TABLE IF NOT EXISTS product_costing (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    method VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT product_costing_method_check CHECK (method IN ('fifo', 'weighted_average'))
+);
+-- inventory_cost_layers (units of a product received at one unit cost, over every location;
+-- units leaving the stock consume the oldest layers first, and value is the cost of the
+-- remaining units; a product costed by weighted average has a single open layer; the layers
+-- of a product are all in the currency its stock was first costed in)
+CREATE TABLE IF NOT EXISTS inventory_cost_layers (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    movement_id BIGINT REFERENCES inventory_movements(id) ON DELETE SET NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining INTEGER NOT NULL CHECK (remaining >= 0),
+    unit_cost DECIMAL(12, 2) NOT NULL CHECK (unit_cost >= 0),
+    value DECIMAL(14, 2) NOT NULL CHECK (value >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_cost_layers_open ON inventory_cost_layers(product_id, id) WHERE remaining > 0;
 -- orders
 CREATE TABLE IF NOT EXISTS orders (
     id SERIAL PRIMARY KEY,
     customer_id INTEGER NOT NULL,
     status VARCHAR(50) NOT NULL DEFAULT 'pending',
     total_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    cancellation_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT orders_status_check CHECK (status IN (
+        'pending', 'confirmed', 'partially_fulfilled', 'fulfilled', 'shipped', 'delivered',
+        'cancelled', 'expired', 'returned'
+    ))
 );
 -- order_items
 CREATE TABLE IF NOT EXISTS order_items (
@@ -29,8 +330,111 @@ CREATE TABLE IF NOT EXISTS order_items (
     order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
     product_id INTEGER NOT NULL REFERENCES products(id),
     quantity INTEGER NOT NULL,
-    price_at_order DECIMAL(10, 2) NOT NULL
+    fulfilled_quantity INTEGER NOT NULL DEFAULT 0,
+    returned_quantity INTEGER NOT NULL DEFAULT 0,
+    price_at_order DECIMAL(10, 2) NOT NULL,
+    reservation_id INTEGER,
+    CONSTRAINT order_items_fulfilled_check CHECK (fulfilled_quantity BETWEEN 0 AND quantity),
+    CONSTRAINT order_items_returned_check CHECK (returned_quantity BETWEEN 0 AND fulfilled_quantity)
+);
+-- order_releases (stock still reserved by the items of a cancelled or expired order,
+-- recorded in the same transaction as the status change: pending -> released once the inventory released it;
+-- a background worker retries the pending ones; items without a recorded reservation are manual)
+CREATE TABLE IF NOT EXISTS order_releases (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id INTEGER NOT NULL UNIQUE REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL,
+    reservation_id INTEGER,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_order_releases_status ON order_releases(status, updated_at);
+-- order_item_lots (the inventory lots each order line was fulfilled from, to trace a recall
+-- back to the orders and customers that received a lot)
+CREATE TABLE IF NOT EXISTS order_item_lots (
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_item_id, lot_number)
+);
+CREATE INDEX IF NOT EXISTS idx_order_item_lots_lot ON order_item_lots(lot_number);
+-- order_item_serials (the serial numbers of the units each order line shipped)
+CREATE TABLE IF NOT EXISTS order_item_serials (
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    PRIMARY KEY (order_item_id, serial_number)
+);
+CREATE INDEX IF NOT EXISTS idx_order_item_serials_serial ON order_item_serials(serial_number);
+-- order_status_history (every status change of an order, including its creation)
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason TEXT,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history(order_id, id);
+-- order_returns (return authorizations against the fulfilled lines of an order)
+CREATE TABLE IF NOT EXISTS order_returns (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL DEFAULT 'authorized',
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    received_at TIMESTAMP
+);
+-- order_return_items
+CREATE TABLE IF NOT EXISTS order_return_items (
+    id SERIAL PRIMARY KEY,
+    return_id INTEGER NOT NULL REFERENCES order_returns(id) ON DELETE CASCADE,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL,
+    restocked INTEGER NOT NULL DEFAULT 0,
+    damaged INTEGER NOT NULL DEFAULT 0
+);
+-- order_sagas (durable state of the create order saga)
+CREATE TABLE IF NOT EXISTS order_sagas (
+    id SERIAL PRIMARY KEY,
+    customer_id INTEGER NOT NULL,
+    order_id INTEGER REFERENCES orders(id) ON DELETE SET NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'started',
+    failure_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_order_sagas_status ON order_sagas(status, updated_at);
+-- order_saga_steps (one row per inventory reservation made by a saga)
+CREATE TABLE IF NOT EXISTS order_saga_steps (
+    id SERIAL PRIMARY KEY,
+    saga_id INTEGER NOT NULL REFERENCES order_sagas(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'reserving',
+    reservation_id INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- idempotency_keys (responses of requests sent with an Idempotency-Key, shared by all services)
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(100) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    response JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    -- a request not completed by then (its caller crashed) may be taken over by a retry
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, idempotency_key)
 );
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
 
 -- populating with sample data
 -- initial products
@@ -41,12 +445,44 @@ INSERT INTO products (id, name, description, price, category) VALUES
     (4, 'Monitor', '24-inch LCD monitor', 199.99, 'Electronics'),
     (5, 'Desk Chair', 'Ergonomic office chair', 149.99, 'Furniture')
 ON CONFLICT (id) DO NOTHING;
--- initial inventory
-INSERT INTO inventory (product_id, stock, reserved) VALUES
-    (1, 50, 0),
-    (2, 100, 0),
-    (3, 25, 0),
-    (4, 30, 0),
-    (5, 15, 0)
-ON CONFLICT (product_id) DO NOTHING;
+-- initial locations (none are added to a database upgraded by db_upgrade.sql)
+INSERT INTO locations (code, name, latitude, longitude, priority)
+SELECT * FROM (VALUES
+    ('MAD', 'Madrid warehouse', 40.4168, -3.7038, 1),
+    ('BCN', 'Barcelona warehouse', 41.3874, 2.1686, 2),
+    ('VLC', 'Valencia warehouse', 39.4699, -0.3763, 3)
+) AS v(code, name, latitude, longitude, priority)
+WHERE NOT EXISTS (SELECT 1 FROM locations)
+ON CONFLICT (code) DO NOTHING;
+-- initial inventory, spread over the locations
+INSERT INTO inventory (location_id, product_id, stock, reserved)
+SELECT l.id, v.product_id, v.stock, 0
+FROM (VALUES
+    ('MAD', 1, 30), ('BCN', 1, 15), ('VLC', 1, 5),
+    ('MAD', 2, 60), ('BCN', 2, 40),
+    ('MAD', 3, 25),
+    ('MAD', 4, 20), ('VLC', 4, 10),
+    ('BCN', 5, 15)
+) AS v(code, product_id, stock)
+JOIN locations l ON l.code = v.code
+ON CONFLICT (location_id, product_id) DO NOTHING;
+-- opening balances of the initial inventory, so the ledger accounts for every unit, valued
+-- at 60% of the product's price
+INSERT INTO inventory_movements (location_id, product_id, reason, stock_delta, reserved_delta, damaged_delta, stock_after, reserved_after, damaged_after, actor, unit_cost, value_delta, currency)
+SELECT i.location_id, i.product_id, 'opening_balance', i.stock, i.reserved, i.damaged, i.stock, i.reserved, i.damaged, 'system:seed',
+    ROUND(p.price * 0.6, 2), i.stock * ROUND(p.price * 0.6, 2), p.currency
+FROM inventory i
+JOIN products p ON p.id = i.product_id
+WHERE NOT EXISTS (SELECT 1 FROM inventory_movements m WHERE m.location_id = i.location_id AND m.product_id = i.product_id);
+-- cost layers of the opening balances
+INSERT INTO inventory_cost_layers (product_id, movement_id, quantity, remaining, unit_cost, value, currency)
+SELECT m.product_id, m.id, m.stock_delta, m.stock_delta, m.unit_cost, m.value_delta, m.currency
+FROM inventory_movements m
+WHERE m.reason = 'opening_balance' AND m.stock_delta > 0
+    AND NOT EXISTS (SELECT 1 FROM inventory_cost_layers c WHERE c.movement_id = m.id);
+-- initial suppliers
+INSERT INTO suppliers (code, name, email) VALUES
+    ('ACME', 'Acme Electronics', 'orders@acme.example'),
+    ('OFFI', 'Office Furniture Co.', 'sales@officefurniture.example')
+ON CONFLICT (code) DO NOTHING;
 -- ** no initial orders
@@ -0,0 +1,61 @@
+-- upgrade of a database created by the first version of db_schema.sql (one inventory row
+-- per product, orders without currency or fulfilled quantities) to the current schema
+-- run it once before db_schema.sql, which then creates the tables added since:
+--   psql -v ON_ERROR_STOP=1 -f db_upgrade.sql && psql -v ON_ERROR_STOP=1 -f db_schema.sql
+-- a database created by the current db_schema.sql needs no upgrade
+BEGIN;
+
+-- products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- locations: the existing stock is moved to a single location; set its coordinates and
+-- add the other warehouses afterwards (db_schema.sql only seeds locations into an empty table)
+CREATE TABLE IF NOT EXISTS locations (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 100,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO locations (code, name, latitude, longitude, priority) VALUES
+    ('MAIN', 'Main warehouse', 0, 0, 1)
+ON CONFLICT (code) DO NOTHING;
+
+-- inventory: keyed by (location_id, product_id); the reserved quantities carried over have
+-- no reservation records, so they are released by the orders' manual releases
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS location_id INTEGER REFERENCES locations(id);
+UPDATE inventory SET location_id = (SELECT id FROM locations WHERE code = 'MAIN') WHERE location_id IS NULL;
+ALTER TABLE inventory ALTER COLUMN location_id SET NOT NULL;
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_pkey;
+ALTER TABLE inventory ADD PRIMARY KEY (location_id, product_id);
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS damaged INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS in_transit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS reorder_point INTEGER NOT NULL DEFAULT 0 CHECK (reorder_point >= 0);
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS low_stock BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN (
+    'pending', 'confirmed', 'partially_fulfilled', 'fulfilled', 'shipped', 'delivered',
+    'cancelled', 'expired', 'returned'
+));
+
+-- order_items: the items of fulfilled orders were fulfilled in full
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS fulfilled_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS returned_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS reservation_id INTEGER;
+UPDATE order_items SET fulfilled_quantity = quantity
+WHERE order_id IN (SELECT id FROM orders WHERE status = 'fulfilled') AND fulfilled_quantity = 0;
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_fulfilled_check;
+ALTER TABLE order_items ADD CONSTRAINT order_items_fulfilled_check CHECK (fulfilled_quantity BETWEEN 0 AND quantity);
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_returned_check;
+ALTER TABLE order_items ADD CONSTRAINT order_items_returned_check CHECK (returned_quantity BETWEEN 0 AND fulfilled_quantity);
+
+COMMIT;
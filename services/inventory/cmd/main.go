@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 
 	"fmt"
@@ -11,10 +12,13 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	inventory_controller "inventory-service/internal/controller"
 	inventory_handler_http "inventory-service/internal/handler"
+	inventory_notifier "inventory-service/internal/notifier"
 	inventory_repository "inventory-service/internal/repository"
+	dmodel "inventory-service/pkg"
 
 	pb "inventory-service/proto/inventory"
 
@@ -69,14 +73,24 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
 func main() {
 	var err error
-	//var ctx context.Context
+	var ctx context.Context
+	var cancel context.CancelFunc
 
 	var port int
 	var grpcPort int
 	var datarepo *inventory_repository.DataRepo_Inventory
 	var controller *inventory_controller.Controller_Inventory
+	var notifier inventory_notifier.Notifier
 	var handler *inventory_handler_http.Handler_Inventory
 	var grpcHandler *inventory_handler_http.Handler_Inventory_GRPC
 
@@ -105,16 +119,38 @@ func main() {
 	}
 	log.Printf("Inventory service starting on gRPC port %d", grpcPort)
 
+	// retention window of idempotency keys, and how long a request that never finished
+	// (a crash) holds its key before a retry may execute it again
+	idempotencyKeyTTL := getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	idempotencyKeyLease := getEnvDuration("IDEMPOTENCY_KEY_LEASE", time.Minute)
+
+	// policy picking the location of reservations that do not name one
+	allocationPolicy := getEnv("ALLOCATION_POLICY", dmodel.AllocationPriority)
+	if !dmodel.IsAllocationPolicy(allocationPolicy) {
+		log.Fatalf("Unknown ALLOCATION_POLICY %q (use priority, most_stock or nearest)", allocationPolicy)
+	}
+	log.Printf("Allocating reservations by %s", allocationPolicy)
+
+	// stock alerts are posted to ALERT_WEBHOOK_URL, or written to the log without one
+	if webhookURL := getEnv("ALERT_WEBHOOK_URL", ""); webhookURL != "" {
+		notifier = inventory_notifier.NewWebhook(webhookURL)
+		log.Printf("Delivering stock alerts to %s", webhookURL)
+	} else {
+		notifier = inventory_notifier.NewLog()
+		log.Println("Writing stock alerts to the log")
+	}
+
 	// setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// initializing context
-	//ctx = context.Background()
+	// initializing context (cancelled on shutdown to stop background workers)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
 	// volatile data repository
 	datarepo = inventory_repository.New(db)
 	// controller
-	controller = inventory_controller.New(datarepo)
+	controller = inventory_controller.New(datarepo, notifier, idempotencyKeyTTL, idempotencyKeyLease, allocationPolicy)
 	// handler
 	handler = inventory_handler_http.New(controller)
 	// gRPC handler
@@ -125,24 +161,104 @@ func main() {
 	// service endpoints
 	// -------------------------------------------------------------------
 	r := mux.NewRouter()
+	// the caller of every request is recorded in the stock movement ledger
+	r.Use(inventory_handler_http.AddActor)
 	// CORS preflight (OPTIONS) requests for all endpoints
-	r.PathPrefix("/inventory").Methods(http.MethodOptions).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	preflight := func(w http.ResponseWriter, r *http.Request) {
 		inventory_handler_http.AddCORSHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
 		})).ServeHTTP(w, r)
-	})
-	// GET all inventory
+	}
+	r.PathPrefix("/inventory").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/locations").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/transfers").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/stocktakes").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/suppliers").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/purchase-orders").Methods(http.MethodOptions).HandlerFunc(preflight)
+	// GET all inventory (totals per product, or the items at ?location_id=)
 	r.Handle("/inventory", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_All))).Methods(http.MethodGet)
-	// GET inventory by productId
+	// GET items below their reorder point (at ?location_id=)
+	r.Handle("/inventory/low-stock", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_LowStock))).Methods(http.MethodGet)
+	// GET units still expected from open purchase orders (?product_id=)
+	r.Handle("/inventory/incoming", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Incoming))).Methods(http.MethodGet)
+	// POST import stock levels from a CSV or NDJSON file (?mode=, ?dry_run=, ?reference=), GET export them (?format=, ?location_id=)
+	r.Handle("/inventory/import", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Import_Inventory))).Methods(http.MethodPost)
+	r.Handle("/inventory/export", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Export_Inventory))).Methods(http.MethodGet)
+	// GET value of the stock on hand per product and category (?as_of=, ?location_id=, ?category=)
+	r.Handle("/inventory/valuation", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Valuation))).Methods(http.MethodGet)
+	// GET serialized units with a serial number, across products
+	r.Handle("/inventory/serials/{serialNumber}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Serial))).Methods(http.MethodGet)
+	// GET inventory by productId (totals, or the item at ?location_id=)
 	r.Handle("/inventory/{productId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_ByProductID))).Methods(http.MethodGet)
-	// PUT update stock
+	// PUT update stock (If-Match: "<version>" to reject stale writes)
 	r.Handle("/inventory/{productId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Update_Stock))).Methods(http.MethodPut)
+	// PUT set the reorder point and quantity
+	r.Handle("/inventory/{productId}/reorder", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Set_ReorderPoint))).Methods(http.MethodPut)
+	// POST adjust stock by a signed delta
+	r.Handle("/inventory/{productId}/adjust", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Adjust_Stock))).Methods(http.MethodPost)
 	// POST reserve stock
 	r.Handle("/inventory/{productId}/reserve", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Reserve_Stock))).Methods(http.MethodPost)
+	// GET stock movements of a product
+	r.Handle("/inventory/{productId}/movements", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Movements))).Methods(http.MethodGet)
+	// GET lots of a product holding stock (at ?location_id=, or every location)
+	r.Handle("/inventory/{productId}/lots", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Lots))).Methods(http.MethodGet)
+	// PUT turn serial tracking on or off, GET serialized units of a product (?state=, ?location_id=)
+	r.Handle("/inventory/{productId}/serial-tracking", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Set_SerialTracking))).Methods(http.MethodPut)
+	r.Handle("/inventory/{productId}/serials", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Serials))).Methods(http.MethodGet)
+	// GET costing method and cost layers of a product, PUT set its costing method
+	r.Handle("/inventory/{productId}/costing", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Costing))).Methods(http.MethodGet)
+	r.Handle("/inventory/{productId}/costing", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Set_CostingMethod))).Methods(http.MethodPut)
+	// GET active reservations of a product
+	r.Handle("/inventory/{productId}/reservations", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_ProductReservations))).Methods(http.MethodGet)
+	// GET reservation by reservationId
+	r.Handle("/inventory/reservations/{reservationId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Reservation))).Methods(http.MethodGet)
 	// POST release reservation
-	r.Handle("/inventory/{productId}/release_reservation", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Release_Reservation))).Methods(http.MethodPost)
+	r.Handle("/inventory/reservations/{reservationId}/release", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Release_Reservation))).Methods(http.MethodPost)
 	// POST fulfill reservation
-	r.Handle("/inventory/{productId}/fulfill", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Fulfill_Reservation))).Methods(http.MethodPost)
+	r.Handle("/inventory/reservations/{reservationId}/fulfill", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Fulfill_Reservation))).Methods(http.MethodPost)
+	// POST pick serialized units for a reservation
+	r.Handle("/inventory/reservations/{reservationId}/serials", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Reserve_Serials))).Methods(http.MethodPost)
+	// POST receive returned stock
+	r.Handle("/inventory/{productId}/return", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Receive_Return))).Methods(http.MethodPost)
+	// POST batch operations over several products (all or nothing)
+	r.Handle("/inventory/reserve", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Reserve_StockBatch))).Methods(http.MethodPost)
+	r.Handle("/inventory/release_reservation", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Release_ReservationBatch))).Methods(http.MethodPost)
+	r.Handle("/inventory/fulfill", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Fulfill_ReservationBatch))).Methods(http.MethodPost)
+	// GET all locations, GET location by locationId, POST create location
+	r.Handle("/locations", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Locations))).Methods(http.MethodGet)
+	r.Handle("/locations/{locationId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Location))).Methods(http.MethodGet)
+	r.Handle("/locations", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Location))).Methods(http.MethodPost)
+	// GET transfers (?state=, ?location_id=), GET transfer by transferId, POST create draft transfer
+	r.Handle("/transfers", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Transfers))).Methods(http.MethodGet)
+	r.Handle("/transfers/{transferId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Transfer))).Methods(http.MethodGet)
+	r.Handle("/transfers", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Transfer))).Methods(http.MethodPost)
+	// POST ship a draft transfer, POST receive an in transit transfer
+	r.Handle("/transfers/{transferId}/ship", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Ship_Transfer))).Methods(http.MethodPost)
+	r.Handle("/transfers/{transferId}/receive", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Receive_Transfer))).Methods(http.MethodPost)
+	// GET stocktakes (?state=, ?location_id=), GET stocktake by stocktakeId, POST open stocktake
+	r.Handle("/stocktakes", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Stocktakes))).Methods(http.MethodGet)
+	// GET shrinkage per product of posted stocktakes (?location_id=, ?stocktake_id=, ?from=, ?to=)
+	r.Handle("/stocktakes/shrinkage", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Shrinkage))).Methods(http.MethodGet)
+	r.Handle("/stocktakes/{stocktakeId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Stocktake))).Methods(http.MethodGet)
+	r.Handle("/stocktakes", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Stocktake))).Methods(http.MethodPost)
+	// POST submit a counter's counts, POST approve or reject variances
+	r.Handle("/stocktakes/{stocktakeId}/counts", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Count_Stocktake))).Methods(http.MethodPost)
+	r.Handle("/stocktakes/{stocktakeId}/review", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Review_Stocktake))).Methods(http.MethodPost)
+	// POST post the approved variances as adjustments, POST cancel an open stocktake
+	r.Handle("/stocktakes/{stocktakeId}/post", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Post_Stocktake))).Methods(http.MethodPost)
+	r.Handle("/stocktakes/{stocktakeId}/cancel", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Cancel_Stocktake))).Methods(http.MethodPost)
+	// GET all suppliers, GET supplier by supplierId, POST create supplier
+	r.Handle("/suppliers", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Suppliers))).Methods(http.MethodGet)
+	r.Handle("/suppliers/{supplierId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Supplier))).Methods(http.MethodGet)
+	r.Handle("/suppliers", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Supplier))).Methods(http.MethodPost)
+	// GET purchase orders (?state=, ?supplier_id=), GET purchase order by purchaseOrderId, POST place purchase order
+	r.Handle("/purchase-orders", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_PurchaseOrders))).Methods(http.MethodGet)
+	r.Handle("/purchase-orders/{purchaseOrderId}", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_PurchaseOrder))).Methods(http.MethodGet)
+	r.Handle("/purchase-orders", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_PurchaseOrder))).Methods(http.MethodPost)
+	// POST receive goods for a purchase order, POST cancel its outstanding units, GET its receipts
+	r.Handle("/purchase-orders/{purchaseOrderId}/receive", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Receive_PurchaseOrder))).Methods(http.MethodPost)
+	r.Handle("/purchase-orders/{purchaseOrderId}/cancel", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Cancel_PurchaseOrder))).Methods(http.MethodPost)
+	r.Handle("/purchase-orders/{purchaseOrderId}/receipts", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Receipts))).Methods(http.MethodGet)
 	// Health check endpoint
 	r.Handle("/health", inventory_handler_http.AddCORSHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -153,7 +269,10 @@ func main() {
 	// -------------------------------------------------------------------
 	// Start gRPC server
 	// -------------------------------------------------------------------
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(inventory_handler_http.ActorInterceptor),
+		grpc.StreamInterceptor(inventory_handler_http.ActorStreamInterceptor),
+	)
 	pb.RegisterInventoryServiceServer(grpcServer, grpcHandler)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
@@ -169,6 +288,17 @@ func main() {
 	}()
 	// -------------------------------------------------------------------
 
+	// -------------------------------------------------------------------
+	// Start background workers
+	// -------------------------------------------------------------------
+	// deletes idempotency keys past their retention window
+	go controller.Run_IdempotencyPurge(ctx, time.Hour)
+	// releases what is left of reservations past their expiry
+	go controller.Run_ReservationExpiry(ctx, getEnvDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute))
+	// hands the stock alerts of items crossing their reorder point to the notifier
+	go controller.Run_AlertDelivery(ctx, getEnvDuration("ALERT_DELIVERY_INTERVAL", 10*time.Second))
+	// -------------------------------------------------------------------
+
 	// -------------------------------------------------------------------
 	// Start HTTP server
 	// -------------------------------------------------------------------
@@ -190,6 +320,7 @@ func main() {
 	// -------------------------------------------------------------------
 	<-sigChan
 	log.Println("Received shutdown signal, shutting down gracefully...")
+	cancel()
 	grpcServer.GracefulStop()
 	log.Println("Servers stopped")
 	// -------------------------------------------------------------------
@@ -0,0 +1,108 @@
+package inventory_controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// maximum number of stock alerts delivered per pass of the delivery worker
+const alertBatchSize = 100
+
+// time a worker holds the alerts it claimed; undelivered ones are retried after it
+const alertLease = time.Minute
+
+// -------------------------------------------------------------------
+// reorder points and stock alerts
+// -------------------------------------------------------------------
+
+// Get_LowStock lists the items with fewer available units than their reorder point, at
+// a location or at every location when locationID is 0
+func (c *Controller_Inventory) Get_LowStock(ctx context.Context, locationID int) ([]*dmodel.InventoryItem, error) {
+	if locationID < 0 {
+		return nil, internal.ErrLocationNotFound
+	}
+
+	res, err := c.repo.Get_LowStock(ctx, locationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Set_ReorderPoint sets below how many available units an item is low on stock (never
+// when 0) and how many units to reorder then, at a location or at the product's preferred
+// location when locationID is 0 (the product's totals are returned then)
+func (c *Controller_Inventory) Set_ReorderPoint(ctx context.Context, productID, locationID, reorderPoint, reorderQuantity int) (*dmodel.InventoryItem, error) {
+	if reorderPoint < 0 || reorderQuantity < 0 {
+		return nil, internal.ErrInvalidReorderPoint
+	}
+
+	res, err := c.repo.Set_ReorderPoint(ctx, productID, locationID, reorderPoint, reorderQuantity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
+// stock alert delivery
+// -------------------------------------------------------------------
+
+// Run_AlertDelivery hands the stock alerts raised by stock changes to the notifier every
+// interval until the context is cancelled
+// alerts are delivered at least once, in the order they were raised; a failed delivery
+// stops the pass and is retried once its claim runs out
+func (c *Controller_Inventory) Run_AlertDelivery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep going while full batches are delivered, so a backlog is cleared in one pass
+		for {
+			n, err := c.deliverAlerts(ctx)
+			if err != nil {
+				log.Printf("Error delivering stock alerts: %v", err)
+				break
+			}
+			if n < alertBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// deliver a batch of alerts, returning how many were delivered
+func (c *Controller_Inventory) deliverAlerts(ctx context.Context) (int, error) {
+	now := time.Now()
+	alerts, err := c.repo.Claim_StockAlerts(ctx, now, now.Add(alertLease), alertBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, alert := range alerts {
+		if err := c.notifier.Notify(ctx, alert); err != nil {
+			return i, err
+		}
+		if err := c.repo.Complete_StockAlert(ctx, alert.ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(alerts), nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,133 @@
+package inventory_controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// batch operations
+// -------------------------------------------------------------------
+
+// the batch operations apply every line or none of them; when a line cannot be applied
+// the error is an *internal.BatchError listing every failed line
+
+// Reserve_StockBatch makes one reservation per product and location for owner; lines of
+// the same product and location are merged, and lines without a location are reserved
+// where alloc's policy picks
+func (c *Controller_Inventory) Reserve_StockBatch(ctx context.Context, lines []dmodel.StockLine, owner string, ttl time.Duration, alloc dmodel.Allocation) (*dmodel.BatchUpdate, error) {
+	if owner == "" {
+		return nil, internal.ErrInvalidOwner
+	}
+	if ttl < 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	alloc, err := c.allocation(alloc)
+	if err != nil {
+		return nil, err
+	}
+	lines, err = normalizeProductLines(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Reserve_StockBatch(ctx, lines, owner, expiry(ttl), alloc, internal.ActorFromContext(ctx))
+}
+
+func (c *Controller_Inventory) Release_ReservationBatch(ctx context.Context, lines []dmodel.StockLine) (*dmodel.BatchUpdate, error) {
+	lines, err := normalizeReservationLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if len(line.SerialNumbers) > 0 {
+			return nil, fmt.Errorf("%w: released units of reservation %d are not named", internal.ErrInvalidSerial, line.ReservationID)
+		}
+	}
+
+	return c.repo.Release_ReservationBatch(ctx, lines, internal.ActorFromContext(ctx))
+}
+
+func (c *Controller_Inventory) Fulfill_ReservationBatch(ctx context.Context, lines []dmodel.StockLine) (*dmodel.BatchUpdate, error) {
+	lines, err := normalizeReservationLines(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Fulfill_ReservationBatch(ctx, lines, internal.ActorFromContext(ctx))
+}
+
+// merge the lines of the same product and location, and sort them by product ID with the
+// lines naming a location first, so they are allocated before the policy picks for the rest
+// a line without a positive quantity makes the whole request invalid
+func normalizeProductLines(lines []dmodel.StockLine) ([]dmodel.StockLine, error) {
+	if len(lines) == 0 {
+		return nil, internal.ErrEmptyBatch
+	}
+
+	type lineKey struct{ productID, locationID int }
+	merged := make(map[lineKey]int, len(lines))
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d", internal.ErrInvalidQuantity, line.Quantity, line.ProductID)
+		}
+		if line.LocationID < 0 {
+			return nil, fmt.Errorf("%w: location %d", internal.ErrLocationNotFound, line.LocationID)
+		}
+		merged[lineKey{line.ProductID, line.LocationID}] += line.Quantity
+	}
+
+	res := make([]dmodel.StockLine, 0, len(merged))
+	for key, quantity := range merged {
+		res = append(res, dmodel.StockLine{ProductID: key.productID, LocationID: key.locationID, Quantity: quantity})
+	}
+	// a location of 0 (none) sorts last
+	noLocation := func(line dmodel.StockLine) int {
+		if line.LocationID == 0 {
+			return 1
+		}
+		return 0
+	}
+	slices.SortFunc(res, func(a, b dmodel.StockLine) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(noLocation(a), noLocation(b)), cmp.Compare(a.LocationID, b.LocationID))
+	})
+
+	return res, nil
+}
+
+// sort the lines by reservation ID; each reservation may only be listed once, and a
+// quantity of 0 stands for every remaining unit
+// the serial numbers of a line are trimmed, and may only be listed once
+func normalizeReservationLines(lines []dmodel.StockLine) ([]dmodel.StockLine, error) {
+	if len(lines) == 0 {
+		return nil, internal.ErrEmptyBatch
+	}
+
+	res := slices.Clone(lines)
+	slices.SortFunc(res, func(a, b dmodel.StockLine) int {
+		return cmp.Compare(a.ReservationID, b.ReservationID)
+	})
+	for i, line := range res {
+		if line.Quantity < 0 {
+			return nil, fmt.Errorf("%w: %d units of reservation %d", internal.ErrInvalidQuantity, line.Quantity, line.ReservationID)
+		}
+		if i > 0 && res[i-1].ReservationID == line.ReservationID {
+			return nil, fmt.Errorf("%w: reservation %d is listed twice", internal.ErrInvalidQuantity, line.ReservationID)
+		}
+		serials, err := normalizeSerials(line.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+		res[i].SerialNumbers = serials
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,89 @@
+package inventory_controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// bulk import and export
+// -------------------------------------------------------------------
+
+// Import_Inventory sets the stock of the items named by rows; invalid lists the rows the
+// caller could not read, which count as failed rows. An all or nothing import saves
+// nothing when a row fails (its result still reports every failed row), a best effort
+// import skips them, and a dry run reports what would happen without saving anything
+func (c *Controller_Inventory) Import_Inventory(ctx context.Context, rows []dmodel.ImportRow, invalid []dmodel.ImportError, options dmodel.ImportOptions) (*dmodel.ImportResult, error) {
+	switch options.Mode {
+	case "":
+		options.Mode = dmodel.ImportAllOrNothing
+	case dmodel.ImportAllOrNothing, dmodel.ImportBestEffort:
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", internal.ErrInvalidImport, options.Mode)
+	}
+	if len(rows) == 0 && len(invalid) == 0 {
+		return nil, fmt.Errorf("%w: no rows", internal.ErrInvalidImport)
+	}
+	options.Reference = strings.TrimSpace(options.Reference)
+	if options.Reference == "" {
+		options.Reference = dmodel.MovementImport
+	}
+
+	failed := slices.Clone(invalid)
+	valid := make([]dmodel.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		switch {
+		case row.ProductID <= 0:
+			failed = append(failed, dmodel.ImportError{Line: row.Line, Reason: fmt.Sprintf("invalid product_id %d", row.ProductID)})
+		case row.LocationID < 0:
+			failed = append(failed, dmodel.ImportError{Line: row.Line, ProductID: row.ProductID, Reason: fmt.Sprintf("invalid location_id %d", row.LocationID)})
+		case row.Stock < 0:
+			failed = append(failed, dmodel.ImportError{Line: row.Line, ProductID: row.ProductID, LocationID: row.LocationID, Reason: internal.ErrStockBelowReserved.Error()})
+		default:
+			valid = append(valid, row)
+		}
+	}
+
+	// rows already failed reject an all or nothing import, but the others are still
+	// checked so every failed row is reported at once
+	bestEffort := options.Mode == dmodel.ImportBestEffort
+	dryRun := options.DryRun || (len(failed) > 0 && !bestEffort)
+
+	res := &dmodel.ImportResult{Errors: []dmodel.ImportError{}}
+	if len(valid) > 0 {
+		var err error
+		res, err = c.repo.Import_Inventory(ctx, valid, bestEffort, dryRun, options.Reference, internal.ActorFromContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Mode = options.Mode
+	res.DryRun = options.DryRun
+	res.Rows = len(rows) + len(invalid)
+	res.Errors = append(res.Errors, failed...)
+	slices.SortStableFunc(res.Errors, func(a, b dmodel.ImportError) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	res.Failed = len(res.Errors)
+	if !res.Committed && !options.DryRun {
+		// the import was rejected, so no row was applied
+		res.Applied = 0
+	}
+
+	return res, nil
+}
+
+// Export_Inventory passes every item (at one location, or at all of them when locationID
+// is 0) to write, in (product_id, location_id) order
+func (c *Controller_Inventory) Export_Inventory(ctx context.Context, locationID int, write func(*dmodel.InventoryItem) error) error {
+	return c.repo.Export_Inventory(ctx, locationID, write)
+}
+
+// -------------------------------------------------------------------
@@ -2,31 +2,121 @@ package inventory_controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	internal "inventory-service/internal"
 	dmodel "inventory-service/pkg"
+	"shared/money"
 )
 
 type if_repo_inventory interface {
-	Get_All(_ context.Context) ([]*dmodel.InventoryItem, error)
-	Get_ByProductID(_ context.Context, productID int) (*dmodel.InventoryItem, error)
-	Update_Stock(_ context.Context, productID, stock int) error
-	Reserve_Stock(_ context.Context, productID, amount_reserved int) error
-	Release_Reservation(_ context.Context, productID, amount_released int) error
-	Fulfill_Reservation(_ context.Context, productID, amount_fulfilled int) error
+	Get_All(_ context.Context, locationID int) ([]*dmodel.InventoryItem, error)
+	Get_ByProductID(_ context.Context, productID, locationID int) (*dmodel.InventoryItem, error)
+	Get_AllAsOf(_ context.Context, locationID int, asOf time.Time) ([]*dmodel.InventoryItem, error)
+	Get_ByProductIDAsOf(_ context.Context, productID, locationID int, asOf time.Time) (*dmodel.InventoryItem, error)
+	Update_Stock(_ context.Context, productID, locationID, stock, expectedVersion int, reference, actor string) (*dmodel.InventoryItem, error)
+	Adjust_Stock(_ context.Context, productID, locationID, delta int, unitCost *money.Money, lotNumber string, expiresAt *time.Time, reason, reference, actor string) (*dmodel.InventoryItem, error)
+	Receive_Return(_ context.Context, productID, locationID, restocked, damaged int, serials, damagedSerials []string, lots []dmodel.LotQuantity, reference, actor string) (*dmodel.InventoryItem, error)
+	// reorder points and stock alerts
+	Get_LowStock(_ context.Context, locationID int) ([]*dmodel.InventoryItem, error)
+	Set_ReorderPoint(_ context.Context, productID, locationID, reorderPoint, reorderQuantity int) (*dmodel.InventoryItem, error)
+	Claim_StockAlerts(_ context.Context, now, leaseUntil time.Time, limit int) ([]*dmodel.StockAlert, error)
+	Complete_StockAlert(_ context.Context, alertID int) error
+	// locations
+	Get_Locations(_ context.Context) ([]*dmodel.Location, error)
+	Get_Location(_ context.Context, locationID int) (*dmodel.Location, error)
+	Create_Location(_ context.Context, location *dmodel.Location) (*dmodel.Location, error)
+	// transfers
+	Get_Transfer(_ context.Context, transferID int) (*dmodel.Transfer, error)
+	Get_Transfers(_ context.Context, state string, locationID int) ([]*dmodel.Transfer, error)
+	Create_Transfer(_ context.Context, transfer *dmodel.Transfer) (*dmodel.Transfer, error)
+	Ship_Transfer(_ context.Context, transferID int, actor string) (*dmodel.TransferUpdate, error)
+	Receive_Transfer(_ context.Context, transferID int, actor string) (*dmodel.TransferUpdate, error)
+	// stocktakes
+	Get_Stocktake(_ context.Context, stocktakeID int) (*dmodel.Stocktake, error)
+	Get_Stocktakes(_ context.Context, state string, locationID int) ([]*dmodel.Stocktake, error)
+	Create_Stocktake(_ context.Context, stocktake *dmodel.Stocktake, productIDs []int) (*dmodel.Stocktake, error)
+	Count_Stocktake(_ context.Context, stocktakeID int, counter string, lines []dmodel.StocktakeCountLine) (*dmodel.Stocktake, error)
+	Review_Stocktake(_ context.Context, stocktakeID int, approve, reject []int) (*dmodel.Stocktake, error)
+	Post_Stocktake(_ context.Context, stocktakeID int, actor string) (*dmodel.StocktakeUpdate, error)
+	Cancel_Stocktake(_ context.Context, stocktakeID int) (*dmodel.Stocktake, error)
+	Get_Shrinkage(_ context.Context, filter dmodel.ShrinkageFilter) ([]*dmodel.ShrinkageLine, error)
+	// purchasing
+	Get_Suppliers(_ context.Context) ([]*dmodel.Supplier, error)
+	Get_Supplier(_ context.Context, supplierID int) (*dmodel.Supplier, error)
+	Create_Supplier(_ context.Context, supplier *dmodel.Supplier) (*dmodel.Supplier, error)
+	Get_PurchaseOrders(_ context.Context, state string, supplierID int) ([]*dmodel.PurchaseOrder, error)
+	Get_PurchaseOrder(_ context.Context, purchaseOrderID int) (*dmodel.PurchaseOrder, error)
+	Create_PurchaseOrder(_ context.Context, order *dmodel.PurchaseOrder) (*dmodel.PurchaseOrder, error)
+	Cancel_PurchaseOrder(_ context.Context, purchaseOrderID int) (*dmodel.PurchaseOrder, error)
+	Receive_PurchaseOrder(_ context.Context, purchaseOrderID int, lines []dmodel.ReceiptLine, reference, actor string) (*dmodel.ReceiptUpdate, error)
+	Get_Receipts(_ context.Context, purchaseOrderID int) ([]*dmodel.Receipt, error)
+	Get_Incoming(_ context.Context, productID int) ([]*dmodel.IncomingStock, error)
+	// lots
+	Get_Lots(_ context.Context, productID, locationID int) ([]*dmodel.Lot, error)
+	// serial numbers
+	Get_Serials(_ context.Context, filter dmodel.SerialFilter) ([]*dmodel.SerialUnit, error)
+	Set_SerialTracking(_ context.Context, productID int, enabled bool) (*dmodel.InventoryItem, error)
+	Reserve_Serials(_ context.Context, reservationID int, serials []string) ([]*dmodel.SerialUnit, error)
+	// reservations
+	Get_Reservation(_ context.Context, reservationID int) (*dmodel.Reservation, error)
+	Get_ActiveReservations(_ context.Context, productID int, owner string) ([]*dmodel.Reservation, error)
+	Reserve_Stock(_ context.Context, productID, quantity int, owner string, expiresAt *time.Time, alloc dmodel.Allocation, actor string) (*dmodel.ReservationUpdate, error)
+	Release_Reservation(_ context.Context, reservationID, quantity int, actor string) (*dmodel.ReservationUpdate, error)
+	Fulfill_Reservation(_ context.Context, reservationID, quantity int, serials []string, actor string) (*dmodel.ReservationUpdate, error)
+	Expire_Reservations(_ context.Context, now time.Time, limit int, actor string) (int, error)
+	// batch operations
+	Reserve_StockBatch(_ context.Context, lines []dmodel.StockLine, owner string, expiresAt *time.Time, alloc dmodel.Allocation, actor string) (*dmodel.BatchUpdate, error)
+	Release_ReservationBatch(_ context.Context, lines []dmodel.StockLine, actor string) (*dmodel.BatchUpdate, error)
+	Fulfill_ReservationBatch(_ context.Context, lines []dmodel.StockLine, actor string) (*dmodel.BatchUpdate, error)
+	// costing and valuation
+	Get_Costing(_ context.Context, productID int) (*dmodel.ProductCosting, error)
+	Set_CostingMethod(_ context.Context, productID int, method string) (*dmodel.ProductCosting, error)
+	Get_Valuation(_ context.Context, filter dmodel.ValuationFilter) (*dmodel.Valuation, error)
+	// bulk import and export
+	Import_Inventory(_ context.Context, rows []dmodel.ImportRow, bestEffort, dryRun bool, reference, actor string) (*dmodel.ImportResult, error)
+	Export_Inventory(_ context.Context, locationID int, write func(*dmodel.InventoryItem) error) error
+	// stock movements
+	Get_Movements(_ context.Context, productID int, filter dmodel.MovementFilter) ([]*dmodel.Movement, error)
+	// idempotency keys
+	Begin_IdempotentRequest(_ context.Context, scope, key, requestHash string, expiredBefore time.Time, lease time.Duration) ([]byte, error)
+	Complete_IdempotentRequest(_ context.Context, scope, key string, response []byte) error
+	Delete_IdempotentRequest(_ context.Context, scope, key string) error
+	Purge_IdempotencyKeys(_ context.Context, createdBefore time.Time) (int64, error)
+}
+
+// delivers the stock alerts raised when items cross their reorder point
+type if_notifier interface {
+	Notify(_ context.Context, alert *dmodel.StockAlert) error
 }
 
 type Controller_Inventory struct {
-	repo if_repo_inventory
+	repo     if_repo_inventory
+	notifier if_notifier
+	// retention window of idempotency keys, and how long an unfinished request holds its key
+	idempotencyTTL   time.Duration
+	idempotencyLease time.Duration
+	// policy picking the location of reservations that do not name one
+	allocationPolicy string
 }
 
-func New(repo if_repo_inventory) *Controller_Inventory {
+func New(repo if_repo_inventory, notifier if_notifier, idempotencyTTL, idempotencyLease time.Duration, allocationPolicy string) *Controller_Inventory {
 	return &Controller_Inventory{
-		repo: repo,
+		repo:             repo,
+		notifier:         notifier,
+		idempotencyTTL:   idempotencyTTL,
+		idempotencyLease: idempotencyLease,
+		allocationPolicy: allocationPolicy,
 	}
 }
 
-func (c *Controller_Inventory) Get_All(ctx context.Context) ([]*dmodel.InventoryItem, error) {
-	res, err := c.repo.Get_All(ctx)
+// Get_All lists the items at a location, or the totals of every product with their per
+// location breakdown when locationID is 0
+func (c *Controller_Inventory) Get_All(ctx context.Context, locationID int) ([]*dmodel.InventoryItem, error) {
+	res, err := c.repo.Get_All(ctx, locationID)
 
 	if err != nil {
 		return nil, err
@@ -35,8 +125,10 @@ func (c *Controller_Inventory) Get_All(ctx context.Context) ([]*dmodel.Inventory
 	return res, nil
 }
 
-func (c *Controller_Inventory) Get_ByProductID(ctx context.Context, productID int) (*dmodel.InventoryItem, error) {
-	res, err := c.repo.Get_ByProductID(ctx, productID)
+// Get_ByProductID returns the item of a product at a location, or its totals with their
+// per location breakdown when locationID is 0
+func (c *Controller_Inventory) Get_ByProductID(ctx context.Context, productID, locationID int) (*dmodel.InventoryItem, error) {
+	res, err := c.repo.Get_ByProductID(ctx, productID, locationID)
 
 	if err != nil {
 		return nil, err
@@ -45,42 +137,131 @@ func (c *Controller_Inventory) Get_ByProductID(ctx context.Context, productID in
 	return res, nil
 }
 
-func (c *Controller_Inventory) Update_Stock(ctx context.Context, productID, stock int) error {
-	err := c.repo.Update_Stock(ctx, productID, stock)
+// Get_AllAsOf is Get_All as of a past point in time, rebuilt from the movement ledger
+func (c *Controller_Inventory) Get_AllAsOf(ctx context.Context, locationID int, asOf time.Time) ([]*dmodel.InventoryItem, error) {
+	if asOf.After(time.Now()) {
+		return nil, fmt.Errorf("%w: as_of is in the future", internal.ErrInvalidTimeRange)
+	}
+
+	res, err := c.repo.Get_AllAsOf(ctx, locationID, asOf)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return res, nil
 }
 
-func (c *Controller_Inventory) Reserve_Stock(ctx context.Context, productID, amount_reserved int) error {
-	err := c.repo.Reserve_Stock(ctx, productID, amount_reserved)
+// Get_ByProductIDAsOf is Get_ByProductID as of a past point in time, rebuilt from the
+// movement ledger; a product without movements by then is not found
+func (c *Controller_Inventory) Get_ByProductIDAsOf(ctx context.Context, productID, locationID int, asOf time.Time) (*dmodel.InventoryItem, error) {
+	if asOf.After(time.Now()) {
+		return nil, fmt.Errorf("%w: as_of is in the future", internal.ErrInvalidTimeRange)
+	}
+
+	res, err := c.repo.Get_ByProductIDAsOf(ctx, productID, locationID, asOf)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return res, nil
 }
 
-func (c *Controller_Inventory) Release_Reservation(ctx context.Context, productID, amount_released int) error {
-	err := c.repo.Release_Reservation(ctx, productID, amount_released)
+// the stock changes apply to the item at locationID, or at the product's preferred
+// location when locationID is 0 (the product's totals are returned then)
+
+// Update_Stock sets the stock of an item, which cannot drop below zero or the reserved
+// quantity; reference (optional) is recorded with the movement
+// locationID can only be 0 when the product is stocked at a single location
+// when expectedVersion is not 0 a stale write fails with ErrVersionMismatch
+func (c *Controller_Inventory) Update_Stock(ctx context.Context, productID, locationID, stock, expectedVersion int, reference string) (*dmodel.InventoryItem, error) {
+	if expectedVersion < 0 {
+		return nil, internal.ErrVersionMismatch
+	}
+
+	res, err := c.repo.Update_Stock(ctx, productID, locationID, stock, expectedVersion, reference, internal.ActorFromContext(ctx))
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Adjust_Stock adds delta units (removes them when negative) to the stock of an item for
+// one of the adjustment reasons; the stock cannot drop below zero or the reserved quantity
+// unitCost (optional) is the cost of each unit added, the product's current unit cost
+// otherwise
+// lotNumber (optional) is the lot the units are added to or removed from; units added to a
+// lot new at the location expire at expiresAt (optional, the lot's expiry date at other
+// locations otherwise)
+func (c *Controller_Inventory) Adjust_Stock(ctx context.Context, productID, locationID, delta int, unitCost *money.Money, lotNumber string, expiresAt *time.Time, reason, reference string) (*dmodel.InventoryItem, error) {
+	if delta == 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	if !dmodel.IsAdjustmentReason(reason) {
+		return nil, internal.ErrInvalidReason
+	}
+	if err := checkUnitCost(unitCost, delta); err != nil {
+		return nil, err
+	}
+	lotNumber = strings.TrimSpace(lotNumber)
+	if expiresAt != nil && (lotNumber == "" || delta < 0) {
+		return nil, fmt.Errorf("%w: an expiry date only applies to units added to a lot", internal.ErrInvalidLot)
 	}
 
-	return nil
+	return c.repo.Adjust_Stock(ctx, productID, locationID, delta, unitCost, lotNumber, utcTime(expiresAt), reason, reference, internal.ActorFromContext(ctx))
 }
 
-func (c *Controller_Inventory) Fulfill_Reservation(ctx context.Context, productID, amount_fulfilled int) error {
-	err := c.repo.Fulfill_Reservation(ctx, productID, amount_fulfilled)
+// Receive_Return takes back the units of a customer return: restocked units become
+// available again, damaged ones are only counted
+// the units of a serial-tracked product are named, one serial number each: serials the
+// restocked ones, which become returned, and damagedSerials the damaged ones, which become
+// damaged and cannot be shipped again
+// the restocked units of lot-tracked stock go back to the lots they came from (lots, at most
+// restocked units; the rest are untracked)
+// reference (optional, e.g. the return authorization) is recorded with the movement
+func (c *Controller_Inventory) Receive_Return(ctx context.Context, productID, locationID, restocked, damaged int, serials, damagedSerials []string, lots []dmodel.LotQuantity, reference string) (*dmodel.InventoryItem, error) {
+	if restocked < 0 || damaged < 0 || restocked+damaged == 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	// normalized together, so that a unit is not both restocked and damaged
+	all, err := normalizeSerials(append(slices.Clip(serials), damagedSerials...))
+	if err != nil {
+		return nil, err
+	}
+	serials, damagedSerials = all[:len(serials)], all[len(serials):]
+	lots, err = normalizeLots(lots, restocked)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Receive_Return(ctx, productID, locationID, restocked, damaged, serials, damagedSerials, lots, reference, internal.ActorFromContext(ctx))
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
+
+// an optional time in UTC, as the TIMESTAMP columns hold it (PostgreSQL drops the offset
+// of a time stored in one)
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
+	utc := t.UTC()
+	return &utc
+}
 
-	return nil
+// whether two optional times are both unset or the same instant
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
@@ -0,0 +1,86 @@
+package inventory_controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+)
+
+// -------------------------------------------------------------------
+// costing and valuation
+// -------------------------------------------------------------------
+
+// every unit entering the stock is valued (at the cost given by its purchase order,
+// adjustment or transfer, or at its product's current unit cost), and units leaving it
+// take the cost picked by their product's costing method: the cost of fulfilled units
+// is the cost of goods sold of their reservation
+
+// Get_Costing returns the costing method of a product and the cost of its stock
+func (c *Controller_Inventory) Get_Costing(ctx context.Context, productID int) (*dmodel.ProductCosting, error) {
+	res, err := c.repo.Get_Costing(ctx, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Set_CostingMethod changes the costing method of a product; the units already in stock
+// keep their cost, and switching to weighted average costs them at their average
+func (c *Controller_Inventory) Set_CostingMethod(ctx context.Context, productID int, method string) (*dmodel.ProductCosting, error) {
+	method = strings.TrimSpace(method)
+	if !dmodel.IsCostingMethod(method) {
+		return nil, fmt.Errorf("%w: %q", internal.ErrInvalidCostingMethod, method)
+	}
+
+	res, err := c.repo.Set_CostingMethod(ctx, productID, method)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get_Valuation values the stock on hand as of a point in time (now when the filter has
+// none), per product and per category
+func (c *Controller_Inventory) Get_Valuation(ctx context.Context, filter dmodel.ValuationFilter) (*dmodel.Valuation, error) {
+	now := time.Now()
+	if filter.AsOf.IsZero() {
+		filter.AsOf = now
+	}
+	if filter.AsOf.After(now) {
+		return nil, fmt.Errorf("%w: as_of is in the future", internal.ErrInvalidTimeRange)
+	}
+	filter.Category = strings.TrimSpace(filter.Category)
+
+	res, err := c.repo.Get_Valuation(ctx, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// a unit cost, when given, cannot be negative and only values units entering the stock
+func checkUnitCost(unitCost *money.Money, quantity int) error {
+	switch {
+	case unitCost == nil:
+		return nil
+	case unitCost.IsNegative():
+		return fmt.Errorf("%w: %s is negative", internal.ErrInvalidCost, unitCost)
+	case quantity < 0:
+		return fmt.Errorf("%w: units leaving the stock take the cost of their costing method", internal.ErrInvalidCost)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
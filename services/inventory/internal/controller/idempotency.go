@@ -0,0 +1,183 @@
+package inventory_controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	dmodel "inventory-service/pkg"
+	"shared/idempotency"
+	"shared/money"
+)
+
+// operations protected by idempotency keys
+const (
+	scopeReserveStock       = "inventory.reserve"
+	scopeReleaseReservation = "inventory.release_reservation"
+	scopeFulfillReservation = "inventory.fulfill"
+	scopeReceiveReturn      = "inventory.receive_return"
+	scopeAdjustStock        = "inventory.adjust"
+	scopeReceivePurchase    = "inventory.receive_purchase_order"
+	// batch operations
+	scopeReserveStockBatch       = "inventory.reserve_batch"
+	scopeReleaseReservationBatch = "inventory.release_reservation_batch"
+	scopeFulfillReservationBatch = "inventory.fulfill_batch"
+)
+
+// request fingerprints of the reservation operations
+type reserveRequest struct {
+	ProductID  int               `json:"product_id"`
+	Quantity   int               `json:"quantity"`
+	Owner      string            `json:"owner"`
+	TTL        time.Duration     `json:"ttl"`
+	Allocation dmodel.Allocation `json:"allocation"`
+}
+
+type settleRequest struct {
+	ReservationID int      `json:"reservation_id"`
+	Quantity      int      `json:"quantity"`
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+type reserveBatchRequest struct {
+	Lines      []dmodel.StockLine `json:"lines"`
+	Owner      string             `json:"owner"`
+	TTL        time.Duration      `json:"ttl"`
+	Allocation dmodel.Allocation  `json:"allocation"`
+}
+
+// request fingerprint of a received return
+type returnRequest struct {
+	ProductID            int                  `json:"product_id"`
+	LocationID           int                  `json:"location_id"`
+	Restocked            int                  `json:"restocked"`
+	Damaged              int                  `json:"damaged"`
+	SerialNumbers        []string             `json:"serial_numbers,omitempty"`
+	DamagedSerialNumbers []string             `json:"damaged_serial_numbers,omitempty"`
+	Lots                 []dmodel.LotQuantity `json:"lots,omitempty"`
+	Reference            string               `json:"reference"`
+}
+
+// request fingerprint of a stock adjustment
+type adjustRequest struct {
+	ProductID  int          `json:"product_id"`
+	LocationID int          `json:"location_id"`
+	Delta      int          `json:"delta"`
+	UnitCost   *money.Money `json:"unit_cost,omitempty"`
+	LotNumber  string       `json:"lot_number,omitempty"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	Reason     string       `json:"reason"`
+	Reference  string       `json:"reference"`
+}
+
+// request fingerprint of goods received for a purchase order
+type receivePurchaseRequest struct {
+	PurchaseOrderID int                  `json:"purchase_order_id"`
+	Lines           []dmodel.ReceiptLine `json:"lines"`
+	Reference       string               `json:"reference"`
+}
+
+// -------------------------------------------------------------------
+// idempotent requests
+// -------------------------------------------------------------------
+
+// the *Idempotent variants apply the operation at most once per idempotency key and
+// return their result; a repeated request gets the result stored by the first one
+// (replayed is then true)
+
+func (c *Controller_Inventory) Reserve_StockIdempotent(ctx context.Context, key string, productID, quantity int, owner string, ttl time.Duration, alloc dmodel.Allocation) (*dmodel.ReservationUpdate, bool, error) {
+	request := reserveRequest{ProductID: productID, Quantity: quantity, Owner: owner, TTL: ttl, Allocation: alloc}
+	return runIdempotent(ctx, c, scopeReserveStock, key, request, func() (*dmodel.ReservationUpdate, error) {
+		return c.Reserve_Stock(ctx, productID, quantity, owner, ttl, alloc)
+	})
+}
+
+func (c *Controller_Inventory) Release_ReservationIdempotent(ctx context.Context, key string, reservationID, quantity int) (*dmodel.ReservationUpdate, bool, error) {
+	request := settleRequest{ReservationID: reservationID, Quantity: quantity}
+	return runIdempotent(ctx, c, scopeReleaseReservation, key, request, func() (*dmodel.ReservationUpdate, error) {
+		return c.Release_Reservation(ctx, reservationID, quantity)
+	})
+}
+
+func (c *Controller_Inventory) Fulfill_ReservationIdempotent(ctx context.Context, key string, reservationID, quantity int, serials []string) (*dmodel.ReservationUpdate, bool, error) {
+	request := settleRequest{ReservationID: reservationID, Quantity: quantity, SerialNumbers: serials}
+	return runIdempotent(ctx, c, scopeFulfillReservation, key, request, func() (*dmodel.ReservationUpdate, error) {
+		return c.Fulfill_Reservation(ctx, reservationID, quantity, serials)
+	})
+}
+
+func (c *Controller_Inventory) Receive_ReturnIdempotent(ctx context.Context, key string, productID, locationID, restocked, damaged int, serials, damagedSerials []string, lots []dmodel.LotQuantity, reference string) (*dmodel.InventoryItem, bool, error) {
+	request := returnRequest{ProductID: productID, LocationID: locationID, Restocked: restocked, Damaged: damaged, SerialNumbers: serials, DamagedSerialNumbers: damagedSerials, Lots: lots, Reference: reference}
+	return runIdempotent(ctx, c, scopeReceiveReturn, key, request, func() (*dmodel.InventoryItem, error) {
+		return c.Receive_Return(ctx, productID, locationID, restocked, damaged, serials, damagedSerials, lots, reference)
+	})
+}
+
+func (c *Controller_Inventory) Adjust_StockIdempotent(ctx context.Context, key string, productID, locationID, delta int, unitCost *money.Money, lotNumber string, expiresAt *time.Time, reason, reference string) (*dmodel.InventoryItem, bool, error) {
+	request := adjustRequest{ProductID: productID, LocationID: locationID, Delta: delta, UnitCost: unitCost, LotNumber: lotNumber, ExpiresAt: expiresAt, Reason: reason, Reference: reference}
+	return runIdempotent(ctx, c, scopeAdjustStock, key, request, func() (*dmodel.InventoryItem, error) {
+		return c.Adjust_Stock(ctx, productID, locationID, delta, unitCost, lotNumber, expiresAt, reason, reference)
+	})
+}
+
+func (c *Controller_Inventory) Receive_PurchaseOrderIdempotent(ctx context.Context, key string, purchaseOrderID int, lines []dmodel.ReceiptLine, reference string) (*dmodel.ReceiptUpdate, bool, error) {
+	request := receivePurchaseRequest{PurchaseOrderID: purchaseOrderID, Lines: lines, Reference: reference}
+	return runIdempotent(ctx, c, scopeReceivePurchase, key, request, func() (*dmodel.ReceiptUpdate, error) {
+		return c.Receive_PurchaseOrder(ctx, purchaseOrderID, lines, reference)
+	})
+}
+
+func (c *Controller_Inventory) Reserve_StockBatchIdempotent(ctx context.Context, key string, lines []dmodel.StockLine, owner string, ttl time.Duration, alloc dmodel.Allocation) (*dmodel.BatchUpdate, bool, error) {
+	request := reserveBatchRequest{Lines: lines, Owner: owner, TTL: ttl, Allocation: alloc}
+	return runIdempotent(ctx, c, scopeReserveStockBatch, key, request, func() (*dmodel.BatchUpdate, error) {
+		return c.Reserve_StockBatch(ctx, lines, owner, ttl, alloc)
+	})
+}
+
+func (c *Controller_Inventory) Release_ReservationBatchIdempotent(ctx context.Context, key string, lines []dmodel.StockLine) (*dmodel.BatchUpdate, bool, error) {
+	return runIdempotent(ctx, c, scopeReleaseReservationBatch, key, lines, func() (*dmodel.BatchUpdate, error) {
+		return c.Release_ReservationBatch(ctx, lines)
+	})
+}
+
+func (c *Controller_Inventory) Fulfill_ReservationBatchIdempotent(ctx context.Context, key string, lines []dmodel.StockLine) (*dmodel.BatchUpdate, bool, error) {
+	return runIdempotent(ctx, c, scopeFulfillReservationBatch, key, lines, func() (*dmodel.BatchUpdate, error) {
+		return c.Fulfill_ReservationBatch(ctx, lines)
+	})
+}
+
+// runIdempotent applies an operation at most once per idempotency key (see idempotency.Run),
+// with the controller's retention window and lease
+func runIdempotent[T any](ctx context.Context, c *Controller_Inventory, scope, key string, request any, run func() (T, error)) (T, bool, error) {
+	return idempotency.Run(ctx, c.repo, c.idempotencyTTL, c.idempotencyLease, scope, key, request, run)
+}
+
+// -------------------------------------------------------------------
+// idempotency key retention
+// -------------------------------------------------------------------
+
+// Run_IdempotencyPurge deletes idempotency keys older than the retention window
+// every interval until the context is cancelled
+func (c *Controller_Inventory) Run_IdempotencyPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := c.repo.Purge_IdempotencyKeys(ctx, time.Now().Add(-c.idempotencyTTL))
+		if err != nil {
+			log.Printf("Error purging idempotency keys: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Purged %d expired idempotency keys", n)
+		}
+	}
+}
+
+// -------------------------------------------------------------------
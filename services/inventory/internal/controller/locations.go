@@ -0,0 +1,56 @@
+package inventory_controller
+
+import (
+	"context"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// locations
+// -------------------------------------------------------------------
+
+// Get_Locations lists every location, in priority order
+func (c *Controller_Inventory) Get_Locations(ctx context.Context) ([]*dmodel.Location, error) {
+	res, err := c.repo.Get_Locations(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller_Inventory) Get_Location(ctx context.Context, locationID int) (*dmodel.Location, error) {
+	res, err := c.repo.Get_Location(ctx, locationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create_Location adds a location; its code (e.g. "MAD") must be unique
+func (c *Controller_Inventory) Create_Location(ctx context.Context, location *dmodel.Location) (*dmodel.Location, error) {
+	location.Code = strings.TrimSpace(location.Code)
+	location.Name = strings.TrimSpace(location.Name)
+	if location.Code == "" || location.Name == "" || location.Priority < 0 {
+		return nil, internal.ErrInvalidLocation
+	}
+	if location.Latitude < -90 || location.Latitude > 90 || location.Longitude < -180 || location.Longitude > 180 {
+		return nil, internal.ErrInvalidLocation
+	}
+
+	res, err := c.repo.Create_Location(ctx, location)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
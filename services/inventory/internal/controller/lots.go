@@ -0,0 +1,76 @@
+package inventory_controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// lots
+// -------------------------------------------------------------------
+
+// Get_Lots lists the lots of a product holding stock, at a location or at every location
+// when locationID is 0, the ones expiring first first
+func (c *Controller_Inventory) Get_Lots(ctx context.Context, productID, locationID int) ([]*dmodel.Lot, error) {
+	// unknown products are reported as such instead of as having no lots
+	if _, err := c.repo.Get_ByProductID(ctx, productID, 0); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Get_Lots(ctx, productID, locationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// trim the lot numbers of a list of lots and merge the units of the same lot, in lot number
+// order; every lot must be named, with a positive quantity and a single expiry date, and
+// the lots cannot cover more than quantity units
+func normalizeLots(lots []dmodel.LotQuantity, quantity int) ([]dmodel.LotQuantity, error) {
+	if len(lots) == 0 {
+		return nil, nil
+	}
+
+	var merged []dmodel.LotQuantity
+	total := 0
+	for _, lot := range lots {
+		lot.LotNumber = strings.TrimSpace(lot.LotNumber)
+		if lot.LotNumber == "" {
+			return nil, fmt.Errorf("%w: empty lot number", internal.ErrInvalidLot)
+		}
+		if lot.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: %d units of lot %s", internal.ErrInvalidQuantity, lot.Quantity, lot.LotNumber)
+		}
+		lot.ExpiresAt = utcTime(lot.ExpiresAt)
+		total += lot.Quantity
+
+		i := slices.IndexFunc(merged, func(m dmodel.LotQuantity) bool { return m.LotNumber == lot.LotNumber })
+		if i < 0 {
+			merged = append(merged, lot)
+			continue
+		}
+		if !equalTimes(merged[i].ExpiresAt, lot.ExpiresAt) {
+			return nil, fmt.Errorf("%w: lot %s is given with two expiry dates", internal.ErrInvalidLot, lot.LotNumber)
+		}
+		merged[i].Quantity += lot.Quantity
+	}
+	if total > quantity {
+		return nil, fmt.Errorf("%w: the lots cover %d units of %d", internal.ErrInvalidLot, total, quantity)
+	}
+	slices.SortFunc(merged, func(a, b dmodel.LotQuantity) int {
+		return cmp.Compare(a.LotNumber, b.LotNumber)
+	})
+
+	return merged, nil
+}
+
+// -------------------------------------------------------------------
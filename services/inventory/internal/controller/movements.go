@@ -0,0 +1,48 @@
+package inventory_controller
+
+import (
+	"context"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// page size of the movement history, when not given, and its maximum
+const (
+	defaultMovementLimit = 100
+	maxMovementLimit     = 1000
+)
+
+// -------------------------------------------------------------------
+// stock movements
+// -------------------------------------------------------------------
+
+// Get_Movements lists the ledger entries of a product in the order they were made,
+// filtered by filter; pages are read by passing the last ID seen as AfterID
+func (c *Controller_Inventory) Get_Movements(ctx context.Context, productID int, filter dmodel.MovementFilter) ([]*dmodel.Movement, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, internal.ErrInvalidTimeRange
+	}
+	if filter.Limit < 0 || filter.AfterID < 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultMovementLimit
+	}
+	filter.Limit = min(filter.Limit, maxMovementLimit)
+
+	// unknown products are reported as such instead of as an empty history
+	if _, err := c.repo.Get_ByProductID(ctx, productID, 0); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Get_Movements(ctx, productID, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,260 @@
+package inventory_controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+)
+
+// -------------------------------------------------------------------
+// suppliers
+// -------------------------------------------------------------------
+
+// Get_Suppliers lists every supplier, by code
+func (c *Controller_Inventory) Get_Suppliers(ctx context.Context) ([]*dmodel.Supplier, error) {
+	res, err := c.repo.Get_Suppliers(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller_Inventory) Get_Supplier(ctx context.Context, supplierID int) (*dmodel.Supplier, error) {
+	res, err := c.repo.Get_Supplier(ctx, supplierID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create_Supplier adds a supplier; its code (e.g. "ACME") must be unique
+func (c *Controller_Inventory) Create_Supplier(ctx context.Context, supplier *dmodel.Supplier) (*dmodel.Supplier, error) {
+	supplier.Code = strings.TrimSpace(supplier.Code)
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	supplier.Email = strings.TrimSpace(supplier.Email)
+	if supplier.Code == "" || supplier.Name == "" {
+		return nil, internal.ErrInvalidSupplier
+	}
+
+	res, err := c.repo.Create_Supplier(ctx, supplier)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
+// purchase orders
+// -------------------------------------------------------------------
+
+// a purchase order is open until goods arrive for it, partially received while some of its
+// lines still have outstanding units, and received once none has; cancelling it stops
+// expecting what is left
+
+var purchaseOrderStates = []string{dmodel.PurchaseOrderOpen, dmodel.PurchaseOrderPartiallyReceived, dmodel.PurchaseOrderReceived, dmodel.PurchaseOrderCancelled}
+
+// Get_PurchaseOrders lists the purchase orders in a state and/or placed with a supplier
+// (any when empty or 0)
+func (c *Controller_Inventory) Get_PurchaseOrders(ctx context.Context, state string, supplierID int) ([]*dmodel.PurchaseOrder, error) {
+	if state != "" && !slices.Contains(purchaseOrderStates, state) {
+		return nil, fmt.Errorf("%w: unknown state %q", internal.ErrInvalidPurchaseOrder, state)
+	}
+
+	res, err := c.repo.Get_PurchaseOrders(ctx, state, supplierID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller_Inventory) Get_PurchaseOrder(ctx context.Context, purchaseOrderID int) (*dmodel.PurchaseOrder, error) {
+	res, err := c.repo.Get_PurchaseOrder(ctx, purchaseOrderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create_PurchaseOrder places an open purchase order with a supplier, to be received at
+// a location; lines of the same product are merged, and must be at the same unit cost
+func (c *Controller_Inventory) Create_PurchaseOrder(ctx context.Context, order *dmodel.PurchaseOrder) (*dmodel.PurchaseOrder, error) {
+	if order.SupplierID <= 0 || order.LocationID <= 0 {
+		return nil, fmt.Errorf("%w: supplier and location are required", internal.ErrInvalidPurchaseOrder)
+	}
+	if len(order.Lines) == 0 {
+		return nil, fmt.Errorf("%w: purchase order has no lines", internal.ErrInvalidPurchaseOrder)
+	}
+
+	merged := make(map[int]*dmodel.PurchaseOrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d", internal.ErrInvalidQuantity, line.Quantity, line.ProductID)
+		}
+		if err := checkUnitCost(&line.UnitCost, line.Quantity); err != nil {
+			return nil, err
+		}
+		previous, ok := merged[line.ProductID]
+		if !ok {
+			merged[line.ProductID] = &dmodel.PurchaseOrderLine{ProductID: line.ProductID, Quantity: line.Quantity, UnitCost: line.UnitCost}
+			continue
+		}
+		if previous.UnitCost.Amount != line.UnitCost.Amount {
+			return nil, fmt.Errorf("%w: product %d is ordered at two unit costs", internal.ErrInvalidPurchaseOrder, line.ProductID)
+		}
+		previous.Quantity += line.Quantity
+	}
+	lines := make([]dmodel.PurchaseOrderLine, 0, len(merged))
+	for _, line := range merged {
+		lines = append(lines, *line)
+	}
+	slices.SortFunc(lines, func(a, b dmodel.PurchaseOrderLine) int {
+		return cmp.Compare(a.ProductID, b.ProductID)
+	})
+
+	res, err := c.repo.Create_PurchaseOrder(ctx, &dmodel.PurchaseOrder{
+		SupplierID: order.SupplierID,
+		LocationID: order.LocationID,
+		Reference:  strings.TrimSpace(order.Reference),
+		ExpectedAt: utcTime(order.ExpectedAt),
+		Lines:      lines,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Cancel_PurchaseOrder stops expecting the outstanding units of an open or partially
+// received purchase order
+func (c *Controller_Inventory) Cancel_PurchaseOrder(ctx context.Context, purchaseOrderID int) (*dmodel.PurchaseOrder, error) {
+	res, err := c.repo.Cancel_PurchaseOrder(ctx, purchaseOrderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Receive_PurchaseOrder adds the units that arrived for an open or partially received
+// purchase order to the stock of its location; a line may bring more units than are
+// outstanding (an over-receipt), but every line must be a product of the order
+// a line naming a lot number (and optionally the lot's expiry date) adds its units to that
+// lot; lines of the same product and lot are merged
+// the units of a serial-tracked product are registered by the serial numbers of the line,
+// one for each unit
+// a line giving a unit cost values its units at that cost instead of the order line's
+// reference (optional, e.g. the delivery note) is recorded with the receipts and movements
+func (c *Controller_Inventory) Receive_PurchaseOrder(ctx context.Context, purchaseOrderID int, lines []dmodel.ReceiptLine, reference string) (*dmodel.ReceiptUpdate, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: receipt has no lines", internal.ErrInvalidReceipt)
+	}
+
+	type lotKey struct {
+		productID int
+		lotNumber string
+	}
+	merged := make(map[lotKey]*dmodel.ReceiptLine, len(lines))
+	now := time.Now()
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d", internal.ErrInvalidQuantity, line.Quantity, line.ProductID)
+		}
+		line.LotNumber = strings.TrimSpace(line.LotNumber)
+		if line.LotNumber == "" && line.ExpiresAt != nil {
+			return nil, fmt.Errorf("%w: expiry date of product %d without a lot number", internal.ErrInvalidLot, line.ProductID)
+		}
+		if line.ExpiresAt != nil && !line.ExpiresAt.After(now) {
+			return nil, fmt.Errorf("%w: lot %s of product %d has already expired", internal.ErrInvalidLot, line.LotNumber, line.ProductID)
+		}
+		if err := checkUnitCost(line.UnitCost, line.Quantity); err != nil {
+			return nil, err
+		}
+		line.ExpiresAt = utcTime(line.ExpiresAt)
+
+		key := lotKey{line.ProductID, line.LotNumber}
+		previous, ok := merged[key]
+		if !ok {
+			merged[key] = &line
+			continue
+		}
+		if !equalTimes(previous.ExpiresAt, line.ExpiresAt) {
+			return nil, fmt.Errorf("%w: lot %s of product %d is received with two expiry dates", internal.ErrInvalidLot, line.LotNumber, line.ProductID)
+		}
+		if !equalCosts(previous.UnitCost, line.UnitCost) {
+			return nil, fmt.Errorf("%w: lot %s of product %d is received at two unit costs", internal.ErrInvalidReceipt, line.LotNumber, line.ProductID)
+		}
+		previous.Quantity += line.Quantity
+		previous.SerialNumbers = append(slices.Clip(previous.SerialNumbers), line.SerialNumbers...)
+	}
+	normalized := make([]dmodel.ReceiptLine, 0, len(merged))
+	for _, line := range merged {
+		serials, err := normalizeSerials(line.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+		line.SerialNumbers = serials
+		normalized = append(normalized, *line)
+	}
+	slices.SortFunc(normalized, func(a, b dmodel.ReceiptLine) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LotNumber, b.LotNumber))
+	})
+
+	return c.repo.Receive_PurchaseOrder(ctx, purchaseOrderID, normalized, strings.TrimSpace(reference), internal.ActorFromContext(ctx))
+}
+
+// whether two optional unit costs are both unset or the same amount
+func equalCosts(a, b *money.Money) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Amount == b.Amount
+}
+
+// Get_Receipts lists the goods received for a purchase order
+func (c *Controller_Inventory) Get_Receipts(ctx context.Context, purchaseOrderID int) ([]*dmodel.Receipt, error) {
+	if _, err := c.repo.Get_PurchaseOrder(ctx, purchaseOrderID); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Get_Receipts(ctx, purchaseOrderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get_Incoming lists the units still expected from open and partially received purchase
+// orders, per product and location, of a product or of every product when productID is 0
+func (c *Controller_Inventory) Get_Incoming(ctx context.Context, productID int) ([]*dmodel.IncomingStock, error) {
+	res, err := c.repo.Get_Incoming(ctx, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,146 @@
+package inventory_controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// maximum number of reservations expired per pass of the expiry worker
+const expiryBatchSize = 100
+
+// actor recorded in the movements of expired reservations
+const expiryActor = "system:reservation-expiry"
+
+// -------------------------------------------------------------------
+// reservations
+// -------------------------------------------------------------------
+
+func (c *Controller_Inventory) Get_Reservation(ctx context.Context, reservationID int) (*dmodel.Reservation, error) {
+	res, err := c.repo.Get_Reservation(ctx, reservationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get_ActiveReservations lists the reservations still holding stock, of a product
+// and/or an owner (any when 0 or empty)
+func (c *Controller_Inventory) Get_ActiveReservations(ctx context.Context, productID int, owner string) ([]*dmodel.Reservation, error) {
+	res, err := c.repo.Get_ActiveReservations(ctx, productID, owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Reserve_Stock holds quantity units of a product for owner (e.g. "order-12"), at the
+// location named by alloc or, when it names none, at the one picked by its policy (the
+// configured one when empty)
+// the reservation expires after ttl, or never when ttl is 0
+func (c *Controller_Inventory) Reserve_Stock(ctx context.Context, productID, quantity int, owner string, ttl time.Duration, alloc dmodel.Allocation) (*dmodel.ReservationUpdate, error) {
+	if quantity <= 0 || ttl < 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	if owner == "" {
+		return nil, internal.ErrInvalidOwner
+	}
+	alloc, err := c.allocation(alloc)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Reserve_Stock(ctx, productID, quantity, owner, expiry(ttl), alloc, internal.ActorFromContext(ctx))
+}
+
+// alloc with the configured policy when it has none
+func (c *Controller_Inventory) allocation(alloc dmodel.Allocation) (dmodel.Allocation, error) {
+	if alloc.LocationID < 0 {
+		return alloc, internal.ErrLocationNotFound
+	}
+	if alloc.Policy == "" {
+		alloc.Policy = c.allocationPolicy
+	}
+	if !dmodel.IsAllocationPolicy(alloc.Policy) {
+		return alloc, internal.ErrInvalidPolicy
+	}
+
+	return alloc, nil
+}
+
+// Fulfill_Reservation deducts quantity units of a reservation (every remaining unit
+// when 0) from the stock; serials names the units shipped of a serial-tracked product,
+// which may be left out when as many units were picked for the reservation
+func (c *Controller_Inventory) Fulfill_Reservation(ctx context.Context, reservationID, quantity int, serials []string) (*dmodel.ReservationUpdate, error) {
+	if quantity < 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+	serials, err := normalizeSerials(serials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Fulfill_Reservation(ctx, reservationID, quantity, serials, internal.ActorFromContext(ctx))
+}
+
+// Release_Reservation makes quantity units of a reservation (every remaining unit
+// when 0) available again
+func (c *Controller_Inventory) Release_Reservation(ctx context.Context, reservationID, quantity int) (*dmodel.ReservationUpdate, error) {
+	if quantity < 0 {
+		return nil, internal.ErrInvalidQuantity
+	}
+
+	return c.repo.Release_Reservation(ctx, reservationID, quantity, internal.ActorFromContext(ctx))
+}
+
+// expiry time of a reservation made now, nil when it never expires
+func expiry(ttl time.Duration) *time.Time {
+	if ttl == 0 {
+		return nil
+	}
+	expiresAt := time.Now().Add(ttl)
+	return &expiresAt
+}
+
+// -------------------------------------------------------------------
+// reservation expiry
+// -------------------------------------------------------------------
+
+// Run_ReservationExpiry releases the stock of expired reservations every interval
+// until the context is cancelled
+func (c *Controller_Inventory) Run_ReservationExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep going while full batches come back, so a backlog is cleared in one pass
+		for {
+			n, err := c.repo.Expire_Reservations(ctx, time.Now(), expiryBatchSize, expiryActor)
+			if err != nil {
+				log.Printf("Error expiring reservations: %v", err)
+				break
+			}
+			if n > 0 {
+				log.Printf("Expired %d reservations", n)
+			}
+			if n < expiryBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// -------------------------------------------------------------------
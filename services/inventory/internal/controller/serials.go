@@ -0,0 +1,86 @@
+package inventory_controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// serial numbers
+// -------------------------------------------------------------------
+
+// Get_Serials lists the serialized units matching filter; State, when set, must be one
+// of the unit states
+func (c *Controller_Inventory) Get_Serials(ctx context.Context, filter dmodel.SerialFilter) ([]*dmodel.SerialUnit, error) {
+	filter.SerialNumber = strings.TrimSpace(filter.SerialNumber)
+	switch filter.State {
+	case "", dmodel.SerialAvailable, dmodel.SerialReserved, dmodel.SerialShipped, dmodel.SerialReturned, dmodel.SerialDamaged, dmodel.SerialInTransit:
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", internal.ErrInvalidSerial, filter.State)
+	}
+
+	res, err := c.repo.Get_Serials(ctx, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Set_SerialTracking turns serial tracking of a product on or off; it can only be turned
+// on while the product holds no stock
+func (c *Controller_Inventory) Set_SerialTracking(ctx context.Context, productID int, enabled bool) (*dmodel.InventoryItem, error) {
+	res, err := c.repo.Set_SerialTracking(ctx, productID, enabled)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Reserve_Serials picks serialized units for an active reservation, which ships them when
+// it is fulfilled without naming them
+func (c *Controller_Inventory) Reserve_Serials(ctx context.Context, reservationID int, serials []string) ([]*dmodel.SerialUnit, error) {
+	serials, err := normalizeSerials(serials)
+	if err != nil {
+		return nil, err
+	}
+	if len(serials) == 0 {
+		return nil, fmt.Errorf("%w: no serial numbers", internal.ErrInvalidSerial)
+	}
+
+	return c.repo.Reserve_Serials(ctx, reservationID, serials)
+}
+
+// -------------------------------------------------------------------
+
+// trim a list of serial numbers, each of which must be set and listed once
+func normalizeSerials(serials []string) ([]string, error) {
+	if len(serials) == 0 {
+		return nil, nil
+	}
+
+	res := make([]string, len(serials))
+	seen := make(map[string]bool, len(serials))
+	for i, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			return nil, fmt.Errorf("%w: empty serial number", internal.ErrInvalidSerial)
+		}
+		if seen[serial] {
+			return nil, fmt.Errorf("%w: %s is listed twice", internal.ErrInvalidSerial, serial)
+		}
+		seen[serial] = true
+		res[i] = serial
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
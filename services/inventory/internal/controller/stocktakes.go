@@ -0,0 +1,176 @@
+package inventory_controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// stocktakes
+// -------------------------------------------------------------------
+
+// a stocktake counts products at a location: opening it snapshots their stock, counters
+// submit what they counted (a product counted in several places by several counters adds
+// up their counts), the variances are approved or rejected, and posting it adds the
+// approved ones to the stock as cycle count adjustments
+
+var stocktakeStates = []string{dmodel.StocktakeOpen, dmodel.StocktakePosted, dmodel.StocktakeCancelled}
+
+// Get_Stocktakes lists the stocktakes in a state and/or at a location (any when empty or 0)
+func (c *Controller_Inventory) Get_Stocktakes(ctx context.Context, state string, locationID int) ([]*dmodel.Stocktake, error) {
+	if state != "" && !slices.Contains(stocktakeStates, state) {
+		return nil, fmt.Errorf("%w: unknown state %q", internal.ErrInvalidStocktake, state)
+	}
+
+	res, err := c.repo.Get_Stocktakes(ctx, state, locationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller_Inventory) Get_Stocktake(ctx context.Context, stocktakeID int) (*dmodel.Stocktake, error) {
+	res, err := c.repo.Get_Stocktake(ctx, stocktakeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create_Stocktake opens a stocktake of products at a location (every product held there
+// when productIDs is empty), snapshotting their stock as the expected quantities
+func (c *Controller_Inventory) Create_Stocktake(ctx context.Context, locationID int, productIDs []int, reference string) (*dmodel.Stocktake, error) {
+	if locationID <= 0 {
+		return nil, fmt.Errorf("%w: location is required", internal.ErrInvalidStocktake)
+	}
+	productIDs, err := normalizeStocktakeProducts(productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Create_Stocktake(ctx, &dmodel.Stocktake{
+		LocationID: locationID,
+		Reference:  strings.TrimSpace(reference),
+		CreatedBy:  internal.ActorFromContext(ctx),
+	}, productIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Count_Stocktake records the units of products counted by counter (the caller when
+// empty), replacing the counter's earlier count of each of them; their expected quantities
+// become their stock at the time of the count
+func (c *Controller_Inventory) Count_Stocktake(ctx context.Context, stocktakeID int, counter string, lines []dmodel.StocktakeCountLine) (*dmodel.Stocktake, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no counts", internal.ErrInvalidStocktake)
+	}
+	seen := make(map[int]bool, len(lines))
+	for _, line := range lines {
+		if line.Quantity < 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d", internal.ErrInvalidQuantity, line.Quantity, line.ProductID)
+		}
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("%w: product %d is counted twice", internal.ErrInvalidStocktake, line.ProductID)
+		}
+		seen[line.ProductID] = true
+	}
+	counter = strings.TrimSpace(counter)
+	if counter == "" {
+		counter = internal.ActorFromContext(ctx)
+	}
+
+	res, err := c.repo.Count_Stocktake(ctx, stocktakeID, counter, lines)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Review_Stocktake approves or rejects the variances of counted products
+func (c *Controller_Inventory) Review_Stocktake(ctx context.Context, stocktakeID int, approve, reject []int) (*dmodel.Stocktake, error) {
+	if len(approve) == 0 && len(reject) == 0 {
+		return nil, fmt.Errorf("%w: nothing to review", internal.ErrInvalidStocktake)
+	}
+	for _, productID := range approve {
+		if slices.Contains(reject, productID) {
+			return nil, fmt.Errorf("%w: product %d is both approved and rejected", internal.ErrInvalidStocktake, productID)
+		}
+	}
+
+	res, err := c.repo.Review_Stocktake(ctx, stocktakeID, approve, reject)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Post_Stocktake adds the approved variances of an open stocktake to the stock; every
+// counted product must have been reviewed, and every variance must leave its item with
+// enough stock for its reservations, otherwise nothing is posted
+func (c *Controller_Inventory) Post_Stocktake(ctx context.Context, stocktakeID int) (*dmodel.StocktakeUpdate, error) {
+	res, err := c.repo.Post_Stocktake(ctx, stocktakeID, internal.ActorFromContext(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Cancel_Stocktake closes an open stocktake without changing any stock
+func (c *Controller_Inventory) Cancel_Stocktake(ctx context.Context, stocktakeID int) (*dmodel.Stocktake, error) {
+	res, err := c.repo.Cancel_Stocktake(ctx, stocktakeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get_Shrinkage totals the variances posted by the stocktakes matching filter, per product
+func (c *Controller_Inventory) Get_Shrinkage(ctx context.Context, filter dmodel.ShrinkageFilter) ([]*dmodel.ShrinkageLine, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, internal.ErrInvalidTimeRange
+	}
+
+	res, err := c.repo.Get_Shrinkage(ctx, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// drop repeated product IDs and sort them
+func normalizeStocktakeProducts(productIDs []int) ([]int, error) {
+	for _, productID := range productIDs {
+		if productID <= 0 {
+			return nil, fmt.Errorf("%w: product %d", internal.ErrInvalidStocktake, productID)
+		}
+	}
+
+	res := slices.Clone(productIDs)
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,149 @@
+package inventory_controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// transfers
+// -------------------------------------------------------------------
+
+// a transfer moves units between two locations: it is created as a draft, shipping it
+// removes the units from the source (in transit to the destination) and receiving it adds
+// them to the destination's stock
+
+var transferStates = []string{dmodel.TransferDraft, dmodel.TransferInTransit, dmodel.TransferReceived}
+
+// Get_Transfers lists the transfers in a state and/or from or to a location (any when
+// empty or 0)
+func (c *Controller_Inventory) Get_Transfers(ctx context.Context, state string, locationID int) ([]*dmodel.Transfer, error) {
+	if state != "" && !slices.Contains(transferStates, state) {
+		return nil, fmt.Errorf("%w: unknown state %q", internal.ErrInvalidTransfer, state)
+	}
+
+	res, err := c.repo.Get_Transfers(ctx, state, locationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller_Inventory) Get_Transfer(ctx context.Context, transferID int) (*dmodel.Transfer, error) {
+	res, err := c.repo.Get_Transfer(ctx, transferID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create_Transfer creates a draft transfer between two different locations; lines of the
+// same product are merged
+// a line of a serial-tracked product names the units it moves, one serial number each
+// a line may name lots its units are taken from (up to its quantity); the expiry date of a
+// lot is the one it has at the source
+func (c *Controller_Inventory) Create_Transfer(ctx context.Context, transfer *dmodel.Transfer) (*dmodel.Transfer, error) {
+	if transfer.SourceLocationID <= 0 || transfer.DestinationLocationID <= 0 {
+		return nil, fmt.Errorf("%w: source and destination locations are required", internal.ErrInvalidTransfer)
+	}
+	if transfer.SourceLocationID == transfer.DestinationLocationID {
+		return nil, fmt.Errorf("%w: source and destination must differ", internal.ErrInvalidTransfer)
+	}
+	lines, err := normalizeTransferLines(transfer.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Create_Transfer(ctx, &dmodel.Transfer{
+		SourceLocationID:      transfer.SourceLocationID,
+		DestinationLocationID: transfer.DestinationLocationID,
+		Lines:                 lines,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Ship_Transfer removes the units of a draft transfer from its source's stock; every
+// line must have enough available units, and its serialized units must be available at
+// the source, otherwise nothing is shipped
+func (c *Controller_Inventory) Ship_Transfer(ctx context.Context, transferID int) (*dmodel.TransferUpdate, error) {
+	res, err := c.repo.Ship_Transfer(ctx, transferID, internal.ActorFromContext(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Receive_Transfer adds the units of an in transit transfer to its destination's stock
+func (c *Controller_Inventory) Receive_Transfer(ctx context.Context, transferID int) (*dmodel.TransferUpdate, error) {
+	res, err := c.repo.Receive_Transfer(ctx, transferID, internal.ActorFromContext(ctx))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// merge the lines of the same product and sort them by product ID
+func normalizeTransferLines(lines []dmodel.TransferLine) ([]dmodel.TransferLine, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: transfer has no lines", internal.ErrInvalidTransfer)
+	}
+
+	merged := make(map[int]*dmodel.TransferLine, len(lines))
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d", internal.ErrInvalidQuantity, line.Quantity, line.ProductID)
+		}
+		previous, ok := merged[line.ProductID]
+		if !ok {
+			merged[line.ProductID] = &dmodel.TransferLine{ProductID: line.ProductID, Quantity: line.Quantity, SerialNumbers: line.SerialNumbers, Lots: line.Lots}
+			continue
+		}
+		previous.Quantity += line.Quantity
+		previous.SerialNumbers = append(slices.Clip(previous.SerialNumbers), line.SerialNumbers...)
+		previous.Lots = append(slices.Clip(previous.Lots), line.Lots...)
+	}
+
+	res := make([]dmodel.TransferLine, 0, len(merged))
+	for _, line := range merged {
+		serials, err := normalizeSerials(line.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+		line.SerialNumbers = serials
+		// the lots leave the source with the expiry date they have there
+		for i := range line.Lots {
+			line.Lots[i].ExpiresAt = nil
+		}
+		lots, err := normalizeLots(line.Lots, line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		line.Lots = lots
+		res = append(res, *line)
+	}
+	slices.SortFunc(res, func(a, b dmodel.TransferLine) int {
+		return cmp.Compare(a.ProductID, b.ProductID)
+	})
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
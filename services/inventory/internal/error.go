@@ -1,9 +1,86 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	dmodel "inventory-service/pkg"
+	"shared/idempotency"
+)
 
 var (
 	ErrItemNotFound         = errors.New("item (inventory product) not found")
 	ErrInsufficientStock    = errors.New("insufficient stock")
 	ErrInsufficientReserved = errors.New("insufficient reserved stock")
+	ErrInvalidQuantity      = errors.New("invalid quantity")
+	ErrStockBelowReserved   = errors.New("stock cannot drop below zero or the reserved quantity")
+	ErrInvalidReason        = errors.New("invalid adjustment reason")
+	ErrVersionMismatch      = errors.New("item was modified since the expected version")
+	ErrInvalidReorderPoint  = errors.New("reorder point and quantity cannot be negative")
+	// locations
+	ErrLocationNotFound = errors.New("location not found")
+	ErrInvalidLocation  = errors.New("invalid location")
+	ErrLocationExists   = errors.New("a location with this code already exists")
+	ErrInvalidPolicy    = errors.New("unknown allocation policy")
+	ErrLocationRequired = errors.New("the product is stocked at more than one location, name the location to set")
+	// transfers
+	ErrTransferNotFound = errors.New("transfer not found")
+	ErrInvalidTransfer  = errors.New("invalid transfer")
+	ErrTransferState    = errors.New("transfer is not in the state required by the operation")
+	// stocktakes
+	ErrStocktakeNotFound = errors.New("stocktake not found")
+	ErrInvalidStocktake  = errors.New("invalid stocktake")
+	ErrStocktakeState    = errors.New("stocktake is not in the state required by the operation")
+	ErrStocktakeOverlap  = errors.New("product is already counted by another open stocktake at the location")
+	// purchasing
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrInvalidSupplier       = errors.New("invalid supplier")
+	ErrSupplierExists        = errors.New("a supplier with this code already exists")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrPurchaseOrderState    = errors.New("purchase order is not in a state allowing the operation")
+	ErrInvalidReceipt        = errors.New("invalid receipt")
+	// lots
+	ErrInvalidLot = errors.New("invalid lot")
+	// serial numbers
+	ErrInvalidSerial      = errors.New("invalid serial numbers")
+	ErrSerialNotFound     = errors.New("serial number not found")
+	ErrSerialExists       = errors.New("serial number is already registered")
+	ErrSerialUnavailable  = errors.New("serialized unit is not available")
+	ErrSerialTrackingUsed = errors.New("serial tracking cannot be turned on for a product holding stock")
+	ErrSerialUnnamed      = errors.New("units of a serial-tracked product are only added with their serial numbers")
+	// stock alerts
+	ErrAlertNotFound = errors.New("stock alert not found")
+	// reservations
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is no longer active")
+	ErrInvalidOwner         = errors.New("reservation owner is required")
+	// stock movements
+	ErrInvalidTimeRange = errors.New("invalid time range")
+	// batch operations
+	ErrEmptyBatch    = errors.New("batch has no lines")
+	ErrBatchRejected = errors.New("batch rejected")
+	// bulk import
+	ErrInvalidImport = errors.New("invalid import")
+	// costing
+	ErrInvalidCostingMethod = errors.New("unknown costing method (fifo or weighted_average)")
+	ErrInvalidCost          = errors.New("invalid unit cost")
+	// idempotency keys
+	ErrInvalidIdempotencyKey    = idempotency.ErrInvalidKey
+	ErrIdempotencyKeyInProgress = idempotency.ErrInProgress
+	ErrIdempotencyKeyReused     = idempotency.ErrKeyReused
 )
+
+// BatchError lists the lines that made a batch operation fail; no line of the batch
+// was applied. It matches ErrBatchRejected with errors.Is
+type BatchError struct {
+	Failures []dmodel.LineFailure
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%v: %d line(s) failed", ErrBatchRejected, len(e.Failures))
+}
+
+func (e *BatchError) Unwrap() error {
+	return ErrBatchRejected
+}
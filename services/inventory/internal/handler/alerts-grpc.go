@@ -0,0 +1,48 @@
+package inventory_handler_http
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// reorder points and stock alerts
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory_GRPC) SetReorderPoint(ctx context.Context, req *pb.SetReorderPointRequest) (*pb.SetReorderPointResponse, error) {
+	item, err := h.controller.Set_ReorderPoint(ctx, int(req.ProductId), int(req.LocationId), int(req.ReorderPoint), int(req.ReorderQuantity))
+	if err != nil {
+		switch err {
+		case internal.ErrItemNotFound:
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		case internal.ErrLocationNotFound:
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		case internal.ErrInvalidReorderPoint:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.SetReorderPointResponse{
+		Item: toPBItem(item),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListLowStock(ctx context.Context, req *pb.ListLowStockRequest) (*pb.ListLowStockResponse, error) {
+	items, err := h.controller.Get_LowStock(ctx, int(req.LocationId))
+	if err != nil {
+		if err == internal.ErrLocationNotFound {
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.ListLowStockResponse{
+		Items: toPBItems(items),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+)
+
+// -------------------------------------------------------------------
+// reorder points and stock alerts
+// -------------------------------------------------------------------
+
+// Get_LowStock lists the items below their reorder point, at the location given by the
+// location_id query parameter or at every location
+func (h *Handler_Inventory) Get_LowStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	items, err := h.controller.Get_LowStock(ctx, locationID)
+	if err != nil {
+		log.Printf("Error getting low stock items: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(items)
+	if err != nil {
+		log.Printf("Error encoding low stock items to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Set_ReorderPoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		LocationID      int `json:"location_id"`
+		ReorderPoint    int `json:"reorder_point"`
+		ReorderQuantity int `json:"reorder_quantity"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.controller.Set_ReorderPoint(ctx, productID, template_req.LocationID, template_req.ReorderPoint, template_req.ReorderQuantity)
+	if err != nil {
+		switch err {
+		case internal.ErrItemNotFound:
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		case internal.ErrLocationNotFound:
+			http.Error(w, "Location not found", http.StatusNotFound)
+			return
+		case internal.ErrInvalidReorderPoint:
+			http.Error(w, "Reorder point and quantity cannot be negative", http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error setting reorder point: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", etag(item.Version))
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(item)
+	if err != nil {
+		log.Printf("Error encoding inventory item to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Set reorder point of product %d to %d (reorder %d units)", productID, template_req.ReorderPoint, template_req.ReorderQuantity)
+}
@@ -0,0 +1,118 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// batch operations
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory_GRPC) ReserveStockBatch(ctx context.Context, req *pb.ReserveStockBatchRequest) (*pb.ReserveStockBatchResponse, error) {
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	alloc := dmodel.Allocation{Policy: req.Policy, Destination: fromPBCoordinates(req.Destination)}
+	res, replayed, err := h.controller.Reserve_StockBatchIdempotent(ctx, idempotencyKeyFromContext(ctx), fromPBLines(req.Lines), req.Owner, ttl, alloc)
+	if err != nil {
+		return nil, batchStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.ReserveStockBatchResponse{
+		Items:        toPBItems(res.Items),
+		Reservations: toPBReservations(res.Reservations),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) FulfillReservationBatch(ctx context.Context, req *pb.FulfillReservationBatchRequest) (*pb.FulfillReservationBatchResponse, error) {
+	res, replayed, err := h.controller.Fulfill_ReservationBatchIdempotent(ctx, idempotencyKeyFromContext(ctx), fromPBLines(req.Lines))
+	if err != nil {
+		return nil, batchStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.FulfillReservationBatchResponse{
+		Items:        toPBItems(res.Items),
+		Reservations: toPBReservations(res.Reservations),
+		Lots:         toPBLotConsumptions(res.Lots),
+		Serials:      toPBSerialConsumptions(res.Serials),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReleaseReservationBatch(ctx context.Context, req *pb.ReleaseReservationBatchRequest) (*pb.ReleaseReservationBatchResponse, error) {
+	res, replayed, err := h.controller.Release_ReservationBatchIdempotent(ctx, idempotencyKeyFromContext(ctx), fromPBLines(req.Lines))
+	if err != nil {
+		return nil, batchStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.ReleaseReservationBatchResponse{
+		Items:        toPBItems(res.Items),
+		Reservations: toPBReservations(res.Reservations),
+	}, nil
+}
+
+// maps the error of a batch operation to a gRPC status
+// a rejected batch carries every failed line in a BatchFailure detail
+func batchStatus(err error) error {
+	var batchErr *internal.BatchError
+	if errors.As(err, &batchErr) {
+		failures := make([]*pb.LineFailure, len(batchErr.Failures))
+		for i, failure := range batchErr.Failures {
+			failures[i] = &pb.LineFailure{
+				LocationId:    int32(failure.LocationID),
+				ProductId:     int32(failure.ProductID),
+				ReservationId: int32(failure.ReservationID),
+				Quantity:      int32(failure.Quantity),
+				Reason:        failure.Reason,
+			}
+		}
+
+		st, detailErr := status.New(codes.FailedPrecondition, batchErr.Error()).WithDetails(&pb.BatchFailure{Failures: failures})
+		if detailErr != nil {
+			return status.Errorf(codes.FailedPrecondition, "%v", batchErr)
+		}
+		return st.Err()
+	}
+	if errors.Is(err, internal.ErrEmptyBatch) || errors.Is(err, internal.ErrInvalidQuantity) || errors.Is(err, internal.ErrInvalidOwner) ||
+		errors.Is(err, internal.ErrLocationNotFound) || errors.Is(err, internal.ErrInvalidPolicy) {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := serialStatus(err); err != nil {
+		return err
+	}
+	if err := idempotencyStatus(err); err != nil {
+		return err
+	}
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+func fromPBLines(pbLines []*pb.StockLine) []dmodel.StockLine {
+	lines := make([]dmodel.StockLine, len(pbLines))
+	for i, line := range pbLines {
+		lines[i] = dmodel.StockLine{
+			LocationID:    int(line.LocationId),
+			ProductID:     int(line.ProductId),
+			ReservationID: int(line.ReservationId),
+			Quantity:      int(line.Quantity),
+			SerialNumbers: line.SerialNumbers,
+		}
+	}
+	return lines
+}
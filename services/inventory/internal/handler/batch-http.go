@@ -0,0 +1,103 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// batch operations
+// -------------------------------------------------------------------
+
+// request body of the batch operations (owner, ttl_seconds, policy and destination only
+// apply to reservations)
+type batchRequest struct {
+	Lines       []dmodel.StockLine  `json:"lines"`
+	Owner       string              `json:"owner"`
+	TTLSeconds  int                 `json:"ttl_seconds"`
+	Policy      string              `json:"policy"`
+	Destination *dmodel.Coordinates `json:"destination"`
+}
+
+// signature of the controller's idempotent batch operations
+type batchOperation func(ctx context.Context, key string, req batchRequest) (*dmodel.BatchUpdate, bool, error)
+
+func (h *Handler_Inventory) Reserve_StockBatch(w http.ResponseWriter, r *http.Request) {
+	h.applyBatch(w, r, "Reserved stock", func(ctx context.Context, key string, req batchRequest) (*dmodel.BatchUpdate, bool, error) {
+		ttl := time.Duration(req.TTLSeconds) * time.Second
+		alloc := dmodel.Allocation{Policy: req.Policy, Destination: req.Destination}
+		return h.controller.Reserve_StockBatchIdempotent(ctx, key, req.Lines, req.Owner, ttl, alloc)
+	})
+}
+
+func (h *Handler_Inventory) Release_ReservationBatch(w http.ResponseWriter, r *http.Request) {
+	h.applyBatch(w, r, "Released reservations", func(ctx context.Context, key string, req batchRequest) (*dmodel.BatchUpdate, bool, error) {
+		return h.controller.Release_ReservationBatchIdempotent(ctx, key, req.Lines)
+	})
+}
+
+func (h *Handler_Inventory) Fulfill_ReservationBatch(w http.ResponseWriter, r *http.Request) {
+	h.applyBatch(w, r, "Fulfilled reservations", func(ctx context.Context, key string, req batchRequest) (*dmodel.BatchUpdate, bool, error) {
+		return h.controller.Fulfill_ReservationBatchIdempotent(ctx, key, req.Lines)
+	})
+}
+
+// decodes the lines of a batch request, applies them with operation and writes the updated items
+// a rejected batch gets 409 with every failed line
+func (h *Handler_Inventory) applyBatch(w http.ResponseWriter, r *http.Request, done string, operation batchOperation) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var template_req batchRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	res, replayed, err := operation(ctx, r.Header.Get(idempotencyKeyHeader), template_req)
+	if err != nil {
+		var batchErr *internal.BatchError
+		if errors.As(err, &batchErr) {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]any{
+				"error":    batchErr.Error(),
+				"failures": batchErr.Failures,
+			})
+			return
+		}
+		if errors.Is(err, internal.ErrEmptyBatch) || errors.Is(err, internal.ErrInvalidQuantity) || errors.Is(err, internal.ErrInvalidOwner) ||
+			errors.Is(err, internal.ErrLocationNotFound) || errors.Is(err, internal.ErrInvalidPolicy) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if writeSerialError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error applying inventory batch: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding updated inventory items to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("%s for %d reservations", done, len(res.Reservations))
+}
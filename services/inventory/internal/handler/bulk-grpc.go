@@ -0,0 +1,73 @@
+package inventory_handler_http
+
+import (
+	"errors"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// bulk import
+// -------------------------------------------------------------------
+
+// ImportInventory reads every message of the stream before applying the import, taking
+// its options from the first one
+func (h *Handler_Inventory_GRPC) ImportInventory(stream pb.InventoryService_ImportInventoryServer) error {
+	var rows []dmodel.ImportRow
+	var options dmodel.ImportOptions
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first {
+			options = dmodel.ImportOptions{Mode: req.Mode, DryRun: req.DryRun, Reference: req.Reference}
+		}
+		for _, row := range req.Rows {
+			rows = append(rows, dmodel.ImportRow{
+				Line:       len(rows) + 1,
+				ProductID:  int(row.ProductId),
+				LocationID: int(row.LocationId),
+				Stock:      int(row.Stock),
+			})
+		}
+	}
+
+	res, err := h.controller.Import_Inventory(stream.Context(), rows, nil, options)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidImport) {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return status.Errorf(codes.Internal, "internal server error")
+	}
+
+	errs := make([]*pb.ImportError, len(res.Errors))
+	for i, e := range res.Errors {
+		errs[i] = &pb.ImportError{
+			Line:       int32(e.Line),
+			ProductId:  int32(e.ProductID),
+			LocationId: int32(e.LocationID),
+			Reason:     e.Reason,
+		}
+	}
+
+	return stream.SendAndClose(&pb.ImportInventoryResponse{
+		Mode:      res.Mode,
+		DryRun:    res.DryRun,
+		Committed: res.Committed,
+		Rows:      int32(res.Rows),
+		Applied:   int32(res.Applied),
+		Unchanged: int32(res.Unchanged),
+		Failed:    int32(res.Failed),
+		Errors:    errs,
+	})
+}
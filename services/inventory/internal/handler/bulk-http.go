@@ -0,0 +1,300 @@
+package inventory_handler_http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// bulk import and export
+// -------------------------------------------------------------------
+
+// file formats of imports and exports
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// columns of an export; an import reads product_id, location_id (optional) and stock, and
+// ignores the others, so an export can be imported back
+var exportColumns = []string{"product_id", "location_id", "stock", "reserved", "damaged", "in_transit", "reorder_point", "reorder_quantity", "version"}
+
+// format named by the format query parameter, or else by the given header
+func fileFormat(r *http.Request, header string) (string, error) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		value := r.Header.Get(header)
+		switch {
+		case strings.Contains(value, "csv"):
+			format = formatCSV
+		case strings.Contains(value, "ndjson"), strings.Contains(value, "jsonl"):
+			format = formatNDJSON
+		}
+	}
+	if format != formatCSV && format != formatNDJSON {
+		return "", fmt.Errorf("%w: unknown format %q (csv or ndjson)", internal.ErrInvalidImport, format)
+	}
+	return format, nil
+}
+
+// reads the rows of a CSV file with a header line; rows that cannot be read are returned
+// as failed rows, a header without the required columns fails the whole file
+func readCSVRows(body io.Reader) ([]dmodel.ImportRow, []dmodel.ImportError, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading the header: %v", internal.ErrInvalidImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"product_id", "stock"} {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("%w: missing %s column", internal.ErrInvalidImport, name)
+		}
+	}
+
+	var rows []dmodel.ImportRow
+	var invalid []dmodel.ImportError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			invalid = append(invalid, dmodel.ImportError{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := dmodel.ImportRow{Line: line}
+		fields := []struct {
+			name     string
+			value    *int
+			optional bool
+		}{
+			{"product_id", &row.ProductID, false},
+			{"location_id", &row.LocationID, true},
+			{"stock", &row.Stock, false},
+		}
+		var reason string
+		for _, field := range fields {
+			i, ok := columns[field.name]
+			if !ok {
+				continue
+			}
+			value := ""
+			if i < len(record) {
+				value = strings.TrimSpace(record[i])
+			}
+			if value == "" && field.optional {
+				continue
+			}
+			if *field.value, err = strconv.Atoi(value); err != nil {
+				reason = fmt.Sprintf("invalid %s %q", field.name, value)
+				break
+			}
+		}
+		if reason != "" {
+			invalid = append(invalid, dmodel.ImportError{Line: line, Reason: reason})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, invalid, nil
+}
+
+// reads the rows of an NDJSON file, one JSON object per line; blank lines are skipped
+func readNDJSONRows(body io.Reader) ([]dmodel.ImportRow, []dmodel.ImportError, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []dmodel.ImportRow
+	var invalid []dmodel.ImportError
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record struct {
+			ProductID  *int `json:"product_id"`
+			LocationID int  `json:"location_id"`
+			Stock      *int `json:"stock"`
+		}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			invalid = append(invalid, dmodel.ImportError{Line: line, Reason: err.Error()})
+			continue
+		}
+		if record.ProductID == nil || record.Stock == nil {
+			invalid = append(invalid, dmodel.ImportError{Line: line, Reason: "product_id and stock are required"})
+			continue
+		}
+		rows = append(rows, dmodel.ImportRow{Line: line, ProductID: *record.ProductID, LocationID: record.LocationID, Stock: *record.Stock})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", internal.ErrInvalidImport, err)
+	}
+
+	return rows, invalid, nil
+}
+
+// Import_Inventory sets the stock of the items listed in a CSV or NDJSON file, picked by
+// the format query parameter or the Content-Type header
+// query parameters: mode (all_or_nothing or best_effort), dry_run and reference
+// a rejected all or nothing import gets 409 with its result, which lists every failed row
+func (h *Handler_Inventory) Import_Inventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	format, err := fileFormat(r, "Content-Type")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options := dmodel.ImportOptions{Mode: query.Get("mode"), Reference: query.Get("reference")}
+	if value := query.Get("dry_run"); value != "" {
+		if options.DryRun, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, fmt.Sprintf("invalid dry_run %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	read := readCSVRows
+	if format == formatNDJSON {
+		read = readNDJSONRows
+	}
+	rows, invalid, err := read(r.Body)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidImport) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error reading import file: %v", err)
+		http.Error(w, "Invalid import file", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	res, err := h.controller.Import_Inventory(ctx, rows, invalid, options)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidImport) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error importing inventory: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !res.DryRun && !res.Committed {
+		w.WriteHeader(http.StatusConflict)
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding import result to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Imported inventory (%s, dry run %t): %d row(s), %d applied, %d unchanged, %d failed",
+		res.Mode, res.DryRun, res.Rows, res.Applied, res.Unchanged, res.Failed)
+}
+
+// Export_Inventory streams every item, or the items at the location given by the
+// location_id query parameter, as CSV or NDJSON, picked by the format query parameter or
+// the Accept header (CSV when neither names a format)
+func (h *Handler_Inventory) Export_Inventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format := formatCSV
+	if r.URL.Query().Get("format") != "" {
+		f, err := fileFormat(r, "Accept")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		format = f
+	} else if f, err := fileFormat(r, "Accept"); err == nil {
+		format = f
+	}
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the CSV writer buffers its output, so nothing is sent before the first item
+	var write func(*dmodel.InventoryItem) error
+	var flush func() error
+	switch format {
+	case formatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		writer := csv.NewWriter(w)
+		writer.Write(exportColumns)
+		write = func(item *dmodel.InventoryItem) error {
+			return writer.Write([]string{
+				strconv.Itoa(item.ProductID), strconv.Itoa(item.LocationID), strconv.Itoa(item.Stock),
+				strconv.Itoa(item.Reserved), strconv.Itoa(item.Damaged), strconv.Itoa(item.InTransit),
+				strconv.Itoa(item.ReorderPoint), strconv.Itoa(item.ReorderQuantity), strconv.Itoa(item.Version),
+			})
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case formatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(w)
+		write = func(item *dmodel.InventoryItem) error {
+			return encoder.Encode(item)
+		}
+		flush = func() error { return nil }
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"inventory.%s\"", format))
+
+	// streaming the items as they are read; once one is written, an error can only cut
+	// the response short
+	written := false
+	err = h.controller.Export_Inventory(ctx, locationID, func(item *dmodel.InventoryItem) error {
+		written = true
+		return write(item)
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		log.Printf("Error exporting inventory: %v", err)
+		if !written {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	// logging
+	log.Printf("Exported inventory as %s", format)
+}
@@ -0,0 +1,119 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// costing and valuation
+// -------------------------------------------------------------------
+
+// converts a domain product costing into its protobuf representation
+func toPBCosting(c *dmodel.ProductCosting) *pb.ProductCosting {
+	layers := make([]*pb.CostLayer, len(c.Layers))
+	for i, l := range c.Layers {
+		layers[i] = &pb.CostLayer{
+			Id:         int32(l.ID),
+			Quantity:   int32(l.Quantity),
+			Remaining:  int32(l.Remaining),
+			UnitCost:   toPBMoney(l.UnitCost),
+			Value:      toPBMoney(l.Value),
+			ReceivedAt: l.ReceivedAt.Format(time.RFC3339),
+		}
+	}
+	return &pb.ProductCosting{
+		ProductId: int32(c.ProductID),
+		Method:    c.Method,
+		Quantity:  int32(c.Quantity),
+		Value:     toPBMoney(c.Value),
+		UnitCost:  toPBMoney(c.UnitCost),
+		Layers:    layers,
+	}
+}
+
+func (h *Handler_Inventory_GRPC) GetCosting(ctx context.Context, req *pb.GetCostingRequest) (*pb.GetCostingResponse, error) {
+	costing, err := h.controller.Get_Costing(ctx, int(req.ProductId))
+	if err != nil {
+		if errors.Is(err, internal.ErrItemNotFound) {
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.GetCostingResponse{
+		Costing: toPBCosting(costing),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) SetCostingMethod(ctx context.Context, req *pb.SetCostingMethodRequest) (*pb.SetCostingMethodResponse, error) {
+	costing, err := h.controller.Set_CostingMethod(ctx, int(req.ProductId), req.Method)
+	if err != nil {
+		switch {
+		case errors.Is(err, internal.ErrItemNotFound):
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		case errors.Is(err, internal.ErrInvalidCostingMethod):
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.SetCostingMethodResponse{
+		Costing: toPBCosting(costing),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetValuation(ctx context.Context, req *pb.GetValuationRequest) (*pb.GetValuationResponse, error) {
+	filter := dmodel.ValuationFilter{LocationID: int(req.LocationId), Category: req.Category}
+	asOf, err := parseTime(req.AsOf)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if asOf != nil {
+		filter.AsOf = *asOf
+	}
+
+	valuation, err := h.controller.Get_Valuation(ctx, filter)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	products := make([]*pb.ValuationLine, len(valuation.Products))
+	for i, line := range valuation.Products {
+		products[i] = &pb.ValuationLine{
+			ProductId: int32(line.ProductID),
+			Name:      line.Name,
+			Category:  line.Category,
+			Method:    line.Method,
+			Quantity:  int32(line.Quantity),
+			Value:     toPBMoney(line.Value),
+			UnitCost:  toPBMoney(line.UnitCost),
+		}
+	}
+	categories := make([]*pb.ValuationCategory, len(valuation.Categories))
+	for i, category := range valuation.Categories {
+		categories[i] = &pb.ValuationCategory{
+			Category: category.Category,
+			Products: int32(category.Products),
+			Quantity: int32(category.Quantity),
+			Value:    toPBMoney(category.Value),
+		}
+	}
+
+	return &pb.GetValuationResponse{
+		AsOf:       valuation.AsOf.Format(time.RFC3339),
+		Products:   products,
+		Categories: categories,
+	}, nil
+}
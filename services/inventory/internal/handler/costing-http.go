@@ -0,0 +1,148 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// costing and valuation
+// -------------------------------------------------------------------
+
+// Get_Costing returns the costing method of a product and the cost layers of its stock
+func (h *Handler_Inventory) Get_Costing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	costing, err := h.controller.Get_Costing(ctx, productID)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting product costing: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(costing)
+	if err != nil {
+		log.Printf("Error encoding product costing to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Set_CostingMethod sets the costing method of a product, fifo or weighted_average
+func (h *Handler_Inventory) Set_CostingMethod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		Method string `json:"method"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	costing, err := h.controller.Set_CostingMethod(ctx, productID, template_req.Method)
+	if err != nil {
+		switch {
+		case errors.Is(err, internal.ErrItemNotFound):
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		case errors.Is(err, internal.ErrInvalidCostingMethod):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error setting costing method: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(costing)
+	if err != nil {
+		log.Printf("Error encoding product costing to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Set costing method of product %d to %s", productID, costing.Method)
+}
+
+// Get_Valuation values the stock on hand per product and category
+// query parameters: as_of (RFC 3339, now when empty), location_id and category
+func (h *Handler_Inventory) Get_Valuation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := dmodel.ValuationFilter{LocationID: locationID, Category: query.Get("category")}
+	asOf, err := parseTime(query.Get("as_of"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if asOf != nil {
+		filter.AsOf = *asOf
+	}
+
+	// getting the controller's response
+	valuation, err := h.controller.Get_Valuation(ctx, filter)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error getting inventory valuation: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(valuation)
+	if err != nil {
+		log.Printf("Error encoding inventory valuation to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
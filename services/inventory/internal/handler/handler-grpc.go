@@ -2,13 +2,18 @@ package inventory_handler_http
 
 import (
 	"context"
+	"errors"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	internal "inventory-service/internal"
 	inventory_controller "inventory-service/internal/controller"
+	dmodel "inventory-service/pkg"
 	pb "inventory-service/proto/inventory"
+	"shared/money"
 )
 
 type Handler_Inventory_GRPC struct {
@@ -22,145 +27,262 @@ func NewGRPC(controller *inventory_controller.Controller_Inventory) *Handler_Inv
 	}
 }
 
+// idempotency metadata keys (gRPC equivalents of the Idempotency-Key / Idempotent-Replayed headers)
+const (
+	idempotencyKeyMetadata     = "idempotency-key"
+	idempotentReplayedMetadata = "idempotent-replayed"
+)
+
+// returns the idempotency key sent in the request metadata, if any
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(idempotencyKeyMetadata); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// maps idempotency key errors to gRPC statuses, returns nil for any other error
+func idempotencyStatus(err error) error {
+	switch err {
+	case internal.ErrInvalidIdempotencyKey:
+		return status.Errorf(codes.InvalidArgument, "invalid idempotency key")
+	case internal.ErrIdempotencyKeyInProgress:
+		return status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress")
+	case internal.ErrIdempotencyKeyReused:
+		return status.Errorf(codes.InvalidArgument, "idempotency key was already used for a different request")
+	}
+	return nil
+}
+
+// gRPC metadata key identifying who performs a request (equivalent of the X-Actor header)
+const actorMetadata = "x-actor"
+
+// ActorInterceptor stores the caller named in the request metadata in the context
+func ActorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withMetadataActor(ctx), req)
+}
+
+// ActorStreamInterceptor is the ActorInterceptor of streaming RPCs
+func ActorStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &actorStream{ServerStream: stream, ctx: withMetadataActor(stream.Context())})
+}
+
+// a server stream whose context carries the caller
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
+
+func withMetadataActor(ctx context.Context) context.Context {
+	actor := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(actorMetadata); len(values) > 0 {
+			actor = values[0]
+		}
+	}
+	return internal.WithActor(ctx, actor)
+}
+
+// converts a domain amount into its protobuf representation
+func toPBMoney(m money.Money) *pb.Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	return &pb.Money{Amount: m.Amount, Currency: currency}
+}
+
+// converts an optional protobuf amount into its domain representation, nil when unset
+func fromPBMoney(m *pb.Money) *money.Money {
+	if m == nil {
+		return nil
+	}
+	amount := money.New(m.Amount, m.Currency)
+	return &amount
+}
+
+// converts a domain inventory item into its protobuf representation
+func toPBItem(item *dmodel.InventoryItem) *pb.InventoryItem {
+	return &pb.InventoryItem{
+		LocationId:      int32(item.LocationID),
+		ProductId:       int32(item.ProductID),
+		Stock:           int32(item.Stock),
+		Reserved:        int32(item.Reserved),
+		Damaged:         int32(item.Damaged),
+		InTransit:       int32(item.InTransit),
+		Version:         int32(item.Version),
+		ReorderPoint:    int32(item.ReorderPoint),
+		ReorderQuantity: int32(item.ReorderQuantity),
+		Expired:         int32(item.Expired),
+		SerialTracked:   item.SerialTracked,
+		Locations:       toPBItems(item.Locations),
+	}
+}
+
+func toPBItems(items []*dmodel.InventoryItem) []*pb.InventoryItem {
+	pbItems := make([]*pb.InventoryItem, len(items))
+	for i, item := range items {
+		pbItems[i] = toPBItem(item)
+	}
+	return pbItems
+}
+
 func (h *Handler_Inventory_GRPC) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.GetInventoryResponse, error) {
-	item, err := h.controller.Get_ByProductID(ctx, int(req.ProductId))
+	asOf, err := parseTime(req.AsOf)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var item *dmodel.InventoryItem
+	if asOf != nil {
+		item, err = h.controller.Get_ByProductIDAsOf(ctx, int(req.ProductId), int(req.LocationId), *asOf)
+	} else {
+		item, err = h.controller.Get_ByProductID(ctx, int(req.ProductId), int(req.LocationId))
+	}
 	if err != nil {
 		if err == internal.ErrItemNotFound {
 			return nil, status.Errorf(codes.NotFound, "inventory not found")
 		}
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
 	return &pb.GetInventoryResponse{
-		Item: &pb.InventoryItem{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Stock),
-			Reserved:  int32(item.Reserved),
-		},
+		Item: toPBItem(item),
 	}, nil
 }
 
 func (h *Handler_Inventory_GRPC) ListInventory(ctx context.Context, req *pb.ListInventoryRequest) (*pb.ListInventoryResponse, error) {
-	items, err := h.controller.Get_All(ctx)
+	asOf, err := parseTime(req.AsOf)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal server error")
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	pbItems := make([]*pb.InventoryItem, len(items))
-	for i, item := range items {
-		pbItems[i] = &pb.InventoryItem{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Stock),
-			Reserved:  int32(item.Reserved),
+	var items []*dmodel.InventoryItem
+	if asOf != nil {
+		items, err = h.controller.Get_AllAsOf(ctx, int(req.LocationId), *asOf)
+	} else {
+		items, err = h.controller.Get_All(ctx, int(req.LocationId))
+	}
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
 	return &pb.ListInventoryResponse{
-		Items: pbItems,
+		Items: toPBItems(items),
 	}, nil
 }
 
 func (h *Handler_Inventory_GRPC) UpdateStock(ctx context.Context, req *pb.UpdateStockRequest) (*pb.UpdateStockResponse, error) {
-	err := h.controller.Update_Stock(ctx, int(req.ProductId), int(req.Quantity))
+	updatedItem, err := h.controller.Update_Stock(ctx, int(req.ProductId), int(req.LocationId), int(req.Quantity), int(req.ExpectedVersion), req.Reference)
 	if err != nil {
-		if err == internal.ErrItemNotFound {
+		switch err {
+		case internal.ErrItemNotFound:
 			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		case internal.ErrLocationNotFound:
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		case internal.ErrLocationRequired:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case internal.ErrVersionMismatch:
+			return nil, status.Errorf(codes.Aborted, "%v", err)
+		case internal.ErrStockBelowReserved:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if st := serialStatus(err); st != nil {
+			return nil, st
 		}
-		return nil, status.Errorf(codes.Internal, "internal server error")
-	}
-
-	// Get the updated item
-	updatedItem, err := h.controller.Get_ByProductID(ctx, int(req.ProductId))
-	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
 	return &pb.UpdateStockResponse{
-		Item: &pb.InventoryItem{
-			ProductId: int32(updatedItem.ProductID),
-			Stock:     int32(updatedItem.Stock),
-			Reserved:  int32(updatedItem.Reserved),
-		},
+		Item: toPBItem(updatedItem),
 	}, nil
 }
 
-func (h *Handler_Inventory_GRPC) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
-	err := h.controller.Reserve_Stock(ctx, int(req.ProductId), int(req.Stock))
-	if err != nil {
-		if err == internal.ErrItemNotFound {
-			return nil, status.Errorf(codes.NotFound, "inventory not found")
-		}
-		if err == internal.ErrInsufficientStock {
-			return nil, status.Errorf(codes.FailedPrecondition, "insufficient stock")
-		}
-		return nil, status.Errorf(codes.Internal, "internal server error")
-	}
-
-	// Get the updated item
-	item, err := h.controller.Get_ByProductID(ctx, int(req.ProductId))
+func (h *Handler_Inventory_GRPC) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	expiresAt, err := parseTime(req.ExpiresAt)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal server error")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
 	}
 
-	return &pb.ReserveStockResponse{
-		Item: &pb.InventoryItem{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Stock),
-			Reserved:  int32(item.Reserved),
-		},
-	}, nil
-}
-
-func (h *Handler_Inventory_GRPC) FulfillReservation(ctx context.Context, req *pb.FulfillReservationRequest) (*pb.FulfillReservationResponse, error) {
-	err := h.controller.Fulfill_Reservation(ctx, int(req.ProductId), int(req.Stock))
+	item, replayed, err := h.controller.Adjust_StockIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.ProductId), int(req.LocationId), int(req.Delta), fromPBMoney(req.UnitCost), req.LotNumber, expiresAt, req.Reason, req.Reference)
 	if err != nil {
-		if err == internal.ErrItemNotFound {
+		if errors.Is(err, internal.ErrInvalidCost) || errors.Is(err, internal.ErrInvalidLot) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		switch err {
+		case internal.ErrItemNotFound:
 			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		case internal.ErrLocationNotFound:
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		case internal.ErrStockBelowReserved:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		case internal.ErrInvalidQuantity, internal.ErrInvalidReason:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if st := serialStatus(err); st != nil {
+			return nil, st
 		}
-		if err == internal.ErrInsufficientReserved {
-			return nil, status.Errorf(codes.FailedPrecondition, "insufficient reserved stock")
+		if st := idempotencyStatus(err); st != nil {
+			return nil, st
 		}
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
-
-	// Get the updated item
-	item, err := h.controller.Get_ByProductID(ctx, int(req.ProductId))
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal server error")
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
 	}
 
-	return &pb.FulfillReservationResponse{
-		Item: &pb.InventoryItem{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Stock),
-			Reserved:  int32(item.Reserved),
-		},
+	return &pb.AdjustStockResponse{
+		Item: toPBItem(item),
 	}, nil
 }
 
-func (h *Handler_Inventory_GRPC) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
-	err := h.controller.Release_Reservation(ctx, int(req.ProductId), int(req.Stock))
+func (h *Handler_Inventory_GRPC) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	lots, err := fromPBLotQuantities(req.Lots)
 	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
+	}
+
+	item, replayed, err := h.controller.Receive_ReturnIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.ProductId), int(req.LocationId), int(req.Restock), int(req.Damaged), req.SerialNumbers, req.DamagedSerialNumbers, lots, req.Reference)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidLot) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		if err == internal.ErrItemNotFound {
 			return nil, status.Errorf(codes.NotFound, "inventory not found")
 		}
-		if err == internal.ErrInsufficientReserved {
-			return nil, status.Errorf(codes.FailedPrecondition, "insufficient reserved stock")
+		if err == internal.ErrLocationNotFound {
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		}
+		if err == internal.ErrInvalidQuantity {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity")
+		}
+		if err := serialStatus(err); err != nil {
+			return nil, err
+		}
+		if err := idempotencyStatus(err); err != nil {
+			return nil, err
 		}
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
-
-	// Get the updated item
-	item, err := h.controller.Get_ByProductID(ctx, int(req.ProductId))
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal server error")
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
 	}
 
-	return &pb.ReleaseReservationResponse{
-		Item: &pb.InventoryItem{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Stock),
-			Reserved:  int32(item.Reserved),
-		},
+	return &pb.ReceiveReturnResponse{
+		Item: toPBItem(item),
 	}, nil
 }
@@ -2,20 +2,49 @@ package inventory_handler_http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	internal "inventory-service/internal"
 	inventory_controller "inventory-service/internal/controller"
+	dmodel "inventory-service/pkg"
+	"shared/money"
 )
 
+// idempotency headers
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// writes the HTTP error for idempotency key errors, returns false for any other error
+func writeIdempotencyError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case internal.ErrInvalidIdempotencyKey:
+		http.Error(w, "Invalid Idempotency-Key header", http.StatusBadRequest)
+	case internal.ErrIdempotencyKeyInProgress:
+		http.Error(w, "A request with this Idempotency-Key is still in progress", http.StatusConflict)
+	case internal.ErrIdempotencyKeyReused:
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+	default:
+		return false
+	}
+	return true
+}
+
 func AddCORSHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key, X-Actor, If-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "Idempotent-Replayed, ETag")
 
 		// CORS preflight request (OPTIONS) handling
 		if r.Method == http.MethodOptions {
@@ -27,6 +56,63 @@ func AddCORSHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// header identifying who performs a request, recorded in the stock movement ledger
+const actorHeader = "X-Actor"
+
+// AddActor stores the caller named in the X-Actor header in the request context
+func AddActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(internal.WithActor(r.Context(), r.Header.Get(actorHeader))))
+	})
+}
+
+// entity tag of a version of an inventory item
+func etag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// version required by the If-Match header of a request, 0 when any version matches
+// (no header, or "*")
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match header %q", value)
+	}
+	return version, nil
+}
+
+// location named by the location_id query parameter, 0 when missing
+func locationParam(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("location_id")
+	if value == "" {
+		return 0, nil
+	}
+	locationID, err := strconv.Atoi(value)
+	if err != nil || locationID <= 0 {
+		return 0, fmt.Errorf("invalid location_id %q", value)
+	}
+	return locationID, nil
+}
+
+// parses an optional RFC 3339 time, nil when empty
+// the time is converted to UTC: the TIMESTAMP columns hold UTC times and PostgreSQL drops
+// the offset of a parameter compared with them
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", internal.ErrInvalidTimeRange, err)
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
 type Handler_Inventory struct {
 	controller *inventory_controller.Controller_Inventory
 }
@@ -37,59 +123,54 @@ func New(controller *inventory_controller.Controller_Inventory) *Handler_Invento
 	}
 }
 
+// Get_All lists the totals of every product, or the items at the location given by the
+// location_id query parameter; as they were at the as_of query parameter when given
 func (h *Handler_Inventory) Get_All(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
 
-	// getting the controller's response
-	items, err := h.controller.Get_All(ctx)
+	locationID, err := locationParam(r)
 	if err != nil {
-		log.Printf("Error getting all inventory items: Repository error: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
 		return
 	}
-
-	// encoding the response to JSON
-	err = json.NewEncoder(w).Encode(items)
+	asOf, err := parseTime(r.URL.Query().Get("as_of"))
 	if err != nil {
-		log.Printf("Error encoding inventory items to JSON: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-}
-
-func (h *Handler_Inventory) Get_ByProductID(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	w.Header().Set("Content-Type", "application/json")
-
-	r_params := mux.Vars(r)
-	productID, err := strconv.Atoi(r_params["productId"])
-	if err != nil {
-		log.Printf("Error getting product ID from URL: %v", err)
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// getting the controller's response
-	item, err := h.controller.Get_ByProductID(ctx, productID)
+	var items []*dmodel.InventoryItem
+	if asOf != nil {
+		items, err = h.controller.Get_AllAsOf(ctx, locationID, *asOf)
+	} else {
+		items, err = h.controller.Get_All(ctx, locationID)
+	}
 	if err != nil {
-		log.Printf("Error getting inventory item by product ID: %v", err)
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error getting all inventory items: Repository error: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
 	// encoding the response to JSON
-	err = json.NewEncoder(w).Encode(item)
+	err = json.NewEncoder(w).Encode(items)
 	if err != nil {
-		log.Printf("Error encoding inventory item to JSON: %v", err)
+		log.Printf("Error encoding inventory items to JSON: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *Handler_Inventory) Update_Stock(w http.ResponseWriter, r *http.Request) {
+// Get_ByProductID returns the totals of a product with their per location breakdown, or
+// its item at the location given by the location_id query parameter; as they were at the
+// as_of query parameter when given
+func (h *Handler_Inventory) Get_ByProductID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
@@ -101,42 +182,52 @@ func (h *Handler_Inventory) Update_Stock(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Invalid product ID", http.StatusBadRequest)
 		return
 	}
-
-	var template_req struct {
-		Stock int `json:"stock"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
-		log.Printf("Error decoding JSON request body: %v", err)
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
 		return
 	}
-
-	if err := h.controller.Update_Stock(ctx, productID, template_req.Stock); err != nil {
-		log.Printf("Error updating inventory stock: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	asOf, err := parseTime(r.URL.Query().Get("as_of"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	item, err := h.controller.Get_ByProductID(ctx, productID)
+	// getting the controller's response
+	var item *dmodel.InventoryItem
+	if asOf != nil {
+		item, err = h.controller.Get_ByProductIDAsOf(ctx, productID, locationID, *asOf)
+	} else {
+		item, err = h.controller.Get_ByProductID(ctx, productID, locationID)
+	}
 	if err != nil {
-		log.Printf("Error getting updated inventory item by product ID: %v", err)
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, internal.ErrInvalidTimeRange) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error getting inventory item by product ID: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	// a past state has no version to update it at
+	if asOf == nil {
+		w.Header().Set("ETag", etag(item.Version))
+	}
 
 	// encoding the response to JSON
 	err = json.NewEncoder(w).Encode(item)
 	if err != nil {
-		log.Printf("Error encoding updated inventory item to JSON: %v", err)
+		log.Printf("Error encoding inventory item to JSON: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
-	// logging
-	log.Printf("Updated inventory item: %+v", item)
 }
 
-func (h *Handler_Inventory) Reserve_Stock(w http.ResponseWriter, r *http.Request) {
+func (h *Handler_Inventory) Update_Stock(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
@@ -150,7 +241,9 @@ func (h *Handler_Inventory) Reserve_Stock(w http.ResponseWriter, r *http.Request
 	}
 
 	var template_req struct {
-		Stock int `json:"stock"`
+		LocationID int    `json:"location_id"`
+		Stock      int    `json:"stock"`
+		Reference  string `json:"reference"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
@@ -159,18 +252,39 @@ func (h *Handler_Inventory) Reserve_Stock(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := h.controller.Reserve_Stock(ctx, productID, template_req.Stock); err != nil {
-		log.Printf("Error reserving inventory stock: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		http.Error(w, "Invalid If-Match header", http.StatusBadRequest)
 		return
 	}
 
-	item, err := h.controller.Get_ByProductID(ctx, productID)
+	item, err := h.controller.Update_Stock(ctx, productID, template_req.LocationID, template_req.Stock, expectedVersion, template_req.Reference)
 	if err != nil {
-		log.Printf("Error getting updated inventory item by product ID: %v", err)
+		switch err {
+		case internal.ErrItemNotFound:
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		case internal.ErrLocationNotFound:
+			http.Error(w, "Location not found", http.StatusNotFound)
+			return
+		case internal.ErrLocationRequired:
+			http.Error(w, "Product is stocked at more than one location, set location_id", http.StatusBadRequest)
+			return
+		case internal.ErrVersionMismatch:
+			http.Error(w, "Inventory item was modified, fetch it again", http.StatusPreconditionFailed)
+			return
+		case internal.ErrStockBelowReserved:
+			http.Error(w, "Stock cannot drop below zero or the reserved quantity", http.StatusConflict)
+			return
+		}
+		if writeSerialError(w, err) {
+			return
+		}
+		log.Printf("Error updating inventory stock: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("ETag", etag(item.Version))
 
 	// encoding the response to JSON
 	err = json.NewEncoder(w).Encode(item)
@@ -179,12 +293,11 @@ func (h *Handler_Inventory) Reserve_Stock(w http.ResponseWriter, r *http.Request
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	// logging
-	log.Printf("Reserved inventory item: %+v", item)
+	log.Printf("Updated inventory item: %+v", item)
 }
 
-func (h *Handler_Inventory) Release_Reservation(w http.ResponseWriter, r *http.Request) {
+func (h *Handler_Inventory) Adjust_Stock(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
@@ -198,7 +311,13 @@ func (h *Handler_Inventory) Release_Reservation(w http.ResponseWriter, r *http.R
 	}
 
 	var template_req struct {
-		Stock int `json:"stock"`
+		LocationID int          `json:"location_id"`
+		Delta      int          `json:"delta"`
+		UnitCost   *money.Money `json:"unit_cost"`  // cost of each unit added (optional)
+		LotNumber  string       `json:"lot_number"` // lot the units are added to or taken from (optional)
+		ExpiresAt  *time.Time   `json:"expires_at"` // expiry date of a lot units are added to (optional)
+		Reason     string       `json:"reason"`
+		Reference  string       `json:"reference"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
@@ -207,32 +326,52 @@ func (h *Handler_Inventory) Release_Reservation(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err := h.controller.Release_Reservation(ctx, productID, template_req.Stock); err != nil {
-		log.Printf("Error releasing inventory reservation: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	item, err := h.controller.Get_ByProductID(ctx, productID)
+	item, replayed, err := h.controller.Adjust_StockIdempotent(ctx, r.Header.Get(idempotencyKeyHeader), productID, template_req.LocationID, template_req.Delta, template_req.UnitCost, template_req.LotNumber, template_req.ExpiresAt, template_req.Reason, template_req.Reference)
 	if err != nil {
-		log.Printf("Error getting updated inventory item by product ID: %v", err)
+		if errors.Is(err, internal.ErrInvalidCost) || errors.Is(err, internal.ErrInvalidLot) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch err {
+		case internal.ErrItemNotFound:
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		case internal.ErrLocationNotFound:
+			http.Error(w, "Location not found", http.StatusNotFound)
+			return
+		case internal.ErrStockBelowReserved:
+			http.Error(w, "Stock cannot drop below zero or the reserved quantity", http.StatusConflict)
+			return
+		case internal.ErrInvalidQuantity:
+			http.Error(w, "Invalid delta", http.StatusBadRequest)
+			return
+		case internal.ErrInvalidReason:
+			http.Error(w, "Invalid reason", http.StatusBadRequest)
+			return
+		}
+		if writeSerialError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error adjusting inventory stock: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
 
 	// encoding the response to JSON
 	err = json.NewEncoder(w).Encode(item)
 	if err != nil {
-		log.Printf("Error encoding updated inventory item to JSON: %v", err)
+		log.Printf("Error encoding adjusted inventory item to JSON: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	// logging
-	log.Printf("Released reservation for inventory item: %+v", item)
+	log.Printf("Adjusted inventory item by %d (%s): %+v", template_req.Delta, template_req.Reason, item)
 }
 
-func (h *Handler_Inventory) Fulfill_Reservation(w http.ResponseWriter, r *http.Request) {
+func (h *Handler_Inventory) Receive_Return(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	w.Header().Set("Content-Type", "application/json")
@@ -246,7 +385,13 @@ func (h *Handler_Inventory) Fulfill_Reservation(w http.ResponseWriter, r *http.R
 	}
 
 	var template_req struct {
-		Stock int `json:"stock"`
+		LocationID           int                  `json:"location_id"`
+		Restock              int                  `json:"restock"`
+		Damaged              int                  `json:"damaged"`
+		SerialNumbers        []string             `json:"serial_numbers"`
+		DamagedSerialNumbers []string             `json:"damaged_serial_numbers"`
+		Lots                 []dmodel.LotQuantity `json:"lots"` // lots the restocked units go back to (optional)
+		Reference            string               `json:"reference"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
@@ -255,18 +400,34 @@ func (h *Handler_Inventory) Fulfill_Reservation(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err := h.controller.Fulfill_Reservation(ctx, productID, template_req.Stock); err != nil {
-		log.Printf("Error fulfilling inventory reservation: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	item, err := h.controller.Get_ByProductID(ctx, productID)
+	item, replayed, err := h.controller.Receive_ReturnIdempotent(ctx, r.Header.Get(idempotencyKeyHeader), productID, template_req.LocationID, template_req.Restock, template_req.Damaged, template_req.SerialNumbers, template_req.DamagedSerialNumbers, template_req.Lots, template_req.Reference)
 	if err != nil {
-		log.Printf("Error getting updated inventory item by product ID: %v", err)
+		if errors.Is(err, internal.ErrInvalidLot) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		}
+		if err == internal.ErrLocationNotFound {
+			http.Error(w, "Location not found", http.StatusNotFound)
+			return
+		}
+		if err == internal.ErrInvalidQuantity {
+			http.Error(w, "Invalid quantity", http.StatusBadRequest)
+			return
+		}
+		if writeSerialError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error receiving returned inventory: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
 
 	// encoding the response to JSON
 	err = json.NewEncoder(w).Encode(item)
@@ -275,7 +436,6 @@ func (h *Handler_Inventory) Fulfill_Reservation(w http.ResponseWriter, r *http.R
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	// logging
-	log.Printf("Fulfilled reservation for inventory item: %+v", item)
+	log.Printf("Received return for inventory item: %+v", item)
 }
@@ -0,0 +1,80 @@
+package inventory_handler_http
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// locations
+// -------------------------------------------------------------------
+
+func toPBLocation(l *dmodel.Location) *pb.Location {
+	return &pb.Location{
+		Id:        int32(l.ID),
+		Code:      l.Code,
+		Name:      l.Name,
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+		Priority:  int32(l.Priority),
+	}
+}
+
+func (h *Handler_Inventory_GRPC) ListLocations(ctx context.Context, req *pb.ListLocationsRequest) (*pb.ListLocationsResponse, error) {
+	locations, err := h.controller.Get_Locations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbLocations := make([]*pb.Location, len(locations))
+	for i, l := range locations {
+		pbLocations[i] = toPBLocation(l)
+	}
+
+	return &pb.ListLocationsResponse{
+		Locations: pbLocations,
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetLocation(ctx context.Context, req *pb.GetLocationRequest) (*pb.GetLocationResponse, error) {
+	location, err := h.controller.Get_Location(ctx, int(req.Id))
+	if err != nil {
+		if err == internal.ErrLocationNotFound {
+			return nil, status.Errorf(codes.NotFound, "location not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.GetLocationResponse{
+		Location: toPBLocation(location),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CreateLocation(ctx context.Context, req *pb.CreateLocationRequest) (*pb.CreateLocationResponse, error) {
+	location, err := h.controller.Create_Location(ctx, &dmodel.Location{
+		Code:      req.Code,
+		Name:      req.Name,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Priority:  int(req.Priority),
+	})
+	if err != nil {
+		switch err {
+		case internal.ErrInvalidLocation:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case internal.ErrLocationExists:
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.CreateLocationResponse{
+		Location: toPBLocation(location),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// locations
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory) Get_Locations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	// getting the controller's response
+	locations, err := h.controller.Get_Locations(ctx)
+	if err != nil {
+		log.Printf("Error getting locations: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(locations)
+	if err != nil {
+		log.Printf("Error encoding locations to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Get_Location(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	locationID, err := strconv.Atoi(r_params["locationId"])
+	if err != nil {
+		log.Printf("Error getting location ID from URL: %v", err)
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	location, err := h.controller.Get_Location(ctx, locationID)
+	if err != nil {
+		if err == internal.ErrLocationNotFound {
+			http.Error(w, "Location not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting location: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(location)
+	if err != nil {
+		log.Printf("Error encoding location to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Create_Location(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var location dmodel.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	created, err := h.controller.Create_Location(ctx, &location)
+	if err != nil {
+		switch err {
+		case internal.ErrInvalidLocation:
+			http.Error(w, "Invalid location: code and name are required, coordinates must be valid", http.StatusBadRequest)
+			return
+		case internal.ErrLocationExists:
+			http.Error(w, "A location with this code already exists", http.StatusConflict)
+			return
+		}
+		log.Printf("Error creating location: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		log.Printf("Error encoding location to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Created location: %+v", created)
+}
@@ -0,0 +1,98 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// lots
+// -------------------------------------------------------------------
+
+func toPBLot(l *dmodel.Lot) *pb.Lot {
+	pbLot := &pb.Lot{
+		Id:         int32(l.ID),
+		LocationId: int32(l.LocationID),
+		ProductId:  int32(l.ProductID),
+		LotNumber:  l.LotNumber,
+		Stock:      int32(l.Stock),
+		Reserved:   int32(l.Reserved),
+		Expired:    l.Expired,
+		CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+	}
+	if l.ExpiresAt != nil {
+		pbLot.ExpiresAt = l.ExpiresAt.Format(time.RFC3339)
+	}
+	return pbLot
+}
+
+func toPBLotConsumptions(lots []dmodel.LotConsumption) []*pb.LotConsumption {
+	pbLots := make([]*pb.LotConsumption, len(lots))
+	for i, l := range lots {
+		pbLots[i] = &pb.LotConsumption{
+			ReservationId: int32(l.ReservationID),
+			LotNumber:     l.LotNumber,
+			Quantity:      int32(l.Quantity),
+		}
+		if l.ExpiresAt != nil {
+			pbLots[i].ExpiresAt = l.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+	return pbLots
+}
+
+func toPBLotQuantities(lots []dmodel.LotQuantity) []*pb.LotQuantity {
+	pbLots := make([]*pb.LotQuantity, len(lots))
+	for i, l := range lots {
+		pbLots[i] = &pb.LotQuantity{
+			LotNumber: l.LotNumber,
+			Quantity:  int32(l.Quantity),
+		}
+		if l.ExpiresAt != nil {
+			pbLots[i].ExpiresAt = l.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+	return pbLots
+}
+
+func fromPBLotQuantities(pbLots []*pb.LotQuantity) ([]dmodel.LotQuantity, error) {
+	lots := make([]dmodel.LotQuantity, len(pbLots))
+	for i, l := range pbLots {
+		expiresAt, err := parseTime(l.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		lots[i] = dmodel.LotQuantity{
+			LotNumber: l.LotNumber,
+			ExpiresAt: expiresAt,
+			Quantity:  int(l.Quantity),
+		}
+	}
+	return lots, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListLots(ctx context.Context, req *pb.ListLotsRequest) (*pb.ListLotsResponse, error) {
+	lots, err := h.controller.Get_Lots(ctx, int(req.ProductId), int(req.LocationId))
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbLots := make([]*pb.Lot, len(lots))
+	for i, l := range lots {
+		pbLots[i] = toPBLot(l)
+	}
+
+	return &pb.ListLotsResponse{
+		Lots: pbLots,
+	}, nil
+}
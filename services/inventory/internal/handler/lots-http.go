@@ -0,0 +1,57 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+)
+
+// -------------------------------------------------------------------
+// lots
+// -------------------------------------------------------------------
+
+// Get_Lots lists the lots of a product holding stock, at the location given by the
+// location_id query parameter or at every location
+func (h *Handler_Inventory) Get_Lots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	lots, err := h.controller.Get_Lots(ctx, productID, locationID)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error getting lots: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(lots)
+	if err != nil {
+		log.Printf("Error encoding lots to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,66 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// stock movements
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory_GRPC) ListMovements(ctx context.Context, req *pb.ListMovementsRequest) (*pb.ListMovementsResponse, error) {
+	filter, err := movementFilter(req.From, req.To, int(req.AfterId), int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	filter.LocationID = int(req.LocationId)
+
+	movements, err := h.controller.Get_Movements(ctx, int(req.ProductId), filter)
+	if err != nil {
+		switch err {
+		case internal.ErrItemNotFound:
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		case internal.ErrInvalidTimeRange, internal.ErrInvalidQuantity:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbMovements := make([]*pb.Movement, len(movements))
+	for i, m := range movements {
+		pbMovements[i] = &pb.Movement{
+			Id:              int64(m.ID),
+			LocationId:      int32(m.LocationID),
+			ProductId:       int32(m.ProductID),
+			Reason:          m.Reason,
+			StockDelta:      int32(m.StockDelta),
+			ReservedDelta:   int32(m.ReservedDelta),
+			DamagedDelta:    int32(m.DamagedDelta),
+			Stock:           int32(m.Stock),
+			Reserved:        int32(m.Reserved),
+			Damaged:         int32(m.Damaged),
+			ReservationId:   int32(m.ReservationID),
+			TransferId:      int32(m.TransferID),
+			PurchaseOrderId: int32(m.PurchaseOrderID),
+			StocktakeId:     int32(m.StocktakeID),
+			ReferenceId:     m.ReferenceID,
+			Actor:           m.Actor,
+			CreatedAt:       m.CreatedAt.Format(time.RFC3339Nano),
+			Value:           toPBMoney(m.Value),
+		}
+		if m.UnitCost != nil {
+			pbMovements[i].UnitCost = toPBMoney(*m.UnitCost)
+		}
+	}
+
+	return &pb.ListMovementsResponse{
+		Movements: pbMovements,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// stock movements
+// -------------------------------------------------------------------
+
+// Get_Movements lists the stock ledger of a product
+// query parameters: from and to (RFC 3339), after_id, limit and location_id
+func (h *Handler_Inventory) Get_Movements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	afterID, limit := 0, 0
+	if v := query.Get("after_id"); v != "" {
+		if afterID, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid after_id", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	filter, err := movementFilter(query.Get("from"), query.Get("to"), afterID, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.LocationID, err = locationParam(r); err != nil {
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	movements, err := h.controller.Get_Movements(ctx, productID, filter)
+	if err != nil {
+		switch err {
+		case internal.ErrItemNotFound:
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		case internal.ErrInvalidTimeRange, internal.ErrInvalidQuantity:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Error getting stock movements: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(movements)
+	if err != nil {
+		log.Printf("Error encoding stock movements to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// builds the movement filter of a request; from and to are RFC 3339 times (unbounded when empty)
+func movementFilter(from, to string, afterID, limit int) (dmodel.MovementFilter, error) {
+	filter := dmodel.MovementFilter{AfterID: afterID, Limit: limit}
+
+	var err error
+	if filter.From, err = parseTime(from); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTime(to); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
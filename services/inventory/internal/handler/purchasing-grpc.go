@@ -0,0 +1,276 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// purchasing
+// -------------------------------------------------------------------
+
+func toPBSupplier(s *dmodel.Supplier) *pb.Supplier {
+	return &pb.Supplier{
+		Id:        int32(s.ID),
+		Code:      s.Code,
+		Name:      s.Name,
+		Email:     s.Email,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toPBPurchaseOrder(po *dmodel.PurchaseOrder) *pb.PurchaseOrder {
+	lines := make([]*pb.PurchaseOrderLine, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = &pb.PurchaseOrderLine{
+			ProductId:        int32(line.ProductID),
+			Quantity:         int32(line.Quantity),
+			ReceivedQuantity: int32(line.ReceivedQuantity),
+			UnitCost:         toPBMoney(line.UnitCost),
+		}
+	}
+
+	pbOrder := &pb.PurchaseOrder{
+		Id:         int32(po.ID),
+		SupplierId: int32(po.SupplierID),
+		LocationId: int32(po.LocationID),
+		State:      po.State,
+		Reference:  po.Reference,
+		Lines:      lines,
+		CreatedAt:  po.CreatedAt.Format(time.RFC3339),
+	}
+	if po.ExpectedAt != nil {
+		pbOrder.ExpectedAt = po.ExpectedAt.Format(time.RFC3339)
+	}
+	if po.ClosedAt != nil {
+		pbOrder.ClosedAt = po.ClosedAt.Format(time.RFC3339)
+	}
+	return pbOrder
+}
+
+func toPBReceipts(receipts []*dmodel.Receipt) []*pb.Receipt {
+	pbReceipts := make([]*pb.Receipt, len(receipts))
+	for i, r := range receipts {
+		pbReceipts[i] = &pb.Receipt{
+			Id:              int32(r.ID),
+			PurchaseOrderId: int32(r.PurchaseOrderID),
+			LocationId:      int32(r.LocationID),
+			ProductId:       int32(r.ProductID),
+			Quantity:        int32(r.Quantity),
+			Reference:       r.Reference,
+			Actor:           r.Actor,
+			ReceivedAt:      r.ReceivedAt.Format(time.RFC3339),
+			LotNumber:       r.LotNumber,
+			UnitCost:        toPBMoney(r.UnitCost),
+		}
+		if r.ExpiresAt != nil {
+			pbReceipts[i].ExpiresAt = r.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+	return pbReceipts
+}
+
+// maps the errors of the supplier and purchase order operations to gRPC statuses
+func purchasingStatus(err error) error {
+	switch {
+	case errors.Is(err, internal.ErrSupplierNotFound):
+		return status.Errorf(codes.NotFound, "supplier not found")
+	case errors.Is(err, internal.ErrPurchaseOrderNotFound):
+		return status.Errorf(codes.NotFound, "purchase order not found")
+	case errors.Is(err, internal.ErrSupplierExists):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, internal.ErrPurchaseOrderState):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, internal.ErrInvalidSupplier), errors.Is(err, internal.ErrInvalidPurchaseOrder),
+		errors.Is(err, internal.ErrInvalidReceipt), errors.Is(err, internal.ErrInvalidQuantity),
+		errors.Is(err, internal.ErrInvalidLot), errors.Is(err, internal.ErrInvalidCost):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if st := serialStatus(err); st != nil {
+		return st
+	}
+	if st := idempotencyStatus(err); st != nil {
+		return st
+	}
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+func (h *Handler_Inventory_GRPC) ListSuppliers(ctx context.Context, req *pb.ListSuppliersRequest) (*pb.ListSuppliersResponse, error) {
+	suppliers, err := h.controller.Get_Suppliers(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbSuppliers := make([]*pb.Supplier, len(suppliers))
+	for i, s := range suppliers {
+		pbSuppliers[i] = toPBSupplier(s)
+	}
+
+	return &pb.ListSuppliersResponse{
+		Suppliers: pbSuppliers,
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetSupplier(ctx context.Context, req *pb.GetSupplierRequest) (*pb.GetSupplierResponse, error) {
+	supplier, err := h.controller.Get_Supplier(ctx, int(req.Id))
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	return &pb.GetSupplierResponse{
+		Supplier: toPBSupplier(supplier),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CreateSupplier(ctx context.Context, req *pb.CreateSupplierRequest) (*pb.CreateSupplierResponse, error) {
+	supplier, err := h.controller.Create_Supplier(ctx, &dmodel.Supplier{
+		Code:  req.Code,
+		Name:  req.Name,
+		Email: req.Email,
+	})
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	return &pb.CreateSupplierResponse{
+		Supplier: toPBSupplier(supplier),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListPurchaseOrders(ctx context.Context, req *pb.ListPurchaseOrdersRequest) (*pb.ListPurchaseOrdersResponse, error) {
+	orders, err := h.controller.Get_PurchaseOrders(ctx, req.State, int(req.SupplierId))
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	pbOrders := make([]*pb.PurchaseOrder, len(orders))
+	for i, po := range orders {
+		pbOrders[i] = toPBPurchaseOrder(po)
+	}
+
+	return &pb.ListPurchaseOrdersResponse{
+		PurchaseOrders: pbOrders,
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetPurchaseOrder(ctx context.Context, req *pb.GetPurchaseOrderRequest) (*pb.GetPurchaseOrderResponse, error) {
+	order, err := h.controller.Get_PurchaseOrder(ctx, int(req.Id))
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+	receipts, err := h.controller.Get_Receipts(ctx, order.ID)
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	return &pb.GetPurchaseOrderResponse{
+		PurchaseOrder: toPBPurchaseOrder(order),
+		Receipts:      toPBReceipts(receipts),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CreatePurchaseOrder(ctx context.Context, req *pb.CreatePurchaseOrderRequest) (*pb.CreatePurchaseOrderResponse, error) {
+	expectedAt, err := parseTime(req.ExpectedAt)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expected_at: %v", err)
+	}
+
+	lines := make([]dmodel.PurchaseOrderLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = dmodel.PurchaseOrderLine{ProductID: int(line.ProductId), Quantity: int(line.Quantity)}
+		if line.UnitCost != nil {
+			lines[i].UnitCost = *fromPBMoney(line.UnitCost)
+		}
+	}
+
+	order, err := h.controller.Create_PurchaseOrder(ctx, &dmodel.PurchaseOrder{
+		SupplierID: int(req.SupplierId),
+		LocationID: int(req.LocationId),
+		Reference:  req.Reference,
+		ExpectedAt: expectedAt,
+		Lines:      lines,
+	})
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	return &pb.CreatePurchaseOrderResponse{
+		PurchaseOrder: toPBPurchaseOrder(order),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReceivePurchaseOrder(ctx context.Context, req *pb.ReceivePurchaseOrderRequest) (*pb.ReceivePurchaseOrderResponse, error) {
+	lines := make([]dmodel.ReceiptLine, len(req.Lines))
+	for i, line := range req.Lines {
+		expiresAt, err := parseTime(line.ExpiresAt)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
+		}
+		lines[i] = dmodel.ReceiptLine{
+			ProductID:     int(line.ProductId),
+			Quantity:      int(line.Quantity),
+			LotNumber:     line.LotNumber,
+			ExpiresAt:     expiresAt,
+			SerialNumbers: line.SerialNumbers,
+			UnitCost:      fromPBMoney(line.UnitCost),
+		}
+	}
+
+	res, replayed, err := h.controller.Receive_PurchaseOrderIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.Id), lines, req.Reference)
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.ReceivePurchaseOrderResponse{
+		PurchaseOrder: toPBPurchaseOrder(res.PurchaseOrder),
+		Receipts:      toPBReceipts(res.Receipts),
+		Items:         toPBItems(res.Items),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CancelPurchaseOrder(ctx context.Context, req *pb.CancelPurchaseOrderRequest) (*pb.CancelPurchaseOrderResponse, error) {
+	order, err := h.controller.Cancel_PurchaseOrder(ctx, int(req.Id))
+	if err != nil {
+		return nil, purchasingStatus(err)
+	}
+
+	return &pb.CancelPurchaseOrderResponse{
+		PurchaseOrder: toPBPurchaseOrder(order),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListIncoming(ctx context.Context, req *pb.ListIncomingRequest) (*pb.ListIncomingResponse, error) {
+	incoming, err := h.controller.Get_Incoming(ctx, int(req.ProductId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbIncoming := make([]*pb.IncomingStock, len(incoming))
+	for i, in := range incoming {
+		pbIncoming[i] = &pb.IncomingStock{
+			ProductId:  int32(in.ProductID),
+			LocationId: int32(in.LocationID),
+			Quantity:   int32(in.Quantity),
+		}
+		if in.ExpectedAt != nil {
+			pbIncoming[i].ExpectedAt = in.ExpectedAt.Format(time.RFC3339)
+		}
+	}
+
+	return &pb.ListIncomingResponse{
+		Incoming: pbIncoming,
+	}, nil
+}
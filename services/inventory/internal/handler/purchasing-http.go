@@ -0,0 +1,402 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// purchasing
+// -------------------------------------------------------------------
+
+// writes the HTTP error for the errors of the supplier and purchase order operations,
+// returns false for any other error
+func writePurchasingError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, internal.ErrSupplierNotFound):
+		http.Error(w, "Supplier not found", http.StatusNotFound)
+	case errors.Is(err, internal.ErrPurchaseOrderNotFound):
+		http.Error(w, "Purchase order not found", http.StatusNotFound)
+	case errors.Is(err, internal.ErrSupplierExists):
+		http.Error(w, "A supplier with this code already exists", http.StatusConflict)
+	case errors.Is(err, internal.ErrPurchaseOrderState):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, internal.ErrInvalidSupplier):
+		http.Error(w, "Invalid supplier: code and name are required", http.StatusBadRequest)
+	case errors.Is(err, internal.ErrInvalidPurchaseOrder), errors.Is(err, internal.ErrInvalidReceipt), errors.Is(err, internal.ErrInvalidQuantity),
+		errors.Is(err, internal.ErrInvalidLot), errors.Is(err, internal.ErrInvalidCost):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *Handler_Inventory) Get_Suppliers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	// getting the controller's response
+	suppliers, err := h.controller.Get_Suppliers(ctx)
+	if err != nil {
+		log.Printf("Error getting suppliers: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(suppliers)
+	if err != nil {
+		log.Printf("Error encoding suppliers to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Get_Supplier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	supplierID, err := strconv.Atoi(r_params["supplierId"])
+	if err != nil {
+		log.Printf("Error getting supplier ID from URL: %v", err)
+		http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	supplier, err := h.controller.Get_Supplier(ctx, supplierID)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error getting supplier: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(supplier)
+	if err != nil {
+		log.Printf("Error encoding supplier to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Create_Supplier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var supplier dmodel.Supplier
+	if err := json.NewDecoder(r.Body).Decode(&supplier); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	created, err := h.controller.Create_Supplier(ctx, &supplier)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error creating supplier: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		log.Printf("Error encoding supplier to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Created supplier: %+v", created)
+}
+
+// Get_PurchaseOrders lists the purchase orders, filtered by the state and supplier_id query parameters
+func (h *Handler_Inventory) Get_PurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	supplierID := 0
+	if value := r.URL.Query().Get("supplier_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+			return
+		}
+		supplierID = id
+	}
+
+	// getting the controller's response
+	orders, err := h.controller.Get_PurchaseOrders(ctx, r.URL.Query().Get("state"), supplierID)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error getting purchase orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(orders)
+	if err != nil {
+		log.Printf("Error encoding purchase orders to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Get_PurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	purchaseOrderID, err := strconv.Atoi(r_params["purchaseOrderId"])
+	if err != nil {
+		log.Printf("Error getting purchase order ID from URL: %v", err)
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	order, err := h.controller.Get_PurchaseOrder(ctx, purchaseOrderID)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error getting purchase order: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(order)
+	if err != nil {
+		log.Printf("Error encoding purchase order to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Create_PurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var template_req struct {
+		SupplierID int                        `json:"supplier_id"`
+		LocationID int                        `json:"location_id"`
+		Reference  string                     `json:"reference"`
+		ExpectedAt *time.Time                 `json:"expected_at"`
+		Lines      []dmodel.PurchaseOrderLine `json:"lines"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	order, err := h.controller.Create_PurchaseOrder(ctx, &dmodel.PurchaseOrder{
+		SupplierID: template_req.SupplierID,
+		LocationID: template_req.LocationID,
+		Reference:  template_req.Reference,
+		ExpectedAt: template_req.ExpectedAt,
+		Lines:      template_req.Lines,
+	})
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error creating purchase order: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(order)
+	if err != nil {
+		log.Printf("Error encoding purchase order to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Created purchase order %d with supplier %d for location %d", order.ID, order.SupplierID, order.LocationID)
+}
+
+func (h *Handler_Inventory) Receive_PurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	purchaseOrderID, err := strconv.Atoi(r_params["purchaseOrderId"])
+	if err != nil {
+		log.Printf("Error getting purchase order ID from URL: %v", err)
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		Lines     []dmodel.ReceiptLine `json:"lines"`
+		Reference string               `json:"reference"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	res, replayed, err := h.controller.Receive_PurchaseOrderIdempotent(ctx, r.Header.Get(idempotencyKeyHeader), purchaseOrderID, template_req.Lines, template_req.Reference)
+	if err != nil {
+		if writePurchasingError(w, err) || writeSerialError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error receiving purchase order: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding receipt to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Received purchase order %d (%s): %d receipt(s)", res.PurchaseOrder.ID, res.PurchaseOrder.State, len(res.Receipts))
+}
+
+func (h *Handler_Inventory) Cancel_PurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	purchaseOrderID, err := strconv.Atoi(r_params["purchaseOrderId"])
+	if err != nil {
+		log.Printf("Error getting purchase order ID from URL: %v", err)
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	order, err := h.controller.Cancel_PurchaseOrder(ctx, purchaseOrderID)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error cancelling purchase order: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(order)
+	if err != nil {
+		log.Printf("Error encoding purchase order to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Cancelled purchase order %d", order.ID)
+}
+
+func (h *Handler_Inventory) Get_Receipts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	purchaseOrderID, err := strconv.Atoi(r_params["purchaseOrderId"])
+	if err != nil {
+		log.Printf("Error getting purchase order ID from URL: %v", err)
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	receipts, err := h.controller.Get_Receipts(ctx, purchaseOrderID)
+	if err != nil {
+		if writePurchasingError(w, err) {
+			return
+		}
+		log.Printf("Error getting receipts: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(receipts)
+	if err != nil {
+		log.Printf("Error encoding receipts to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Get_Incoming lists the units still expected from purchase orders, of the product given by
+// the product_id query parameter or of every product
+func (h *Handler_Inventory) Get_Incoming(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	productID := 0
+	if value := r.URL.Query().Get("product_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			http.Error(w, "Invalid product ID", http.StatusBadRequest)
+			return
+		}
+		productID = id
+	}
+
+	// getting the controller's response
+	incoming, err := h.controller.Get_Incoming(ctx, productID)
+	if err != nil {
+		log.Printf("Error getting incoming stock: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(incoming)
+	if err != nil {
+		log.Printf("Error encoding incoming stock to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,149 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// reservations
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory_GRPC) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	alloc := dmodel.Allocation{LocationID: int(req.LocationId), Policy: req.Policy, Destination: fromPBCoordinates(req.Destination)}
+	res, replayed, err := h.controller.Reserve_StockIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.ProductId), int(req.Stock), req.Owner, ttl, alloc)
+	if err != nil {
+		return nil, reservationStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.ReserveStockResponse{
+		Item:        toPBItem(res.Item),
+		Reservation: toPBReservation(res.Reservation),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) FulfillReservation(ctx context.Context, req *pb.FulfillReservationRequest) (*pb.FulfillReservationResponse, error) {
+	res, replayed, err := h.controller.Fulfill_ReservationIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.ReservationId), int(req.Stock), req.SerialNumbers)
+	if err != nil {
+		return nil, reservationStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.FulfillReservationResponse{
+		Item:            toPBItem(res.Item),
+		Reservation:     toPBReservation(res.Reservation),
+		Lots:            toPBLotConsumptions(res.Lots),
+		Serials:         toPBSerialConsumptions(res.Serials),
+		CostOfGoodsSold: toPBMoney(res.Reservation.CostOfGoodsSold),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	res, replayed, err := h.controller.Release_ReservationIdempotent(ctx, idempotencyKeyFromContext(ctx), int(req.ReservationId), int(req.Stock))
+	if err != nil {
+		return nil, reservationStatus(err)
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+
+	return &pb.ReleaseReservationResponse{
+		Item:        toPBItem(res.Item),
+		Reservation: toPBReservation(res.Reservation),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetReservation(ctx context.Context, req *pb.GetReservationRequest) (*pb.GetReservationResponse, error) {
+	reservation, err := h.controller.Get_Reservation(ctx, int(req.Id))
+	if err != nil {
+		return nil, reservationStatus(err)
+	}
+
+	return &pb.GetReservationResponse{
+		Reservation: toPBReservation(reservation),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListReservations(ctx context.Context, req *pb.ListReservationsRequest) (*pb.ListReservationsResponse, error) {
+	reservations, err := h.controller.Get_ActiveReservations(ctx, int(req.ProductId), req.Owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.ListReservationsResponse{
+		Reservations: toPBReservations(reservations),
+	}, nil
+}
+
+// maps the errors of the reservation operations to gRPC statuses
+func reservationStatus(err error) error {
+	switch err {
+	case internal.ErrItemNotFound:
+		return status.Errorf(codes.NotFound, "inventory not found")
+	case internal.ErrReservationNotFound:
+		return status.Errorf(codes.NotFound, "reservation not found")
+	case internal.ErrLocationNotFound:
+		return status.Errorf(codes.NotFound, "location not found")
+	case internal.ErrInsufficientStock, internal.ErrInsufficientReserved, internal.ErrReservationNotActive:
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case internal.ErrInvalidQuantity, internal.ErrInvalidOwner, internal.ErrInvalidPolicy:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := serialStatus(err); err != nil {
+		return err
+	}
+	if err := idempotencyStatus(err); err != nil {
+		return err
+	}
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+// converts a domain reservation into its protobuf representation
+func toPBReservation(r *dmodel.Reservation) *pb.Reservation {
+	pbReservation := &pb.Reservation{
+		Id:                int32(r.ID),
+		LocationId:        int32(r.LocationID),
+		ProductId:         int32(r.ProductID),
+		Quantity:          int32(r.Quantity),
+		FulfilledQuantity: int32(r.FulfilledQuantity),
+		ReleasedQuantity:  int32(r.ReleasedQuantity),
+		Owner:             r.Owner,
+		State:             r.State,
+		CreatedAt:         r.CreatedAt.Format(time.RFC3339),
+		CostOfGoodsSold:   toPBMoney(r.CostOfGoodsSold),
+	}
+	if r.ExpiresAt != nil {
+		pbReservation.ExpiresAt = r.ExpiresAt.Format(time.RFC3339)
+	}
+	return pbReservation
+}
+
+func toPBReservations(reservations []*dmodel.Reservation) []*pb.Reservation {
+	pbReservations := make([]*pb.Reservation, len(reservations))
+	for i, r := range reservations {
+		pbReservations[i] = toPBReservation(r)
+	}
+	return pbReservations
+}
+
+func fromPBCoordinates(c *pb.Coordinates) *dmodel.Coordinates {
+	if c == nil {
+		return nil
+	}
+	return &dmodel.Coordinates{Latitude: c.Latitude, Longitude: c.Longitude}
+}
@@ -0,0 +1,236 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// reservations
+// -------------------------------------------------------------------
+
+func (h *Handler_Inventory) Reserve_Stock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	// the location is picked by the allocation policy when location_id is missing
+	var template_req struct {
+		Stock       int                 `json:"stock"`
+		Owner       string              `json:"owner"`
+		TTLSeconds  int                 `json:"ttl_seconds"`
+		LocationID  int                 `json:"location_id"`
+		Policy      string              `json:"policy"`
+		Destination *dmodel.Coordinates `json:"destination"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	ttl := time.Duration(template_req.TTLSeconds) * time.Second
+	alloc := dmodel.Allocation{LocationID: template_req.LocationID, Policy: template_req.Policy, Destination: template_req.Destination}
+	res, replayed, err := h.controller.Reserve_StockIdempotent(ctx, r.Header.Get(idempotencyKeyHeader), productID, template_req.Stock, template_req.Owner, ttl, alloc)
+	if err != nil {
+		if writeReservationError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error reserving inventory stock: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding reservation to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Reserved inventory item: %+v (reservation %d)", res.Item, res.Reservation.ID)
+}
+
+func (h *Handler_Inventory) Release_Reservation(w http.ResponseWriter, r *http.Request) {
+	h.settleReservation(w, r, "Released", func(ctx context.Context, key string, reservationID, quantity int, serials []string) (*dmodel.ReservationUpdate, bool, error) {
+		if len(serials) > 0 {
+			return nil, false, fmt.Errorf("%w: released units are not named", internal.ErrInvalidSerial)
+		}
+		return h.controller.Release_ReservationIdempotent(ctx, key, reservationID, quantity)
+	})
+}
+
+func (h *Handler_Inventory) Fulfill_Reservation(w http.ResponseWriter, r *http.Request) {
+	h.settleReservation(w, r, "Fulfilled", h.controller.Fulfill_ReservationIdempotent)
+}
+
+// signature of the controller's idempotent fulfill and release operations
+type settleOperation func(ctx context.Context, key string, reservationID, quantity int, serials []string) (*dmodel.ReservationUpdate, bool, error)
+
+// decodes the quantity to fulfill or release (every remaining unit when missing or 0),
+// and the serial numbers of the units fulfilled, and applies it to the reservation in the URL
+func (h *Handler_Inventory) settleReservation(w http.ResponseWriter, r *http.Request, done string, operation settleOperation) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	reservationID, err := strconv.Atoi(r_params["reservationId"])
+	if err != nil {
+		log.Printf("Error getting reservation ID from URL: %v", err)
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+
+	// the body is optional
+	var template_req struct {
+		Quantity      int      `json:"quantity"`
+		SerialNumbers []string `json:"serial_numbers"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil && err != io.EOF {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	res, replayed, err := operation(ctx, r.Header.Get(idempotencyKeyHeader), reservationID, template_req.Quantity, template_req.SerialNumbers)
+	if err != nil {
+		if writeReservationError(w, err) || writeSerialError(w, err) || writeIdempotencyError(w, err) {
+			return
+		}
+		log.Printf("Error settling inventory reservation: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding reservation to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("%s reservation %d of inventory item: %+v", done, res.Reservation.ID, res.Item)
+}
+
+func (h *Handler_Inventory) Get_Reservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	reservationID, err := strconv.Atoi(r_params["reservationId"])
+	if err != nil {
+		log.Printf("Error getting reservation ID from URL: %v", err)
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	reservation, err := h.controller.Get_Reservation(ctx, reservationID)
+	if err != nil {
+		if writeReservationError(w, err) {
+			return
+		}
+		log.Printf("Error getting reservation: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(reservation)
+	if err != nil {
+		log.Printf("Error encoding reservation to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Get_ProductReservations lists the active reservations of a product
+func (h *Handler_Inventory) Get_ProductReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	reservations, err := h.controller.Get_ActiveReservations(ctx, productID, r.URL.Query().Get("owner"))
+	if err != nil {
+		log.Printf("Error getting reservations: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(reservations)
+	if err != nil {
+		log.Printf("Error encoding reservations to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// writes the HTTP error for the errors of the reservation operations, returns false for any other error
+func writeReservationError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case internal.ErrItemNotFound:
+		http.Error(w, "Inventory not found", http.StatusNotFound)
+	case internal.ErrReservationNotFound:
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+	case internal.ErrInsufficientStock:
+		http.Error(w, "Insufficient stock", http.StatusConflict)
+	case internal.ErrInsufficientReserved:
+		http.Error(w, "Insufficient reserved stock", http.StatusConflict)
+	case internal.ErrReservationNotActive:
+		http.Error(w, "Reservation is no longer active", http.StatusConflict)
+	case internal.ErrInvalidQuantity:
+		http.Error(w, "Invalid quantity", http.StatusBadRequest)
+	case internal.ErrInvalidOwner:
+		http.Error(w, "Reservation owner is required", http.StatusBadRequest)
+	case internal.ErrLocationNotFound:
+		http.Error(w, "Location not found", http.StatusNotFound)
+	case internal.ErrInvalidPolicy:
+		http.Error(w, "Unknown allocation policy", http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
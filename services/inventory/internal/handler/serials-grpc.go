@@ -0,0 +1,115 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// serial numbers
+// -------------------------------------------------------------------
+
+// maps the errors of serialized units to gRPC statuses, returns nil for any other error
+func serialStatus(err error) error {
+	switch {
+	case errors.Is(err, internal.ErrInvalidSerial):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, internal.ErrSerialNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, internal.ErrSerialExists):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, internal.ErrSerialUnavailable), errors.Is(err, internal.ErrSerialTrackingUsed), errors.Is(err, internal.ErrSerialUnnamed):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return nil
+}
+
+func toPBSerial(u *dmodel.SerialUnit) *pb.SerialUnit {
+	return &pb.SerialUnit{
+		Id:              int32(u.ID),
+		ProductId:       int32(u.ProductID),
+		SerialNumber:    u.SerialNumber,
+		LocationId:      int32(u.LocationID),
+		State:           u.State,
+		ReservationId:   int32(u.ReservationID),
+		PurchaseOrderId: int32(u.PurchaseOrderID),
+		CreatedAt:       u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toPBSerials(units []*dmodel.SerialUnit) []*pb.SerialUnit {
+	pbSerials := make([]*pb.SerialUnit, len(units))
+	for i, u := range units {
+		pbSerials[i] = toPBSerial(u)
+	}
+	return pbSerials
+}
+
+func toPBSerialConsumptions(serials []dmodel.SerialConsumption) []*pb.SerialConsumption {
+	pbSerials := make([]*pb.SerialConsumption, len(serials))
+	for i, s := range serials {
+		pbSerials[i] = &pb.SerialConsumption{
+			ReservationId: int32(s.ReservationID),
+			SerialNumber:  s.SerialNumber,
+		}
+	}
+	return pbSerials
+}
+
+func (h *Handler_Inventory_GRPC) SetSerialTracking(ctx context.Context, req *pb.SetSerialTrackingRequest) (*pb.SetSerialTrackingResponse, error) {
+	item, err := h.controller.Set_SerialTracking(ctx, int(req.ProductId), req.Enabled)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "inventory not found")
+		}
+		if st := serialStatus(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.SetSerialTrackingResponse{
+		Item: toPBItem(item),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ListSerials(ctx context.Context, req *pb.ListSerialsRequest) (*pb.ListSerialsResponse, error) {
+	filter := dmodel.SerialFilter{
+		ProductID:     int(req.ProductId),
+		SerialNumber:  req.SerialNumber,
+		State:         req.State,
+		LocationID:    int(req.LocationId),
+		ReservationID: int(req.ReservationId),
+	}
+	units, err := h.controller.Get_Serials(ctx, filter)
+	if err != nil {
+		if st := serialStatus(err); st != nil {
+			return nil, st
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.ListSerialsResponse{
+		Serials: toPBSerials(units),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReserveSerials(ctx context.Context, req *pb.ReserveSerialsRequest) (*pb.ReserveSerialsResponse, error) {
+	units, err := h.controller.Reserve_Serials(ctx, int(req.ReservationId), req.SerialNumbers)
+	if err != nil {
+		return nil, reservationStatus(err)
+	}
+
+	return &pb.ReserveSerialsResponse{
+		Serials: toPBSerials(units),
+	}, nil
+}
@@ -0,0 +1,186 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// serial numbers
+// -------------------------------------------------------------------
+
+// writes the HTTP error for the errors of serialized units, returns false for any other error
+func writeSerialError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, internal.ErrInvalidSerial):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, internal.ErrSerialNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, internal.ErrSerialExists), errors.Is(err, internal.ErrSerialUnavailable), errors.Is(err, internal.ErrSerialTrackingUsed), errors.Is(err, internal.ErrSerialUnnamed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
+
+// Set_SerialTracking turns serial tracking of a product on or off
+// body: {"enabled": true}
+func (h *Handler_Inventory) Set_SerialTracking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.controller.Set_SerialTracking(ctx, productID, template_req.Enabled)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Inventory not found", http.StatusNotFound)
+			return
+		}
+		if writeSerialError(w, err) {
+			return
+		}
+		log.Printf("Error setting serial tracking: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(item)
+	if err != nil {
+		log.Printf("Error encoding inventory item to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Set serial tracking of inventory item: %+v", item)
+}
+
+// Get_Serials lists the serialized units of a product
+// query parameters: state and location_id
+func (h *Handler_Inventory) Get_Serials(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID, err := strconv.Atoi(r_params["productId"])
+	if err != nil {
+		log.Printf("Error getting product ID from URL: %v", err)
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, "Invalid location ID", http.StatusBadRequest)
+		return
+	}
+
+	filter := dmodel.SerialFilter{ProductID: productID, State: r.URL.Query().Get("state"), LocationID: locationID}
+	h.writeSerials(w, r, filter)
+}
+
+// Get_Serial looks a serial number up across products
+func (h *Handler_Inventory) Get_Serial(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	h.writeSerials(w, r, dmodel.SerialFilter{SerialNumber: r_params["serialNumber"]})
+}
+
+// writes the serialized units matching filter; a lookup by serial number that finds
+// nothing gets 404
+func (h *Handler_Inventory) writeSerials(w http.ResponseWriter, r *http.Request, filter dmodel.SerialFilter) {
+	units, err := h.controller.Get_Serials(r.Context(), filter)
+	if err != nil {
+		if writeSerialError(w, err) {
+			return
+		}
+		log.Printf("Error getting serialized units: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if filter.SerialNumber != "" && len(units) == 0 {
+		http.Error(w, "Serial number not found", http.StatusNotFound)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(units)
+	if err != nil {
+		log.Printf("Error encoding serialized units to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Reserve_Serials picks serialized units for a reservation
+// body: {"serial_numbers": ["SN-1", "SN-2"]}
+func (h *Handler_Inventory) Reserve_Serials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	reservationID, err := strconv.Atoi(r_params["reservationId"])
+	if err != nil {
+		log.Printf("Error getting reservation ID from URL: %v", err)
+		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		SerialNumbers []string `json:"serial_numbers"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.controller.Reserve_Serials(ctx, reservationID, template_req.SerialNumbers)
+	if err != nil {
+		if writeReservationError(w, err) || writeSerialError(w, err) {
+			return
+		}
+		log.Printf("Error picking serialized units: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(units)
+	if err != nil {
+		log.Printf("Error encoding serialized units to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Picked %d serialized units for reservation %d", len(units), reservationID)
+}
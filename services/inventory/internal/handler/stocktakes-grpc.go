@@ -0,0 +1,210 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// stocktakes
+// -------------------------------------------------------------------
+
+// converts a domain stocktake into its protobuf representation
+func toPBStocktake(s *dmodel.Stocktake) *pb.Stocktake {
+	lines := make([]*pb.StocktakeLine, len(s.Lines))
+	for i, line := range s.Lines {
+		counts := make([]*pb.StocktakeCount, len(line.Counts))
+		for j, count := range line.Counts {
+			counts[j] = &pb.StocktakeCount{
+				Counter:   count.Counter,
+				Quantity:  int32(count.Quantity),
+				CountedAt: count.CountedAt.Format(time.RFC3339),
+			}
+		}
+		lines[i] = &pb.StocktakeLine{
+			ProductId: int32(line.ProductID),
+			Expected:  int32(line.Expected),
+			Counted:   int32(line.Counted),
+			Variance:  int32(line.Variance()),
+			State:     line.State,
+			Counts:    counts,
+		}
+	}
+
+	pbStocktake := &pb.Stocktake{
+		Id:         int32(s.ID),
+		LocationId: int32(s.LocationID),
+		State:      s.State,
+		Reference:  s.Reference,
+		CreatedBy:  s.CreatedBy,
+		Lines:      lines,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.ClosedAt != nil {
+		pbStocktake.ClosedAt = s.ClosedAt.Format(time.RFC3339)
+	}
+	return pbStocktake
+}
+
+// maps the errors of the stocktake operations to gRPC statuses
+// a stocktake that cannot be posted carries every failed line in a BatchFailure detail
+func stocktakeStatus(err error) error {
+	var batchErr *internal.BatchError
+	if errors.As(err, &batchErr) {
+		return batchStatus(err)
+	}
+	switch {
+	case errors.Is(err, internal.ErrStocktakeNotFound):
+		return status.Errorf(codes.NotFound, "stocktake not found")
+	case errors.Is(err, internal.ErrLocationNotFound), errors.Is(err, internal.ErrItemNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, internal.ErrStocktakeState), errors.Is(err, internal.ErrStocktakeOverlap):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, internal.ErrInvalidStocktake), errors.Is(err, internal.ErrInvalidQuantity), errors.Is(err, internal.ErrInvalidTimeRange):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+func (h *Handler_Inventory_GRPC) ListStocktakes(ctx context.Context, req *pb.ListStocktakesRequest) (*pb.ListStocktakesResponse, error) {
+	stocktakes, err := h.controller.Get_Stocktakes(ctx, req.State, int(req.LocationId))
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	pbStocktakes := make([]*pb.Stocktake, len(stocktakes))
+	for i, s := range stocktakes {
+		pbStocktakes[i] = toPBStocktake(s)
+	}
+
+	return &pb.ListStocktakesResponse{
+		Stocktakes: pbStocktakes,
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetStocktake(ctx context.Context, req *pb.GetStocktakeRequest) (*pb.GetStocktakeResponse, error) {
+	stocktake, err := h.controller.Get_Stocktake(ctx, int(req.Id))
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.GetStocktakeResponse{
+		Stocktake: toPBStocktake(stocktake),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CreateStocktake(ctx context.Context, req *pb.CreateStocktakeRequest) (*pb.CreateStocktakeResponse, error) {
+	productIDs := make([]int, len(req.ProductIds))
+	for i, id := range req.ProductIds {
+		productIDs[i] = int(id)
+	}
+
+	stocktake, err := h.controller.Create_Stocktake(ctx, int(req.LocationId), productIDs, req.Reference)
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.CreateStocktakeResponse{
+		Stocktake: toPBStocktake(stocktake),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) SubmitStocktakeCounts(ctx context.Context, req *pb.SubmitStocktakeCountsRequest) (*pb.SubmitStocktakeCountsResponse, error) {
+	lines := make([]dmodel.StocktakeCountLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = dmodel.StocktakeCountLine{ProductID: int(line.ProductId), Quantity: int(line.Quantity)}
+	}
+
+	stocktake, err := h.controller.Count_Stocktake(ctx, int(req.Id), req.Counter, lines)
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.SubmitStocktakeCountsResponse{
+		Stocktake: toPBStocktake(stocktake),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReviewStocktake(ctx context.Context, req *pb.ReviewStocktakeRequest) (*pb.ReviewStocktakeResponse, error) {
+	approve := make([]int, len(req.Approve))
+	for i, id := range req.Approve {
+		approve[i] = int(id)
+	}
+	reject := make([]int, len(req.Reject))
+	for i, id := range req.Reject {
+		reject[i] = int(id)
+	}
+
+	stocktake, err := h.controller.Review_Stocktake(ctx, int(req.Id), approve, reject)
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.ReviewStocktakeResponse{
+		Stocktake: toPBStocktake(stocktake),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) PostStocktake(ctx context.Context, req *pb.PostStocktakeRequest) (*pb.PostStocktakeResponse, error) {
+	res, err := h.controller.Post_Stocktake(ctx, int(req.Id))
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.PostStocktakeResponse{
+		Stocktake: toPBStocktake(res.Stocktake),
+		Items:     toPBItems(res.Items),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CancelStocktake(ctx context.Context, req *pb.CancelStocktakeRequest) (*pb.CancelStocktakeResponse, error) {
+	stocktake, err := h.controller.Cancel_Stocktake(ctx, int(req.Id))
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	return &pb.CancelStocktakeResponse{
+		Stocktake: toPBStocktake(stocktake),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetShrinkageReport(ctx context.Context, req *pb.GetShrinkageReportRequest) (*pb.GetShrinkageReportResponse, error) {
+	filter := dmodel.ShrinkageFilter{LocationID: int(req.LocationId), StocktakeID: int(req.StocktakeId)}
+	var err error
+	if filter.From, err = parseTime(req.From); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if filter.To, err = parseTime(req.To); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	report, err := h.controller.Get_Shrinkage(ctx, filter)
+	if err != nil {
+		return nil, stocktakeStatus(err)
+	}
+
+	lines := make([]*pb.ShrinkageLine, len(report))
+	for i, line := range report {
+		lines[i] = &pb.ShrinkageLine{
+			ProductId:  int32(line.ProductID),
+			Stocktakes: int32(line.Stocktakes),
+			Expected:   int32(line.Expected),
+			Counted:    int32(line.Counted),
+			Shrinkage:  int32(line.Shrinkage),
+			Found:      int32(line.Found),
+			Variance:   int32(line.Variance),
+		}
+	}
+
+	return &pb.GetShrinkageReportResponse{
+		Lines: lines,
+	}, nil
+}
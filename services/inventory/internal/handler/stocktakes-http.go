@@ -0,0 +1,369 @@
+package inventory_handler_http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// stocktakes
+// -------------------------------------------------------------------
+
+// writes the HTTP error for the errors of the stocktake operations, returns false for any other error
+// a stocktake that cannot be posted gets 409 with every failed line
+func writeStocktakeError(w http.ResponseWriter, err error) bool {
+	var batchErr *internal.BatchError
+	switch {
+	case errors.As(err, &batchErr):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error":    batchErr.Error(),
+			"failures": batchErr.Failures,
+		})
+	case errors.Is(err, internal.ErrStocktakeNotFound):
+		http.Error(w, "Stocktake not found", http.StatusNotFound)
+	case errors.Is(err, internal.ErrLocationNotFound), errors.Is(err, internal.ErrItemNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, internal.ErrStocktakeState), errors.Is(err, internal.ErrStocktakeOverlap):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, internal.ErrInvalidStocktake), errors.Is(err, internal.ErrInvalidQuantity), errors.Is(err, internal.ErrInvalidTimeRange):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// Get_Stocktakes lists the stocktakes, filtered by the state and location_id query parameters
+func (h *Handler_Inventory) Get_Stocktakes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktakes, err := h.controller.Get_Stocktakes(ctx, r.URL.Query().Get("state"), locationID)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error getting stocktakes: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktakes)
+	if err != nil {
+		log.Printf("Error encoding stocktakes to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Get_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	stocktakeID, err := strconv.Atoi(r_params["stocktakeId"])
+	if err != nil {
+		log.Printf("Error getting stocktake ID from URL: %v", err)
+		http.Error(w, "Invalid stocktake ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktake, err := h.controller.Get_Stocktake(ctx, stocktakeID)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error getting stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktake)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// body: {"location_id": 1, "product_ids": [1, 2], "reference": "Q3 count"}; every product
+// held at the location when product_ids is empty
+func (h *Handler_Inventory) Create_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var template_req struct {
+		LocationID int    `json:"location_id"`
+		ProductIDs []int  `json:"product_ids"`
+		Reference  string `json:"reference"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktake, err := h.controller.Create_Stocktake(ctx, template_req.LocationID, template_req.ProductIDs, template_req.Reference)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error creating stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktake)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Opened stocktake %d of %d product(s) at location %d", stocktake.ID, len(stocktake.Lines), stocktake.LocationID)
+}
+
+// body: {"counter": "alice", "lines": [{"product_id": 1, "quantity": 40}]}; the counter
+// defaults to the request's actor
+func (h *Handler_Inventory) Count_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	stocktakeID, err := strconv.Atoi(r_params["stocktakeId"])
+	if err != nil {
+		log.Printf("Error getting stocktake ID from URL: %v", err)
+		http.Error(w, "Invalid stocktake ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		Counter string                      `json:"counter"`
+		Lines   []dmodel.StocktakeCountLine `json:"lines"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktake, err := h.controller.Count_Stocktake(ctx, stocktakeID, template_req.Counter, template_req.Lines)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error counting stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktake)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Counted %d product(s) of stocktake %d", len(template_req.Lines), stocktake.ID)
+}
+
+// body: {"approve": [1], "reject": [2]}, the product IDs of counted lines
+func (h *Handler_Inventory) Review_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	stocktakeID, err := strconv.Atoi(r_params["stocktakeId"])
+	if err != nil {
+		log.Printf("Error getting stocktake ID from URL: %v", err)
+		http.Error(w, "Invalid stocktake ID", http.StatusBadRequest)
+		return
+	}
+
+	var template_req struct {
+		Approve []int `json:"approve"`
+		Reject  []int `json:"reject"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktake, err := h.controller.Review_Stocktake(ctx, stocktakeID, template_req.Approve, template_req.Reject)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error reviewing stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktake)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Reviewed stocktake %d: %d approved, %d rejected", stocktake.ID, len(template_req.Approve), len(template_req.Reject))
+}
+
+// writes the posted stocktake and the items its variances changed
+func (h *Handler_Inventory) Post_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	stocktakeID, err := strconv.Atoi(r_params["stocktakeId"])
+	if err != nil {
+		log.Printf("Error getting stocktake ID from URL: %v", err)
+		http.Error(w, "Invalid stocktake ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	res, err := h.controller.Post_Stocktake(ctx, stocktakeID)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error posting stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Posted stocktake %d: %d item(s) changed", res.Stocktake.ID, len(res.Items))
+}
+
+func (h *Handler_Inventory) Cancel_Stocktake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	stocktakeID, err := strconv.Atoi(r_params["stocktakeId"])
+	if err != nil {
+		log.Printf("Error getting stocktake ID from URL: %v", err)
+		http.Error(w, "Invalid stocktake ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	stocktake, err := h.controller.Cancel_Stocktake(ctx, stocktakeID)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error cancelling stocktake: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(stocktake)
+	if err != nil {
+		log.Printf("Error encoding stocktake to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Cancelled stocktake %d", stocktake.ID)
+}
+
+// Get_Shrinkage totals the variances posted by stocktakes per product
+// query parameters: location_id, stocktake_id, and from and to (RFC 3339) bounding when
+// the stocktakes were posted
+func (h *Handler_Inventory) Get_Shrinkage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := dmodel.ShrinkageFilter{LocationID: locationID}
+	if value := query.Get("stocktake_id"); value != "" {
+		filter.StocktakeID, err = strconv.Atoi(value)
+		if err != nil || filter.StocktakeID <= 0 {
+			http.Error(w, fmt.Sprintf("invalid stocktake_id %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+	if filter.From, err = parseTime(query.Get("from")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTime(query.Get("to")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	report, err := h.controller.Get_Shrinkage(ctx, filter)
+	if err != nil {
+		if writeStocktakeError(w, err) {
+			return
+		}
+		log.Printf("Error getting shrinkage report: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(report)
+	if err != nil {
+		log.Printf("Error encoding shrinkage report to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,140 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	pb "inventory-service/proto/inventory"
+)
+
+// -------------------------------------------------------------------
+// transfers
+// -------------------------------------------------------------------
+
+// converts a domain transfer into its protobuf representation
+func toPBTransfer(t *dmodel.Transfer) *pb.Transfer {
+	lines := make([]*pb.TransferLine, len(t.Lines))
+	for i, line := range t.Lines {
+		lines[i] = &pb.TransferLine{ProductId: int32(line.ProductID), Quantity: int32(line.Quantity), SerialNumbers: line.SerialNumbers, Lots: toPBLotQuantities(line.Lots)}
+	}
+
+	pbTransfer := &pb.Transfer{
+		Id:                    int32(t.ID),
+		SourceLocationId:      int32(t.SourceLocationID),
+		DestinationLocationId: int32(t.DestinationLocationID),
+		State:                 t.State,
+		Lines:                 lines,
+		CreatedAt:             t.CreatedAt.Format(time.RFC3339),
+	}
+	if t.ShippedAt != nil {
+		pbTransfer.ShippedAt = t.ShippedAt.Format(time.RFC3339)
+	}
+	if t.ReceivedAt != nil {
+		pbTransfer.ReceivedAt = t.ReceivedAt.Format(time.RFC3339)
+	}
+	return pbTransfer
+}
+
+// maps the errors of the transfer operations to gRPC statuses
+// a transfer that cannot be shipped carries every failed line in a BatchFailure detail
+func transferStatus(err error) error {
+	var batchErr *internal.BatchError
+	if errors.As(err, &batchErr) {
+		return batchStatus(err)
+	}
+	switch {
+	case errors.Is(err, internal.ErrTransferNotFound):
+		return status.Errorf(codes.NotFound, "transfer not found")
+	case errors.Is(err, internal.ErrLocationNotFound), errors.Is(err, internal.ErrItemNotFound):
+		return status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, internal.ErrTransferState):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, internal.ErrInvalidTransfer), errors.Is(err, internal.ErrInvalidQuantity), errors.Is(err, internal.ErrInvalidLot):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+func (h *Handler_Inventory_GRPC) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	transfers, err := h.controller.Get_Transfers(ctx, req.State, int(req.LocationId))
+	if err != nil {
+		return nil, transferStatus(err)
+	}
+
+	pbTransfers := make([]*pb.Transfer, len(transfers))
+	for i, t := range transfers {
+		pbTransfers[i] = toPBTransfer(t)
+	}
+
+	return &pb.ListTransfersResponse{
+		Transfers: pbTransfers,
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	transfer, err := h.controller.Get_Transfer(ctx, int(req.Id))
+	if err != nil {
+		return nil, transferStatus(err)
+	}
+
+	return &pb.GetTransferResponse{
+		Transfer: toPBTransfer(transfer),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	lines := make([]dmodel.TransferLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lots, err := fromPBLotQuantities(line.Lots)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
+		}
+		lines[i] = dmodel.TransferLine{ProductID: int(line.ProductId), Quantity: int(line.Quantity), SerialNumbers: line.SerialNumbers, Lots: lots}
+	}
+
+	transfer, err := h.controller.Create_Transfer(ctx, &dmodel.Transfer{
+		SourceLocationID:      int(req.SourceLocationId),
+		DestinationLocationID: int(req.DestinationLocationId),
+		Lines:                 lines,
+	})
+	if err != nil {
+		if st := serialStatus(err); st != nil {
+			return nil, st
+		}
+		return nil, transferStatus(err)
+	}
+
+	return &pb.CreateTransferResponse{
+		Transfer: toPBTransfer(transfer),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ShipTransfer(ctx context.Context, req *pb.ShipTransferRequest) (*pb.ShipTransferResponse, error) {
+	res, err := h.controller.Ship_Transfer(ctx, int(req.Id))
+	if err != nil {
+		return nil, transferStatus(err)
+	}
+
+	return &pb.ShipTransferResponse{
+		Transfer: toPBTransfer(res.Transfer),
+		Items:    toPBItems(res.Items),
+	}, nil
+}
+
+func (h *Handler_Inventory_GRPC) ReceiveTransfer(ctx context.Context, req *pb.ReceiveTransferRequest) (*pb.ReceiveTransferResponse, error) {
+	res, err := h.controller.Receive_Transfer(ctx, int(req.Id))
+	if err != nil {
+		return nil, transferStatus(err)
+	}
+
+	return &pb.ReceiveTransferResponse{
+		Transfer: toPBTransfer(res.Transfer),
+		Items:    toPBItems(res.Items),
+	}, nil
+}
@@ -0,0 +1,198 @@
+package inventory_handler_http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// transfers
+// -------------------------------------------------------------------
+
+// writes the HTTP error for the errors of the transfer operations, returns false for any other error
+// a transfer that cannot be shipped gets 409 with every failed line
+func writeTransferError(w http.ResponseWriter, err error) bool {
+	var batchErr *internal.BatchError
+	switch {
+	case errors.As(err, &batchErr):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error":    batchErr.Error(),
+			"failures": batchErr.Failures,
+		})
+	case errors.Is(err, internal.ErrTransferNotFound):
+		http.Error(w, "Transfer not found", http.StatusNotFound)
+	case errors.Is(err, internal.ErrLocationNotFound), errors.Is(err, internal.ErrItemNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, internal.ErrTransferState):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, internal.ErrInvalidTransfer), errors.Is(err, internal.ErrInvalidQuantity), errors.Is(err, internal.ErrInvalidLot):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// Get_Transfers lists the transfers, filtered by the state and location_id query parameters
+func (h *Handler_Inventory) Get_Transfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	locationID, err := locationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	transfers, err := h.controller.Get_Transfers(ctx, r.URL.Query().Get("state"), locationID)
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.Printf("Error getting transfers: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(transfers)
+	if err != nil {
+		log.Printf("Error encoding transfers to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Get_Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	transferID, err := strconv.Atoi(r_params["transferId"])
+	if err != nil {
+		log.Printf("Error getting transfer ID from URL: %v", err)
+		http.Error(w, "Invalid transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	transfer, err := h.controller.Get_Transfer(ctx, transferID)
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.Printf("Error getting transfer: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(transfer)
+	if err != nil {
+		log.Printf("Error encoding transfer to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler_Inventory) Create_Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var template_req struct {
+		SourceLocationID      int                   `json:"source_location_id"`
+		DestinationLocationID int                   `json:"destination_location_id"`
+		Lines                 []dmodel.TransferLine `json:"lines"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil {
+		log.Printf("Error decoding JSON request body: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	transfer, err := h.controller.Create_Transfer(ctx, &dmodel.Transfer{
+		SourceLocationID:      template_req.SourceLocationID,
+		DestinationLocationID: template_req.DestinationLocationID,
+		Lines:                 template_req.Lines,
+	})
+	if err != nil {
+		if writeTransferError(w, err) || writeSerialError(w, err) {
+			return
+		}
+		log.Printf("Error creating transfer: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(transfer)
+	if err != nil {
+		log.Printf("Error encoding transfer to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("Created transfer %d from location %d to %d", transfer.ID, transfer.SourceLocationID, transfer.DestinationLocationID)
+}
+
+func (h *Handler_Inventory) Ship_Transfer(w http.ResponseWriter, r *http.Request) {
+	h.moveTransfer(w, r, "Shipped", h.controller.Ship_Transfer)
+}
+
+func (h *Handler_Inventory) Receive_Transfer(w http.ResponseWriter, r *http.Request) {
+	h.moveTransfer(w, r, "Received", h.controller.Receive_Transfer)
+}
+
+// applies operation to the transfer in the URL and writes the transfer and the items it changed
+func (h *Handler_Inventory) moveTransfer(w http.ResponseWriter, r *http.Request, done string, operation func(context.Context, int) (*dmodel.TransferUpdate, error)) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	transferID, err := strconv.Atoi(r_params["transferId"])
+	if err != nil {
+		log.Printf("Error getting transfer ID from URL: %v", err)
+		http.Error(w, "Invalid transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	res, err := operation(ctx, transferID)
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.Printf("Error updating transfer: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Printf("Error encoding transfer to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// logging
+	log.Printf("%s transfer %d: %d item(s) changed", done, res.Transfer.ID, len(res.Items))
+}
@@ -0,0 +1,34 @@
+package inventory_notifier
+
+import (
+	"context"
+	"log"
+
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// log notifier
+// -------------------------------------------------------------------
+
+// Notifier_Log
+// writes stock alerts to the service log
+type Notifier_Log struct{}
+
+func NewLog() *Notifier_Log {
+	return &Notifier_Log{}
+}
+
+func (n *Notifier_Log) Notify(_ context.Context, alert *dmodel.StockAlert) error {
+	switch alert.Kind {
+	case dmodel.AlertLowStock:
+		log.Printf("Stock alert %d: product %d at location %d is low on stock (%d available, reorder point %d, reorder %d units)",
+			alert.ID, alert.ProductID, alert.LocationID, alert.Available, alert.ReorderPoint, alert.ReorderQuantity)
+	default:
+		log.Printf("Stock alert %d: product %d at location %d recovered (%d available, reorder point %d)",
+			alert.ID, alert.ProductID, alert.LocationID, alert.Available, alert.ReorderPoint)
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------
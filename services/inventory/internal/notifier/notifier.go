@@ -0,0 +1,14 @@
+package inventory_notifier
+
+import (
+	"context"
+
+	dmodel "inventory-service/pkg"
+)
+
+// Notifier
+// delivers the stock alerts raised when items cross their reorder point; a nil error
+// means the alert was delivered and will not be sent again
+type Notifier interface {
+	Notify(_ context.Context, alert *dmodel.StockAlert) error
+}
@@ -0,0 +1,59 @@
+package inventory_notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// webhook notifier
+// -------------------------------------------------------------------
+
+// time allowed for the webhook to answer each alert
+const webhookTimeout = 5 * time.Second
+
+// Notifier_Webhook
+// posts every stock alert as JSON to a URL; any status other than 2xx fails the delivery
+type Notifier_Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Notifier_Webhook {
+	return &Notifier_Webhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (n *Notifier_Webhook) Notify(ctx context.Context, alert *dmodel.StockAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered stock alert %d with status %d", alert.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------
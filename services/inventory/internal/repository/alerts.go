@@ -0,0 +1,170 @@
+package inventory_repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"slices"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// reorder points and stock alerts
+// -------------------------------------------------------------------
+
+// an item is low on stock while fewer units than its reorder point are available; the
+// low_stock column keeps the state of its last alert, so crossing the reorder point raises
+// one alert (low_stock, or recovered when going back up) in the transaction of the change
+// alerts wait in the stock_alerts table until the delivery worker claims them
+
+const alertColumns = `id, location_id, product_id, kind, available, reorder_point, reorder_quantity, created_at`
+
+func scanAlert(row scanner) (*dmodel.StockAlert, error) {
+	var a dmodel.StockAlert
+	err := row.Scan(&a.ID, &a.LocationID, &a.ProductID, &a.Kind, &a.Available, &a.ReorderPoint, &a.ReorderQuantity, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// retrieving the items low on stock, at a location or at every location when locationID is 0
+// (the units of expired lots count as unavailable, so the items are filtered once scanned)
+func (dr *DataRepo_Inventory) Get_LowStock(ctx context.Context, locationID int) ([]*dmodel.InventoryItem, error) {
+	query := `
+		SELECT ` + itemColumns + ` FROM inventory
+		WHERE reorder_point > 0 AND ($1 = 0 OR location_id = $1)
+		ORDER BY product_id, location_id`
+	rows, err := dr.db.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*dmodel.InventoryItem{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		if item.IsLowStock() {
+			items = append(items, item)
+		}
+	}
+
+	return items, rows.Err()
+}
+
+// set the reorder point and quantity of an item (at the product's preferred location when
+// locationID is 0), raising a stock alert when the new reorder point changes whether the
+// item is low on stock
+func (dr *DataRepo_Inventory) Set_ReorderPoint(ctx context.Context, productID, locationID, reorderPoint, reorderQuantity int) (*dmodel.InventoryItem, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := targetItem(ctx, tx, items, productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE inventory SET reorder_point = $1, reorder_quantity = $2, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $3 AND product_id = $4 RETURNING ` + itemColumns
+	item, err := scanItem(tx.QueryRowContext(ctx, query, reorderPoint, reorderQuantity, target.LocationID, productID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLowStock(ctx, tx, item); err != nil {
+		return nil, err
+	}
+
+	return itemView(items, item, locationID), tx.Commit()
+}
+
+// claim up to limit undelivered alerts, in the order they were raised, until leaseUntil;
+// alerts claimed by another worker are skipped until their claim runs out
+func (dr *DataRepo_Inventory) Claim_StockAlerts(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*dmodel.StockAlert, error) {
+	query := `
+		UPDATE stock_alerts SET claimed_until = $2
+		WHERE id IN (
+			SELECT id FROM stock_alerts
+			WHERE delivered_at IS NULL AND (claimed_until IS NULL OR claimed_until < $1)
+			ORDER BY id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + alertColumns
+	rows, err := dr.db.QueryContext(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []*dmodel.StockAlert
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(alerts, func(a, b *dmodel.StockAlert) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return alerts, nil
+}
+
+// mark a claimed alert as delivered
+func (dr *DataRepo_Inventory) Complete_StockAlert(ctx context.Context, id int) error {
+	query := `UPDATE stock_alerts SET delivered_at = CURRENT_TIMESTAMP WHERE id = $1`
+	result, err := dr.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return internal.ErrAlertNotFound
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// raise an alert when the item (its state after a change) is on the other side of its
+// reorder point than at its last alert
+func checkLowStock(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem) error {
+	low := item.IsLowStock()
+	query := `UPDATE inventory SET low_stock = $1 WHERE location_id = $2 AND product_id = $3 AND low_stock <> $1`
+	result, err := tx.ExecContext(ctx, query, low, item.LocationID, item.ProductID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+
+	kind := dmodel.AlertRecovered
+	if low {
+		kind = dmodel.AlertLowStock
+	}
+	alertQuery := `
+		INSERT INTO stock_alerts (location_id, product_id, kind, available, reorder_point, reorder_quantity)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, alertQuery, item.LocationID, item.ProductID, kind, item.Available(), item.ReorderPoint, item.ReorderQuantity)
+	return err
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,242 @@
+package inventory_repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// batch operations
+// -------------------------------------------------------------------
+
+// the batch operations apply every line or none of them, in a single transaction
+// the failed lines are returned in a *internal.BatchError
+
+// hold the quantity of every line's product for owner, one reservation per line, at the
+// line's location or the one picked by alloc
+// lines must name each (product, location) once
+func (dr *DataRepo_Inventory) Reserve_StockBatch(ctx context.Context, lines []dmodel.StockLine, owner string, expiresAt *time.Time, alloc dmodel.Allocation, actor string) (*dmodel.BatchUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	productIDs := make([]int, len(lines))
+	for i, line := range lines {
+		productIDs[i] = line.ProductID
+	}
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the units allocated to a line are held on the locked item, so later lines of the
+	// same product see what is left
+	var failures []dmodel.LineFailure
+	allocated := make([]*dmodel.InventoryItem, len(lines))
+	for i, line := range lines {
+		if len(locked[line.ProductID]) == 0 {
+			failures = append(failures, lineFailure(line, line.ProductID, internal.ErrItemNotFound))
+			continue
+		}
+		lineAlloc := alloc
+		lineAlloc.LocationID = line.LocationID
+		item, err := allocate(locked[line.ProductID], locations, lineAlloc, line.Quantity)
+		if err != nil {
+			failures = append(failures, lineFailure(line, line.ProductID, err))
+			continue
+		}
+		item.Reserved += line.Quantity
+		allocated[i] = item
+	}
+	if len(failures) > 0 {
+		return nil, &internal.BatchError{Failures: failures}
+	}
+
+	res := &dmodel.BatchUpdate{}
+	items := make(map[itemKey]*dmodel.InventoryItem, len(lines))
+	for i, line := range lines {
+		update, err := reserve(ctx, tx, allocated[i].LocationID, line.ProductID, line.Quantity, owner, expiresAt, actor)
+		if err != nil {
+			return nil, err
+		}
+		res.Reservations = append(res.Reservations, update.Reservation)
+		items[keyOf(update.Item)] = update.Item
+	}
+	res.Items = sortedItems(items)
+
+	return res, tx.Commit()
+}
+
+// fulfill the quantity of every line's reservation (every remaining unit when 0)
+// lines must name each reservation once
+func (dr *DataRepo_Inventory) Fulfill_ReservationBatch(ctx context.Context, lines []dmodel.StockLine, actor string) (*dmodel.BatchUpdate, error) {
+	return dr.settleBatch(ctx, lines, true, actor)
+}
+
+// release the quantity of every line's reservation (every remaining unit when 0)
+// lines must name each reservation once
+func (dr *DataRepo_Inventory) Release_ReservationBatch(ctx context.Context, lines []dmodel.StockLine, actor string) (*dmodel.BatchUpdate, error) {
+	return dr.settleBatch(ctx, lines, false, actor)
+}
+
+func (dr *DataRepo_Inventory) settleBatch(ctx context.Context, lines []dmodel.StockLine, fulfill bool, actor string) (*dmodel.BatchUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// reservations are locked first, by id, then the items of their products
+	reservationIDs := make([]int64, len(lines))
+	for i, line := range lines {
+		reservationIDs[i] = int64(line.ReservationID)
+	}
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(reservationIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make(map[int]*dmodel.Reservation, len(lines))
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		reservations[r.ID] = r
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var failures []dmodel.LineFailure
+	quantities := make([]int, len(lines))
+	productIDs := make([]int, 0, len(lines))
+	for i, line := range lines {
+		r, ok := reservations[line.ReservationID]
+		if !ok {
+			failures = append(failures, lineFailure(line, 0, internal.ErrReservationNotFound))
+			continue
+		}
+		quantity, err := checkSettlement(r, line.Quantity)
+		if err != nil {
+			failures = append(failures, lineFailure(line, r.ProductID, err))
+			continue
+		}
+		quantities[i] = quantity
+		productIDs = append(productIDs, r.ProductID)
+	}
+	if len(failures) > 0 {
+		return nil, &internal.BatchError{Failures: failures}
+	}
+
+	if _, err := lockItems(ctx, tx, productIDs); err != nil {
+		return nil, err
+	}
+
+	// the serial numbers of a line are checked as it is applied; the lines after a rejected
+	// one are still applied to report them as well, the transaction is rolled back anyway
+	res := &dmodel.BatchUpdate{}
+	items := make(map[itemKey]*dmodel.InventoryItem, len(lines))
+	for i, line := range lines {
+		r := reservations[line.ReservationID]
+		update, err := settle(ctx, tx, r, quantities[i], fulfill, line.SerialNumbers, actor)
+		if isSerialError(err) {
+			failures = append(failures, lineFailure(line, r.ProductID, err))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Reservations = append(res.Reservations, update.Reservation)
+		res.Lots = append(res.Lots, update.Lots...)
+		res.Serials = append(res.Serials, update.Serials...)
+		items[keyOf(update.Item)] = update.Item
+	}
+	if len(failures) > 0 {
+		return nil, &internal.BatchError{Failures: failures}
+	}
+	res.Items = sortedItems(items)
+
+	return res, tx.Commit()
+}
+
+// -------------------------------------------------------------------
+
+// lock the items of the given products at every location in (product_id, location_id)
+// order, so concurrent batches cannot deadlock, and return the ones found by product ID
+func lockItems(ctx context.Context, tx *sql.Tx, productIDs []int) (map[int][]*dmodel.InventoryItem, error) {
+	ids := make([]int64, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = int64(id)
+	}
+
+	query := `SELECT ` + itemColumns + ` FROM inventory WHERE product_id = ANY($1) ORDER BY product_id, location_id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locked := make(map[int][]*dmodel.InventoryItem, len(productIDs))
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		locked[item.ProductID] = append(locked[item.ProductID], item)
+	}
+
+	return locked, rows.Err()
+}
+
+func lineFailure(line dmodel.StockLine, productID int, err error) dmodel.LineFailure {
+	return dmodel.LineFailure{
+		LocationID:    line.LocationID,
+		ProductID:     productID,
+		ReservationID: line.ReservationID,
+		Quantity:      line.Quantity,
+		Reason:        err.Error(),
+	}
+}
+
+// identifies an item: a product at a location
+type itemKey struct {
+	productID  int
+	locationID int
+}
+
+func keyOf(item *dmodel.InventoryItem) itemKey {
+	return itemKey{productID: item.ProductID, locationID: item.LocationID}
+}
+
+// items in (product_id, location_id) order
+func sortedItems(items map[itemKey]*dmodel.InventoryItem) []*dmodel.InventoryItem {
+	keys := slices.SortedFunc(maps.Keys(items), func(a, b itemKey) int {
+		return cmp.Or(cmp.Compare(a.productID, b.productID), cmp.Compare(a.locationID, b.locationID))
+	})
+
+	res := make([]*dmodel.InventoryItem, 0, len(items))
+	for _, key := range keys {
+		res = append(res, items[key])
+	}
+	return res
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,152 @@
+package inventory_repository
+
+import (
+	"context"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// bulk import and export
+// -------------------------------------------------------------------
+
+// an import sets the stock of every row in a single transaction, locking the items of all
+// its products in (product_id, location_id) order first; the transaction is rolled back
+// on a dry run, or when a failed row rejects an all or nothing import
+
+// set the stock of the items named by rows, recording an import movement for every item
+// whose stock changes; rows that cannot be applied (including rows raising the stock of a
+// serial-tracked product) are reported in the result, and are skipped when bestEffort is
+// set, otherwise nothing is saved
+func (dr *DataRepo_Inventory) Import_Inventory(ctx context.Context, rows []dmodel.ImportRow, bestEffort, dryRun bool, reference, actor string) (*dmodel.ImportResult, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	productIDs := make([]int, len(rows))
+	for i, row := range rows {
+		productIDs[i] = row.ProductID
+	}
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dmodel.ImportResult{Errors: []dmodel.ImportError{}}
+	updateQuery := `UPDATE inventory SET stock = $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	createQuery := `INSERT INTO inventory (location_id, product_id) VALUES ($1, $2) RETURNING ` + itemColumns
+
+	setBy := make(map[itemKey]int, len(rows))
+	for _, row := range rows {
+		items := locked[row.ProductID]
+		var target *dmodel.InventoryItem
+		switch {
+		case len(items) == 0:
+			res.Errors = append(res.Errors, importFailure(row, internal.ErrItemNotFound))
+			continue
+		case row.LocationID == 0 && len(items) > 1:
+			res.Errors = append(res.Errors, importFailure(row, internal.ErrLocationRequired))
+			continue
+		case row.LocationID == 0:
+			target = dmodel.Allocation{Policy: dmodel.AllocationPriority}.Pick(items, locations, 0)
+		default:
+			target = findItem(items, row.LocationID)
+		}
+		if target == nil {
+			if _, ok := locations[row.LocationID]; !ok {
+				res.Errors = append(res.Errors, importFailure(row, internal.ErrLocationNotFound))
+				continue
+			}
+			target, err = scanItem(tx.QueryRowContext(ctx, createQuery, row.LocationID, row.ProductID))
+			if err != nil {
+				return nil, err
+			}
+			locked[row.ProductID] = append(locked[row.ProductID], target)
+		}
+
+		key := keyOf(target)
+		if line, ok := setBy[key]; ok {
+			res.Errors = append(res.Errors, importFailure(row, fmt.Errorf("%w: item already set by line %d", internal.ErrInvalidImport, line)))
+			continue
+		}
+		if row.Stock < target.Reserved {
+			res.Errors = append(res.Errors, importFailure(row, internal.ErrStockBelowReserved))
+			continue
+		}
+		if err := checkUnnamedIncrease(target, row.Stock-target.Stock); err != nil {
+			res.Errors = append(res.Errors, importFailure(row, err))
+			continue
+		}
+		setBy[key] = row.Line
+		if row.Stock == target.Stock {
+			res.Unchanged++
+			continue
+		}
+
+		item, err := scanItem(tx.QueryRowContext(ctx, updateQuery, row.Stock, target.LocationID, target.ProductID))
+		if err != nil {
+			return nil, err
+		}
+		err = recordMovement(ctx, tx, item, dmodel.Movement{
+			Reason:      dmodel.MovementImport,
+			StockDelta:  row.Stock - target.Stock,
+			ReferenceID: reference,
+			Actor:       actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		*target = *item
+		res.Applied++
+	}
+
+	if dryRun || (len(res.Errors) > 0 && !bestEffort) {
+		return res, nil
+	}
+	res.Committed = true
+
+	return res, tx.Commit()
+}
+
+// pass every item (at one location, or at all of them when locationID is 0) to write in
+// (product_id, location_id) order, reading them as they are written
+func (dr *DataRepo_Inventory) Export_Inventory(ctx context.Context, locationID int, write func(*dmodel.InventoryItem) error) error {
+	query := `SELECT ` + itemColumns + ` FROM inventory WHERE ($1 = 0 OR location_id = $1) ORDER BY product_id, location_id`
+	rows, err := dr.db.QueryContext(ctx, query, locationID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return err
+		}
+		if err := write(item); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+func importFailure(row dmodel.ImportRow, err error) dmodel.ImportError {
+	return dmodel.ImportError{
+		Line:       row.Line,
+		ProductID:  row.ProductID,
+		LocationID: row.LocationID,
+		Reason:     err.Error(),
+	}
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,420 @@
+package inventory_repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+	"slices"
+)
+
+// -------------------------------------------------------------------
+// costing and valuation
+// -------------------------------------------------------------------
+
+// the stock of a product is valued by cost layers, kept over every location: units
+// entering the stock add a layer (merged into the open one under weighted average
+// costing), units leaving it are taken out of the oldest layers first, at their share of
+// the layer's value so the cost of a layer's units always adds up to what it was received at
+// every cost is stored with its currency, which is the one the product's stock was first
+// costed in (see costCurrency), so changing the currency of a product never relabels the
+// costs already recorded, nor mixes two currencies in its layers
+// cost layers are only changed by recordMovement, after the items of the product were
+// locked, so they are locked in product order like the items
+// units moved between locations by a transfer stay in the stock of the product, so transfers
+// leave its layers alone: the units leave the source at the average cost of the stock, and
+// arrive at the destination at the cost they left at
+
+const costLayerColumns = `id, quantity, remaining, unit_cost, value, currency, created_at`
+
+func scanCostLayer(row scanner) (*dmodel.CostLayer, error) {
+	var l dmodel.CostLayer
+	if err := row.Scan(&l.ID, &l.Quantity, &l.Remaining, &l.UnitCost, &l.Value, &l.Value.Currency, &l.ReceivedAt); err != nil {
+		return nil, err
+	}
+	l.UnitCost.Currency = l.Value.Currency
+
+	return &l, nil
+}
+
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// the currency the costs of a product are kept in: the currency of its cost layers, or the
+// product's currency until it has any
+func costCurrency(ctx context.Context, q rowQueryer, productID int) (string, error) {
+	query := `
+		SELECT COALESCE(
+			(SELECT currency FROM inventory_cost_layers WHERE product_id = $1 ORDER BY id DESC LIMIT 1),
+			(SELECT currency FROM products WHERE id = $1),
+			$2)`
+	var currency string
+	err := q.QueryRowContext(ctx, query, productID, money.DefaultCurrency).Scan(&currency)
+
+	return currency, err
+}
+
+// the cost of each unit a movement moves in or out of the stock of a product, and the value
+// it adds to the stock (negative when units leave it), in the currency of its costs; layers
+// are taken out here, but only added once the movement is recorded, and neither for a transfer
+func costMovement(ctx context.Context, tx *sql.Tx, productID int, currency string, m dmodel.Movement) (*money.Money, money.Money, error) {
+	switch {
+	case m.Reason == dmodel.MovementTransferOut:
+		unitCost, err := currentUnitCost(ctx, tx, productID)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		unitCost.Currency = currency
+		return &unitCost, unitCost.Mul(m.StockDelta), nil
+
+	case m.StockDelta < 0:
+		cost, err := consumeCostLayers(ctx, tx, productID, -m.StockDelta)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		cost.Currency = currency
+		unitCost := prorate(cost, 1, -m.StockDelta)
+		return &unitCost, money.New(-cost.Amount, currency), nil
+
+	case m.StockDelta > 0:
+		value := money.New(m.Value.Amount, currency)
+		var unitCost money.Money
+		switch {
+		case m.UnitCost != nil:
+			unitCost = money.New(m.UnitCost.Amount, currency)
+			if value.Amount == 0 {
+				value = unitCost.Mul(m.StockDelta)
+			}
+		case value.Amount != 0:
+			unitCost = prorate(value, 1, m.StockDelta)
+		default:
+			current, err := currentUnitCost(ctx, tx, productID)
+			if err != nil {
+				return nil, money.Money{}, err
+			}
+			unitCost = money.New(current.Amount, currency)
+			value = unitCost.Mul(m.StockDelta)
+		}
+		return &unitCost, value, nil
+	}
+
+	return nil, money.New(0, currency), nil
+}
+
+// the cost of units entering the stock of a product without one: the average cost of its
+// stock, or the cost of its last layer when it has no units left, or zero
+func currentUnitCost(ctx context.Context, tx *sql.Tx, productID int) (money.Money, error) {
+	query := `
+		SELECT COALESCE(
+			(SELECT ROUND(SUM(value) / SUM(remaining), 2) FROM inventory_cost_layers WHERE product_id = $1 AND remaining > 0),
+			(SELECT unit_cost FROM inventory_cost_layers WHERE product_id = $1 ORDER BY id DESC LIMIT 1),
+			0)`
+	var cost money.Money
+	err := tx.QueryRowContext(ctx, query, productID).Scan(&cost)
+
+	return cost, err
+}
+
+// take quantity units out of the open layers of a product, oldest first, returning their
+// cost; units beyond the layers (stock predating its costing) leave at no cost
+func consumeCostLayers(ctx context.Context, tx *sql.Tx, productID, quantity int) (money.Money, error) {
+	layers, err := lockCostLayers(ctx, tx, productID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	query := `UPDATE inventory_cost_layers SET remaining = remaining - $1, value = value - $2 WHERE id = $3`
+	var cost money.Money
+	for _, layer := range layers {
+		if quantity == 0 {
+			break
+		}
+		taken := min(quantity, layer.Remaining)
+		share := prorate(layer.Value, taken, layer.Remaining)
+		if _, err := tx.ExecContext(ctx, query, taken, share, layer.ID); err != nil {
+			return money.Money{}, err
+		}
+		cost.Amount += share.Amount
+		quantity -= taken
+	}
+
+	return cost, nil
+}
+
+// add the units a movement brought into the stock of a product as a layer, or into its open
+// layer when the product is costed by weighted average
+func addCostLayer(ctx context.Context, tx *sql.Tx, productID int, movementID int64, quantity int, value money.Money) error {
+	method, err := costingMethod(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	if method == dmodel.CostingWeightedAverage {
+		layers, err := lockCostLayers(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		if len(layers) > 0 {
+			layer := layers[len(layers)-1]
+			return mergeCostLayer(ctx, tx, layer, layer.Quantity+quantity, layer.Remaining+quantity, money.New(layer.Value.Amount+value.Amount, ""))
+		}
+	}
+
+	query := `
+		INSERT INTO inventory_cost_layers (product_id, movement_id, quantity, remaining, unit_cost, value, currency)
+		VALUES ($1, $2, $3, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, query, productID, movementID, quantity, prorate(value, 1, quantity), value, value.Currency)
+
+	return err
+}
+
+// set the units and value of a layer, at their average cost
+func mergeCostLayer(ctx context.Context, tx *sql.Tx, layer *dmodel.CostLayer, quantity, remaining int, value money.Money) error {
+	query := `UPDATE inventory_cost_layers SET quantity = $1, remaining = $2, value = $3, unit_cost = $4 WHERE id = $5`
+	_, err := tx.ExecContext(ctx, query, quantity, remaining, value, prorate(value, 1, remaining), layer.ID)
+
+	return err
+}
+
+// lock the layers of a product still holding units, oldest first
+func lockCostLayers(ctx context.Context, tx *sql.Tx, productID int) ([]*dmodel.CostLayer, error) {
+	query := `SELECT ` + costLayerColumns + ` FROM inventory_cost_layers WHERE product_id = $1 AND remaining > 0 ORDER BY id FOR UPDATE`
+	return queryCostLayers(ctx, tx, query, productID)
+}
+
+func queryCostLayers(ctx context.Context, q queryer, query string, productID int) ([]*dmodel.CostLayer, error) {
+	rows, err := q.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var layers []*dmodel.CostLayer
+	for rows.Next() {
+		layer, err := scanCostLayer(rows)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	return layers, rows.Err()
+}
+
+func costingMethod(ctx context.Context, tx *sql.Tx, productID int) (string, error) {
+	var method string
+	err := tx.QueryRowContext(ctx, `SELECT method FROM product_costing WHERE product_id = $1`, productID).Scan(&method)
+	if err == sql.ErrNoRows {
+		return dmodel.DefaultCostingMethod, nil
+	}
+
+	return method, err
+}
+
+// -------------------------------------------------------------------
+
+// retrieving the costing method of a product held at any location, and the cost of its stock
+func (dr *DataRepo_Inventory) Get_Costing(ctx context.Context, productID int) (*dmodel.ProductCosting, error) {
+	query := `
+		SELECT COALESCE((SELECT method FROM product_costing WHERE product_id = $1), $2)
+		WHERE EXISTS (SELECT 1 FROM inventory WHERE product_id = $1)`
+	costing := &dmodel.ProductCosting{ProductID: productID}
+	err := dr.db.QueryRowContext(ctx, query, productID, dmodel.DefaultCostingMethod).Scan(&costing.Method)
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if costing.Currency, err = costCurrency(ctx, dr.db, productID); err != nil {
+		return nil, err
+	}
+
+	layersQuery := `SELECT ` + costLayerColumns + ` FROM inventory_cost_layers WHERE product_id = $1 AND remaining > 0 ORDER BY id`
+	layers, err := queryCostLayers(ctx, dr.db, layersQuery, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeCosting(costing, layers), nil
+}
+
+// set the costing method of a product held at any location; switching to weighted average
+// merges its open layers into the newest one, at their average cost
+func (dr *DataRepo_Inventory) Set_CostingMethod(ctx context.Context, productID int, method string) (*dmodel.ProductCosting, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+
+	query := `
+		INSERT INTO product_costing (product_id, method) VALUES ($1, $2)
+		ON CONFLICT (product_id) DO UPDATE SET method = EXCLUDED.method, updated_at = CURRENT_TIMESTAMP`
+	if _, err := tx.ExecContext(ctx, query, productID, method); err != nil {
+		return nil, err
+	}
+
+	layers, err := lockCostLayers(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if method == dmodel.CostingWeightedAverage && len(layers) > 1 {
+		newest := layers[len(layers)-1]
+		remaining, value := 0, money.Money{}
+		for _, layer := range layers {
+			remaining += layer.Remaining
+			value.Amount += layer.Value.Amount
+		}
+
+		emptyQuery := `UPDATE inventory_cost_layers SET remaining = 0, value = 0 WHERE product_id = $1 AND remaining > 0 AND id <> $2`
+		if _, err := tx.ExecContext(ctx, emptyQuery, productID, newest.ID); err != nil {
+			return nil, err
+		}
+		quantity := newest.Quantity + remaining - newest.Remaining
+		if err := mergeCostLayer(ctx, tx, newest, quantity, remaining, value); err != nil {
+			return nil, err
+		}
+		newest.Quantity, newest.Remaining, newest.Value, newest.UnitCost = quantity, remaining, value, prorate(value, 1, remaining)
+		layers = []*dmodel.CostLayer{newest}
+	}
+
+	costing := &dmodel.ProductCosting{ProductID: productID, Method: method}
+	if costing.Currency, err = costCurrency(ctx, tx, productID); err != nil {
+		return nil, err
+	}
+
+	return summarizeCosting(costing, layers), tx.Commit()
+}
+
+// fill in the stock of a product's costing from its open layers
+func summarizeCosting(costing *dmodel.ProductCosting, layers []*dmodel.CostLayer) *dmodel.ProductCosting {
+	costing.Value = money.New(0, costing.Currency)
+	costing.Layers = []dmodel.CostLayer{}
+	for _, layer := range layers {
+		costing.Quantity += layer.Remaining
+		costing.Value.Amount += layer.Value.Amount
+		costing.Layers = append(costing.Layers, *layer)
+	}
+	costing.UnitCost = money.New(0, costing.Currency)
+	if costing.Quantity > 0 {
+		costing.UnitCost = prorate(costing.Value, 1, costing.Quantity)
+	}
+
+	return costing
+}
+
+// -------------------------------------------------------------------
+
+// value the stock of every product (at one location, or all of them when the filter has no
+// location) as it was at filter.AsOf, summing the quantities and values of its movements;
+// categories total the products of each category and currency
+// units in transit are valued at their destination from the moment they were shipped: their
+// transfer_out movement is counted there too, negated, and their transfer_in movement (at
+// the same cost) is left out
+func (dr *DataRepo_Inventory) Get_Valuation(ctx context.Context, filter dmodel.ValuationFilter) (*dmodel.Valuation, error) {
+	query := `
+		SELECT m.product_id, COALESCE(p.name, ''), COALESCE(p.category, ''), m.currency, COALESCE(c.method, $4),
+			SUM(m.stock_delta), SUM(m.value_delta)
+		FROM (
+			SELECT product_id, location_id, stock_delta, value_delta, currency, created_at FROM inventory_movements
+			WHERE reason <> 'transfer_in'
+			UNION ALL
+			SELECT s.product_id, t.destination_location_id, -s.stock_delta, -s.value_delta, s.currency, s.created_at
+			FROM inventory_movements s JOIN transfers t ON t.id = s.transfer_id
+			WHERE s.reason = 'transfer_out'
+		) m
+		LEFT JOIN products p ON p.id = m.product_id
+		LEFT JOIN product_costing c ON c.product_id = m.product_id
+		WHERE m.created_at <= $1 AND ($2 = 0 OR m.location_id = $2) AND ($3 = '' OR p.category = $3)
+		GROUP BY m.product_id, m.currency, p.name, p.category, c.method
+		HAVING SUM(m.stock_delta) <> 0 OR SUM(m.value_delta) <> 0
+		ORDER BY m.product_id, m.currency`
+	rows, err := dr.db.QueryContext(ctx, query, filter.AsOf, filter.LocationID, filter.Category, dmodel.DefaultCostingMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	valuation := &dmodel.Valuation{
+		AsOf:       filter.AsOf,
+		LocationID: filter.LocationID,
+		Products:   []dmodel.ValuationLine{},
+		Categories: []dmodel.ValuationCategory{},
+	}
+	type categoryKey struct {
+		category string
+		currency string
+	}
+	categories := make(map[categoryKey]*dmodel.ValuationCategory)
+	for rows.Next() {
+		var line dmodel.ValuationLine
+		err := rows.Scan(&line.ProductID, &line.Name, &line.Category, &line.Currency, &line.Method, &line.Quantity, &line.Value)
+		if err != nil {
+			return nil, err
+		}
+		line.Value.Currency = line.Currency
+		line.UnitCost = money.New(0, line.Currency)
+		if line.Quantity > 0 {
+			line.UnitCost = prorate(line.Value, 1, line.Quantity)
+		}
+		valuation.Products = append(valuation.Products, line)
+
+		key := categoryKey{line.Category, line.Currency}
+		total, ok := categories[key]
+		if !ok {
+			total = &dmodel.ValuationCategory{Category: line.Category, Currency: line.Currency, Value: money.New(0, line.Currency)}
+			categories[key] = total
+		}
+		total.Products++
+		total.Quantity += line.Quantity
+		total.Value.Amount += line.Value.Amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, total := range categories {
+		valuation.Categories = append(valuation.Categories, *total)
+	}
+	slices.SortFunc(valuation.Categories, func(a, b dmodel.ValuationCategory) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Currency, b.Currency))
+	})
+
+	return valuation, nil
+}
+
+// -------------------------------------------------------------------
+
+// the share part/whole of an amount, rounded half away from zero to the nearest minor unit
+func prorate(m money.Money, part, whole int) money.Money {
+	if part == whole {
+		return m
+	}
+
+	n := m.Amount * int64(part)
+	d := int64(whole)
+	q, r := n/d, n%d
+	if 2*max(r, -r) >= d {
+		if n < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+
+	return money.New(q, m.Currency)
+}
+
+// -------------------------------------------------------------------
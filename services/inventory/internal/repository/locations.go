@@ -0,0 +1,200 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// locations
+// -------------------------------------------------------------------
+
+// inventory is kept per (location, product); the operations that do not name a location
+// work on the product's totals, or on its preferred location when they change stock
+
+const locationColumns = `id, code, name, latitude, longitude, priority`
+
+func scanLocation(row scanner) (*dmodel.Location, error) {
+	var l dmodel.Location
+	if err := row.Scan(&l.ID, &l.Code, &l.Name, &l.Latitude, &l.Longitude, &l.Priority); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// retrieving all locations, in priority order
+func (dr *DataRepo_Inventory) Get_Locations(ctx context.Context) ([]*dmodel.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM locations ORDER BY priority, id`
+	rows, err := dr.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []*dmodel.Location{}
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, l)
+	}
+
+	return locations, rows.Err()
+}
+
+func (dr *DataRepo_Inventory) Get_Location(ctx context.Context, id int) (*dmodel.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE id = $1`
+	l, err := scanLocation(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrLocationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (dr *DataRepo_Inventory) Create_Location(ctx context.Context, location *dmodel.Location) (*dmodel.Location, error) {
+	query := `INSERT INTO locations (code, name, latitude, longitude, priority) VALUES ($1, $2, $3, $4, $5) RETURNING ` + locationColumns
+	l, err := scanLocation(dr.db.QueryRowContext(ctx, query, location.Code, location.Name, location.Latitude, location.Longitude, location.Priority))
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, internal.ErrLocationExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// -------------------------------------------------------------------
+
+// every location by ID
+func loadLocations(ctx context.Context, tx *sql.Tx) (map[int]*dmodel.Location, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT `+locationColumns+` FROM locations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make(map[int]*dmodel.Location)
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations[l.ID] = l
+	}
+
+	return locations, rows.Err()
+}
+
+// lock the items of a product at every location, in location_id order
+func lockProductItems(ctx context.Context, tx *sql.Tx, productID int) ([]*dmodel.InventoryItem, error) {
+	query := `SELECT ` + itemColumns + ` FROM inventory WHERE product_id = $1 ORDER BY location_id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*dmodel.InventoryItem
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// the item whose stock a change applies to, among the locked items of its product: the
+// one at locationID, created empty when the product is not stocked there yet, or the one
+// at the preferred location when locationID is 0
+func targetItem(ctx context.Context, tx *sql.Tx, items []*dmodel.InventoryItem, productID, locationID int) (*dmodel.InventoryItem, error) {
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if locationID == 0 {
+		return dmodel.Allocation{Policy: dmodel.AllocationPriority}.Pick(items, locations, 0), nil
+	}
+
+	if item := findItem(items, locationID); item != nil {
+		return item, nil
+	}
+	if _, ok := locations[locationID]; !ok {
+		return nil, internal.ErrLocationNotFound
+	}
+
+	query := `INSERT INTO inventory (location_id, product_id) VALUES ($1, $2) RETURNING ` + itemColumns
+	return scanItem(tx.QueryRowContext(ctx, query, locationID, productID))
+}
+
+// totals of the items of one product, with the items as their per location breakdown
+func sumItems(productID int, items []*dmodel.InventoryItem) *dmodel.InventoryItem {
+	total := &dmodel.InventoryItem{ProductID: productID, Locations: items}
+	for _, item := range items {
+		total.Stock += item.Stock
+		total.Reserved += item.Reserved
+		total.Damaged += item.Damaged
+		total.InTransit += item.InTransit
+		total.Expired += item.Expired
+		total.ReorderPoint += item.ReorderPoint
+		total.ReorderQuantity += item.ReorderQuantity
+		total.Version += item.Version
+		total.SerialTracked = item.SerialTracked
+	}
+
+	return total
+}
+
+// the totals of every product of items, which come grouped by product
+func productTotals(items []*dmodel.InventoryItem) []*dmodel.InventoryItem {
+	var totals []*dmodel.InventoryItem
+	for start := 0; start < len(items); {
+		end := start + 1
+		for end < len(items) && items[end].ProductID == items[start].ProductID {
+			end++
+		}
+		totals = append(totals, sumItems(items[start].ProductID, items[start:end]))
+		start = end
+	}
+
+	return totals
+}
+
+// the view of an item after a change: the item itself when the change named its location,
+// otherwise the totals of its product with the item replacing its previous state
+func itemView(items []*dmodel.InventoryItem, item *dmodel.InventoryItem, locationID int) *dmodel.InventoryItem {
+	if locationID != 0 {
+		return item
+	}
+
+	updated := make([]*dmodel.InventoryItem, len(items))
+	for i, previous := range items {
+		updated[i] = previous
+		if previous.LocationID == item.LocationID {
+			updated[i] = item
+		}
+	}
+
+	return sumItems(item.ProductID, updated)
+}
+
+// -------------------------------------------------------------------
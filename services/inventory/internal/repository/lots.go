@@ -0,0 +1,362 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"slices"
+	"strings"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// lots
+// -------------------------------------------------------------------
+
+// lots break down part of an item's stock and reserved quantities; the rest of its units
+// are untracked. Lots only change together with their item, under the item's row lock, so
+// they take no locks of their own
+// - reservations hold units of the lots that expire first (skipping expired lots), then
+//   untracked units, and fulfillments consume the held lots earliest-expiring first
+// - units leaving stock without naming a lot (adjustments, transfers, ...) are taken from
+//   the untracked units first, then from the unreserved units of the lots, earliest-expiring
+//   first
+// - a transfer takes the lots its units leave with along, into the same lots at its
+//   destination; returns and adjustments add units back to the lots they name
+
+const lotColumns = `id, location_id, product_id, lot_number, expires_at, stock, reserved, COALESCE(expires_at <= CURRENT_TIMESTAMP, false), created_at`
+
+// lots of an item that may be reserved, the ones expiring first first
+const lotOrder = `ORDER BY expires_at NULLS LAST, lot_number`
+
+func scanLot(row scanner) (*dmodel.Lot, error) {
+	var l dmodel.Lot
+	var expiresAt sql.NullTime
+	err := row.Scan(&l.ID, &l.LocationID, &l.ProductID, &l.LotNumber, &expiresAt, &l.Stock, &l.Reserved, &l.Expired, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		l.ExpiresAt = &expiresAt.Time
+	}
+
+	return &l, nil
+}
+
+// retrieving the lots of a product holding stock, at a location or at every location when
+// locationID is 0, the ones expiring first first
+func (dr *DataRepo_Inventory) Get_Lots(ctx context.Context, productID, locationID int) ([]*dmodel.Lot, error) {
+	query := `
+		SELECT ` + lotColumns + ` FROM inventory_lots
+		WHERE product_id = $1 AND ($2 = 0 OR location_id = $2) AND stock > 0
+		ORDER BY location_id, expires_at NULLS LAST, lot_number`
+	rows, err := dr.db.QueryContext(ctx, query, productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []*dmodel.Lot{}
+	for rows.Next() {
+		l, err := scanLot(rows)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, l)
+	}
+
+	return lots, rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// add units to a lot of a product at a location, creating the lot on its first receipt;
+// a lot keeps the expiry date it was created with
+// the item must exist and be locked by the caller
+func receiveLot(ctx context.Context, tx *sql.Tx, locationID, productID int, lot dmodel.LotQuantity) error {
+	query := `
+		INSERT INTO inventory_lots (location_id, product_id, lot_number, expires_at, stock) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (location_id, product_id, lot_number) DO UPDATE SET stock = inventory_lots.stock + EXCLUDED.stock
+		WHERE inventory_lots.expires_at IS NOT DISTINCT FROM EXCLUDED.expires_at`
+	result, err := tx.ExecContext(ctx, query, locationID, productID, lot.LotNumber, lot.ExpiresAt, lot.Quantity)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: lot %s of product %d has another expiry date", internal.ErrInvalidLot, lot.LotNumber, productID)
+	}
+
+	return nil
+}
+
+// add units back to lots of a product at a location (returned or found units); a lot given
+// without an expiry date takes the one it has at any location
+// the item must exist and be locked by the caller
+func restockLots(ctx context.Context, tx *sql.Tx, locationID, productID int, lots []dmodel.LotQuantity) error {
+	query := `SELECT expires_at FROM inventory_lots WHERE product_id = $1 AND lot_number = $2 ORDER BY id LIMIT 1`
+	for _, lot := range lots {
+		if lot.ExpiresAt == nil {
+			var expiresAt sql.NullTime
+			err := tx.QueryRowContext(ctx, query, productID, lot.LotNumber).Scan(&expiresAt)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if expiresAt.Valid {
+				lot.ExpiresAt = &expiresAt.Time
+			}
+		}
+		if err := receiveLot(ctx, tx, locationID, productID, lot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// the units of the same lot added up, in lot number order
+func mergeLots(lots []dmodel.LotQuantity) []dmodel.LotQuantity {
+	var merged []dmodel.LotQuantity
+	for _, lot := range lots {
+		i := slices.IndexFunc(merged, func(m dmodel.LotQuantity) bool { return m.LotNumber == lot.LotNumber })
+		if i < 0 {
+			merged = append(merged, lot)
+			continue
+		}
+		merged[i].Quantity += lot.Quantity
+	}
+	slices.SortFunc(merged, func(a, b dmodel.LotQuantity) int {
+		return strings.Compare(a.LotNumber, b.LotNumber)
+	})
+
+	return merged
+}
+
+// remove units from the unreserved units of named lots of an item, returning the lots with
+// their expiry dates; a lot holding fewer unreserved units fails with ErrInvalidLot
+// the item must be locked by the caller, before its stock is changed
+func takeLots(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, lots []dmodel.LotQuantity) ([]dmodel.LotQuantity, error) {
+	query := `
+		UPDATE inventory_lots SET stock = stock - $1
+		WHERE location_id = $2 AND product_id = $3 AND lot_number = $4 AND stock - reserved >= $1
+		RETURNING expires_at`
+	taken := make([]dmodel.LotQuantity, 0, len(lots))
+	for _, lot := range lots {
+		var expiresAt sql.NullTime
+		err := tx.QueryRowContext(ctx, query, lot.Quantity, item.LocationID, item.ProductID, lot.LotNumber).Scan(&expiresAt)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: lot %s of product %d has fewer than %d unreserved units at location %d", internal.ErrInvalidLot, lot.LotNumber, item.ProductID, lot.Quantity, item.LocationID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lot.ExpiresAt = nil
+		if expiresAt.Valid {
+			lot.ExpiresAt = &expiresAt.Time
+		}
+		taken = append(taken, lot)
+	}
+
+	return taken, nil
+}
+
+// the units of a reservation's lots: what it still holds of each
+type heldLot struct {
+	lotID     int
+	lotNumber string
+	expiresAt *time.Time
+	quantity  int
+}
+
+// hold the units of a new reservation in the item's lots that have not expired, the ones
+// expiring first first; whatever the lots cannot hold comes from the untracked units
+// the item must be locked by the caller, and have enough available units
+func allocateLots(ctx context.Context, tx *sql.Tx, r *dmodel.Reservation) error {
+	query := `
+		SELECT id, stock - reserved FROM inventory_lots
+		WHERE location_id = $1 AND product_id = $2 AND stock > reserved
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		` + lotOrder
+	rows, err := tx.QueryContext(ctx, query, r.LocationID, r.ProductID)
+	if err != nil {
+		return err
+	}
+
+	var allocations []heldLot
+	remaining := r.Quantity
+	for rows.Next() && remaining > 0 {
+		var lotID, free int
+		if err := rows.Scan(&lotID, &free); err != nil {
+			rows.Close()
+			return err
+		}
+		n := min(free, remaining)
+		allocations = append(allocations, heldLot{lotID: lotID, quantity: n})
+		remaining -= n
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range allocations {
+		if _, err := tx.ExecContext(ctx, `UPDATE inventory_lots SET reserved = reserved + $1 WHERE id = $2`, a.quantity, a.lotID); err != nil {
+			return err
+		}
+		insertQuery := `INSERT INTO reservation_lots (reservation_id, lot_id, quantity) VALUES ($1, $2, $3)`
+		if _, err := tx.ExecContext(ctx, insertQuery, r.ID, a.lotID, a.quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// take quantity units out of what a reservation (its state before the change) holds of
+// its lots: a fulfillment removes the units of the lots expiring first from their stock,
+// before any untracked unit; a release gives back untracked units first, then the units
+// of the lots expiring last
+// returns the lots a fulfillment consumed
+// the item must be locked by the caller
+func settleLots(ctx context.Context, tx *sql.Tx, r *dmodel.Reservation, quantity int, fulfill bool) ([]dmodel.LotConsumption, error) {
+	query := `
+		SELECT rl.lot_id, l.lot_number, l.expires_at, rl.quantity
+		FROM reservation_lots rl JOIN inventory_lots l ON l.id = rl.lot_id
+		WHERE rl.reservation_id = $1 AND rl.quantity > 0
+		ORDER BY l.expires_at NULLS LAST, l.lot_number`
+	rows, err := tx.QueryContext(ctx, query, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var held []heldLot
+	total := 0
+	for rows.Next() {
+		var h heldLot
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&h.lotID, &h.lotNumber, &expiresAt, &h.quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if expiresAt.Valid {
+			h.expiresAt = &expiresAt.Time
+		}
+		held = append(held, h)
+		total += h.quantity
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	fromLots := min(quantity, total)
+	if !fulfill {
+		untracked := r.Remaining() - total
+		fromLots = max(quantity-untracked, 0)
+		slices.Reverse(held)
+	}
+
+	var consumed []dmodel.LotConsumption
+	for _, h := range held {
+		if fromLots == 0 {
+			break
+		}
+		n := min(fromLots, h.quantity)
+		fromLots -= n
+		removed := 0 // units leaving the lot's stock
+		if fulfill {
+			removed = n
+		}
+
+		heldQuery := `UPDATE reservation_lots SET quantity = quantity - $1, fulfilled_quantity = fulfilled_quantity + $2 WHERE reservation_id = $3 AND lot_id = $4`
+		if _, err := tx.ExecContext(ctx, heldQuery, n, removed, r.ID, h.lotID); err != nil {
+			return nil, err
+		}
+		lotQuery := `UPDATE inventory_lots SET reserved = reserved - $1, stock = stock - $2 WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, lotQuery, n, removed, h.lotID); err != nil {
+			return nil, err
+		}
+
+		if fulfill {
+			consumed = append(consumed, dmodel.LotConsumption{
+				ReservationID: r.ID,
+				LotNumber:     h.lotNumber,
+				ExpiresAt:     h.expiresAt,
+				Quantity:      n,
+			})
+		}
+	}
+
+	return consumed, nil
+}
+
+// remove from the unreserved units of an item's lots, the ones expiring first first, the
+// units its lots hold beyond the item's stock (its state after a change), keeping the
+// item's count of expired units in step; returns the units removed from each lot
+// the item must be locked by the caller
+func trimLots(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem) ([]dmodel.LotQuantity, error) {
+	var lotted int
+	query := `SELECT COALESCE(SUM(stock), 0) FROM inventory_lots WHERE location_id = $1 AND product_id = $2`
+	if err := tx.QueryRowContext(ctx, query, item.LocationID, item.ProductID).Scan(&lotted); err != nil {
+		return nil, err
+	}
+	excess := lotted - item.Stock
+	if excess <= 0 {
+		return nil, nil
+	}
+
+	freeQuery := `
+		SELECT id, lot_number, expires_at, stock - reserved, COALESCE(expires_at <= CURRENT_TIMESTAMP, false) FROM inventory_lots
+		WHERE location_id = $1 AND product_id = $2 AND stock > reserved
+		` + lotOrder
+	rows, err := tx.QueryContext(ctx, freeQuery, item.LocationID, item.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	type trim struct {
+		lotID int
+		lot   dmodel.LotQuantity
+	}
+	var trims []trim
+	for rows.Next() && excess > 0 {
+		var t trim
+		var expiresAt sql.NullTime
+		var free int
+		var expired bool
+		if err := rows.Scan(&t.lotID, &t.lot.LotNumber, &expiresAt, &free, &expired); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if expiresAt.Valid {
+			t.lot.ExpiresAt = &expiresAt.Time
+		}
+		t.lot.Quantity = min(free, excess)
+		trims = append(trims, t)
+		excess -= t.lot.Quantity
+		if expired {
+			item.Expired -= t.lot.Quantity
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trimmed := make([]dmodel.LotQuantity, len(trims))
+	for i, t := range trims {
+		if _, err := tx.ExecContext(ctx, `UPDATE inventory_lots SET stock = stock - $1 WHERE id = $2`, t.lot.Quantity, t.lotID); err != nil {
+			return nil, err
+		}
+		trimmed[i] = t.lot
+	}
+
+	return trimmed, nil
+}
+
+// -------------------------------------------------------------------
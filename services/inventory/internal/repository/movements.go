@@ -0,0 +1,200 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// stock movements
+// -------------------------------------------------------------------
+
+// every change to the quantities of an item appends a movement in the same transaction,
+// so replaying the movements of a product up to any point gives its balances then, and
+// the value of its stock
+
+const movementColumns = `id, location_id, product_id, reason, stock_delta, reserved_delta, damaged_delta, stock_after, reserved_after, damaged_after, COALESCE(reservation_id, 0), COALESCE(transfer_id, 0), COALESCE(purchase_order_id, 0), COALESCE(stocktake_id, 0), COALESCE(reference_id, ''), actor, created_at,
+	unit_cost, value_delta, currency`
+
+func scanMovement(row scanner) (*dmodel.Movement, error) {
+	var m dmodel.Movement
+	err := row.Scan(&m.ID, &m.LocationID, &m.ProductID, &m.Reason, &m.StockDelta, &m.ReservedDelta, &m.DamagedDelta,
+		&m.Stock, &m.Reserved, &m.Damaged, &m.ReservationID, &m.TransferID, &m.PurchaseOrderID, &m.StocktakeID, &m.ReferenceID, &m.Actor, &m.CreatedAt,
+		&m.UnitCost, &m.Value, &m.Value.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if m.UnitCost != nil {
+		m.UnitCost.Currency = m.Value.Currency
+	}
+
+	return &m, nil
+}
+
+// append a movement of the given item, whose balances are the ones after the change, take
+// the units that left its stock out of its lots if they still count them, and raise a stock
+// alert when the change moved the item across its reorder point
+func recordMovement(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, m dmodel.Movement) error {
+	_, err := recordValuedMovement(ctx, tx, item, m)
+	return err
+}
+
+// recordMovement, returning the value the movement added to the stock of the product
+// (negative when units left it): units leaving it are taken out of its cost layers, units
+// entering it are added to them (units moved by a transfer stay in them)
+func recordValuedMovement(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, m dmodel.Movement) (money.Money, error) {
+	currency, err := costCurrency(ctx, tx, item.ProductID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	unitCost, value, err := costMovement(ctx, tx, item.ProductID, currency, m)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	query := `
+		INSERT INTO inventory_movements (location_id, product_id, reason, stock_delta, reserved_delta, damaged_delta,
+			stock_after, reserved_after, damaged_after, reservation_id, transfer_id, purchase_order_id, stocktake_id, reference_id, actor,
+			unit_cost, value_delta, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), NULLIF($11, 0), NULLIF($12, 0), NULLIF($13, 0), NULLIF($14, ''), $15, $16, $17, $18)
+		RETURNING id`
+	var movementID int64
+	err = tx.QueryRowContext(ctx, query, item.LocationID, item.ProductID, m.Reason, m.StockDelta, m.ReservedDelta, m.DamagedDelta,
+		item.Stock, item.Reserved, item.Damaged, m.ReservationID, m.TransferID, m.PurchaseOrderID, m.StocktakeID, m.ReferenceID, m.Actor,
+		unitCost, value, currency).Scan(&movementID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if m.StockDelta > 0 && m.Reason != dmodel.MovementTransferIn {
+		if err := addCostLayer(ctx, tx, item.ProductID, movementID, m.StockDelta, value); err != nil {
+			return money.Money{}, err
+		}
+	}
+	if m.StockDelta < 0 {
+		if _, err := trimLots(ctx, tx, item); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	return value, checkLowStock(ctx, tx, item)
+}
+
+// retrieving the movements of a product (at one location, or all of them when the filter
+// has no location) in the order they were made
+func (dr *DataRepo_Inventory) Get_Movements(ctx context.Context, productID int, filter dmodel.MovementFilter) ([]*dmodel.Movement, error) {
+	query := `
+		SELECT ` + movementColumns + ` FROM inventory_movements
+		WHERE product_id = $1
+			AND ($2::timestamp IS NULL OR created_at >= $2)
+			AND ($3::timestamp IS NULL OR created_at < $3)
+			AND id > $4
+			AND ($6 = 0 OR location_id = $6)
+		ORDER BY id
+		LIMIT $5`
+	rows, err := dr.db.QueryContext(ctx, query, productID, filter.From, filter.To, filter.AfterID, filter.Limit, filter.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []*dmodel.Movement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, m)
+	}
+
+	return movements, rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// the items as they were at a point in time: the stock, reserved and damaged quantities
+// of an item are the balances of its last movement made by then, and its units in transit
+// those of the transfers shipped to its location but not received by then; the reorder
+// settings and serial tracking are the current ones, and items carry no version nor
+// expired units (lots keep no history)
+// items appear once they had a movement or units in transit, so one created later, or
+// whose history predates the ledger, is missing
+func itemsAsOf(ctx context.Context, q queryer, productID, locationID int, asOf time.Time) ([]*dmodel.InventoryItem, error) {
+	query := `
+		WITH balances AS (
+			SELECT DISTINCT ON (location_id, product_id) location_id, product_id, stock_after, reserved_after, damaged_after
+			FROM inventory_movements
+			WHERE created_at <= $1 AND ($2 = 0 OR product_id = $2) AND ($3 = 0 OR location_id = $3)
+			ORDER BY location_id, product_id, id DESC
+		), transit AS (
+			SELECT t.destination_location_id AS location_id, tl.product_id, SUM(tl.quantity) AS in_transit
+			FROM transfers t JOIN transfer_lines tl ON tl.transfer_id = t.id
+			WHERE t.shipped_at <= $1 AND (t.received_at IS NULL OR t.received_at > $1)
+				AND ($2 = 0 OR tl.product_id = $2) AND ($3 = 0 OR t.destination_location_id = $3)
+			GROUP BY t.destination_location_id, tl.product_id
+		)
+		SELECT COALESCE(b.location_id, t.location_id), COALESCE(b.product_id, t.product_id),
+			COALESCE(b.stock_after, 0), COALESCE(b.reserved_after, 0), COALESCE(b.damaged_after, 0), COALESCE(t.in_transit, 0),
+			COALESCE(i.reorder_point, 0), COALESCE(i.reorder_quantity, 0),
+			EXISTS (SELECT 1 FROM serial_tracked_products s WHERE s.product_id = COALESCE(b.product_id, t.product_id))
+		FROM balances b
+		FULL JOIN transit t ON t.location_id = b.location_id AND t.product_id = b.product_id
+		LEFT JOIN inventory i ON i.location_id = COALESCE(b.location_id, t.location_id) AND i.product_id = COALESCE(b.product_id, t.product_id)
+		ORDER BY 2, 1`
+	rows, err := q.QueryContext(ctx, query, asOf, productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*dmodel.InventoryItem
+	for rows.Next() {
+		var item dmodel.InventoryItem
+		err := rows.Scan(&item.LocationID, &item.ProductID, &item.Stock, &item.Reserved, &item.Damaged, &item.InTransit,
+			&item.ReorderPoint, &item.ReorderQuantity, &item.SerialTracked)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	return items, rows.Err()
+}
+
+// retrieving all items at a location as they were at asOf, or the totals of every product
+// then when locationID is 0
+func (dr *DataRepo_Inventory) Get_AllAsOf(ctx context.Context, locationID int, asOf time.Time) ([]*dmodel.InventoryItem, error) {
+	items, err := itemsAsOf(ctx, dr.db, 0, locationID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if locationID != 0 {
+		return items, nil
+	}
+
+	return productTotals(items), nil
+}
+
+// retrieving the item of a product at a location as it was at asOf, or its totals then
+// when locationID is 0
+func (dr *DataRepo_Inventory) Get_ByProductIDAsOf(ctx context.Context, productID, locationID int, asOf time.Time) (*dmodel.InventoryItem, error) {
+	items, err := itemsAsOf(ctx, dr.db, productID, locationID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+	if locationID != 0 {
+		return items[0], nil
+	}
+
+	return sumItems(productID, items), nil
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,467 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// purchasing
+// -------------------------------------------------------------------
+
+// receiving goods adds the received units to the stock of the purchase order's location,
+// one movement and one receipt per line, and updates the received quantity of the order's
+// lines in the same transaction; a line may receive more units than were ordered
+// received units are valued at the unit cost of the order's line, unless the receipt line
+// gives its own
+// locks are taken purchase order first, then inventory rows (by product_id, then location_id)
+
+const supplierColumns = `id, code, name, COALESCE(email, ''), created_at`
+
+const purchaseOrderColumns = `id, supplier_id, location_id, state, COALESCE(reference, ''), expected_at, created_at, closed_at`
+
+const receiptColumns = `id, purchase_order_id, location_id, product_id, quantity, COALESCE(lot_number, ''), expires_at, COALESCE(reference, ''), actor, received_at,
+	unit_cost, currency`
+
+func scanSupplier(row scanner) (*dmodel.Supplier, error) {
+	var s dmodel.Supplier
+	if err := row.Scan(&s.ID, &s.Code, &s.Name, &s.Email, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func scanPurchaseOrder(row scanner) (*dmodel.PurchaseOrder, error) {
+	var po dmodel.PurchaseOrder
+	var expectedAt, closedAt sql.NullTime
+	err := row.Scan(&po.ID, &po.SupplierID, &po.LocationID, &po.State, &po.Reference, &expectedAt, &po.CreatedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expectedAt.Valid {
+		po.ExpectedAt = &expectedAt.Time
+	}
+	if closedAt.Valid {
+		po.ClosedAt = &closedAt.Time
+	}
+
+	return &po, nil
+}
+
+func scanReceipt(row scanner) (*dmodel.Receipt, error) {
+	var r dmodel.Receipt
+	var expiresAt sql.NullTime
+	err := row.Scan(&r.ID, &r.PurchaseOrderID, &r.LocationID, &r.ProductID, &r.Quantity, &r.LotNumber, &expiresAt, &r.Reference, &r.Actor, &r.ReceivedAt,
+		&r.UnitCost, &r.UnitCost.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		r.ExpiresAt = &expiresAt.Time
+	}
+
+	return &r, nil
+}
+
+// fill in the lines of the given purchase orders, in product_id order
+func loadPurchaseOrderLines(ctx context.Context, q queryer, orders []*dmodel.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(orders))
+	byID := make(map[int]*dmodel.PurchaseOrder, len(orders))
+	for i, po := range orders {
+		ids[i] = int64(po.ID)
+		byID[po.ID] = po
+		po.Lines = []dmodel.PurchaseOrderLine{}
+	}
+
+	query := `
+		SELECT purchase_order_id, product_id, quantity, received_quantity, unit_cost, currency
+		FROM purchase_order_lines
+		WHERE purchase_order_id = ANY($1)
+		ORDER BY purchase_order_id, product_id`
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int
+		var line dmodel.PurchaseOrderLine
+		if err := rows.Scan(&orderID, &line.ProductID, &line.Quantity, &line.ReceivedQuantity, &line.UnitCost, &line.UnitCost.Currency); err != nil {
+			return err
+		}
+		byID[orderID].Lines = append(byID[orderID].Lines, line)
+	}
+
+	return rows.Err()
+}
+
+// -------------------------------------------------------------------
+// suppliers
+// -------------------------------------------------------------------
+
+// retrieving all suppliers, by code
+func (dr *DataRepo_Inventory) Get_Suppliers(ctx context.Context) ([]*dmodel.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers ORDER BY code`
+	rows, err := dr.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := []*dmodel.Supplier{}
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+
+	return suppliers, rows.Err()
+}
+
+func (dr *DataRepo_Inventory) Get_Supplier(ctx context.Context, id int) (*dmodel.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE id = $1`
+	s, err := scanSupplier(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrSupplierNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (dr *DataRepo_Inventory) Create_Supplier(ctx context.Context, supplier *dmodel.Supplier) (*dmodel.Supplier, error) {
+	query := `INSERT INTO suppliers (code, name, email) VALUES ($1, $2, NULLIF($3, '')) RETURNING ` + supplierColumns
+	s, err := scanSupplier(dr.db.QueryRowContext(ctx, query, supplier.Code, supplier.Name, supplier.Email))
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, internal.ErrSupplierExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// -------------------------------------------------------------------
+// purchase orders
+// -------------------------------------------------------------------
+
+func (dr *DataRepo_Inventory) Get_PurchaseOrder(ctx context.Context, id int) (*dmodel.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE id = $1`
+	po, err := scanPurchaseOrder(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadPurchaseOrderLines(ctx, dr.db, []*dmodel.PurchaseOrder{po}); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// retrieving the purchase orders in a state and/or placed with a supplier (any when empty or 0)
+func (dr *DataRepo_Inventory) Get_PurchaseOrders(ctx context.Context, state string, supplierID int) ([]*dmodel.PurchaseOrder, error) {
+	query := `
+		SELECT ` + purchaseOrderColumns + ` FROM purchase_orders
+		WHERE ($1 = '' OR state = $1) AND ($2 = 0 OR supplier_id = $2)
+		ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, state, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []*dmodel.PurchaseOrder{}
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, po)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadPurchaseOrderLines(ctx, dr.db, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// create an open purchase order; lines must name each product once
+func (dr *DataRepo_Inventory) Create_PurchaseOrder(ctx context.Context, order *dmodel.PurchaseOrder) (*dmodel.PurchaseOrder, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_orders (supplier_id, location_id, reference, expected_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING ` + purchaseOrderColumns
+	created, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, order.SupplierID, order.LocationID, order.Reference, order.ExpectedAt))
+	if err != nil {
+		return nil, foreignKeyError(err)
+	}
+
+	lineQuery := `INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_cost, currency) VALUES ($1, $2, $3, $4, $5)`
+	for _, line := range order.Lines {
+		currency, err := costCurrency(ctx, tx, line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, lineQuery, created.ID, line.ProductID, line.Quantity, line.UnitCost, currency); err != nil {
+			return nil, foreignKeyError(err)
+		}
+	}
+	if err := loadPurchaseOrderLines(ctx, tx, []*dmodel.PurchaseOrder{created}); err != nil {
+		return nil, err
+	}
+
+	return created, tx.Commit()
+}
+
+// cancel an open or partially received purchase order; its outstanding units are no
+// longer expected
+func (dr *DataRepo_Inventory) Cancel_PurchaseOrder(ctx context.Context, id int) (*dmodel.PurchaseOrder, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockPurchaseOrder(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE purchase_orders SET state = 'cancelled', closed_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING ` + purchaseOrderColumns
+	po, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPurchaseOrderLines(ctx, tx, []*dmodel.PurchaseOrder{po}); err != nil {
+		return nil, err
+	}
+
+	return po, tx.Commit()
+}
+
+// add the units of every receipt line to the stock of the purchase order's location, and to
+// the line's lot when it names one, registering its serialized units; every line must be a
+// product of the order (lines must name each product and lot once)
+func (dr *DataRepo_Inventory) Receive_PurchaseOrder(ctx context.Context, id int, lines []dmodel.ReceiptLine, reference, actor string) (*dmodel.ReceiptUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	po, err := lockPurchaseOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	ordered := make(map[int]money.Money, len(po.Lines))
+	for _, line := range po.Lines {
+		ordered[line.ProductID] = line.UnitCost
+	}
+	productIDs := make([]int, len(lines))
+	for i, line := range lines {
+		if _, ok := ordered[line.ProductID]; !ok {
+			return nil, fmt.Errorf("%w: product %d is not on purchase order %d", internal.ErrInvalidReceipt, line.ProductID, po.ID)
+		}
+		productIDs[i] = line.ProductID
+	}
+	if _, err := lockItems(ctx, tx, productIDs); err != nil {
+		return nil, err
+	}
+
+	itemQuery := `
+		INSERT INTO inventory (location_id, product_id, stock) VALUES ($1, $2, $3)
+		ON CONFLICT (location_id, product_id) DO UPDATE
+			SET stock = inventory.stock + EXCLUDED.stock, version = inventory.version + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + itemColumns
+	lineQuery := `UPDATE purchase_order_lines SET received_quantity = received_quantity + $1 WHERE purchase_order_id = $2 AND product_id = $3`
+	receiptQuery := `
+		INSERT INTO purchase_receipts (purchase_order_id, location_id, product_id, quantity, lot_number, expires_at, unit_cost, currency, reference, actor)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, NULLIF($9, ''), $10)
+		RETURNING ` + receiptColumns
+
+	res := &dmodel.ReceiptUpdate{}
+	items := make(map[itemKey]*dmodel.InventoryItem, len(lines))
+	for _, line := range lines {
+		item, err := scanItem(tx.QueryRowContext(ctx, itemQuery, po.LocationID, line.ProductID, line.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		if line.LotNumber != "" {
+			lot := dmodel.LotQuantity{LotNumber: line.LotNumber, ExpiresAt: line.ExpiresAt, Quantity: line.Quantity}
+			if err := receiveLot(ctx, tx, po.LocationID, line.ProductID, lot); err != nil {
+				return nil, err
+			}
+		}
+		if err := receiveSerials(ctx, tx, item, po.ID, line); err != nil {
+			return nil, err
+		}
+		unitCost := ordered[line.ProductID]
+		if line.UnitCost != nil {
+			unitCost.Amount = line.UnitCost.Amount
+		}
+		err = recordMovement(ctx, tx, item, dmodel.Movement{
+			Reason:          dmodel.MovementPurchase,
+			StockDelta:      line.Quantity,
+			PurchaseOrderID: po.ID,
+			ReferenceID:     reference,
+			Actor:           actor,
+			UnitCost:        &unitCost,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items[keyOf(item)] = item
+
+		if _, err := tx.ExecContext(ctx, lineQuery, line.Quantity, po.ID, line.ProductID); err != nil {
+			return nil, err
+		}
+		receipt, err := scanReceipt(tx.QueryRowContext(ctx, receiptQuery, po.ID, po.LocationID, line.ProductID, line.Quantity, line.LotNumber, line.ExpiresAt, unitCost, unitCost.Currency, reference, actor))
+		if err != nil {
+			return nil, err
+		}
+		res.Receipts = append(res.Receipts, receipt)
+	}
+
+	// received once no line has outstanding units
+	if err := loadPurchaseOrderLines(ctx, tx, []*dmodel.PurchaseOrder{po}); err != nil {
+		return nil, err
+	}
+	state := dmodel.PurchaseOrderReceived
+	for _, line := range po.Lines {
+		if line.Outstanding() > 0 {
+			state = dmodel.PurchaseOrderPartiallyReceived
+		}
+	}
+	stateQuery := `
+		UPDATE purchase_orders SET state = $1, closed_at = CASE WHEN $1 = 'received' THEN CURRENT_TIMESTAMP END
+		WHERE id = $2
+		RETURNING ` + purchaseOrderColumns
+	res.PurchaseOrder, err = scanPurchaseOrder(tx.QueryRowContext(ctx, stateQuery, state, po.ID))
+	if err != nil {
+		return nil, err
+	}
+	res.PurchaseOrder.Lines = po.Lines
+	res.Items = sortedItems(items)
+
+	return res, tx.Commit()
+}
+
+// retrieving the receipts of a purchase order, in the order they were made
+func (dr *DataRepo_Inventory) Get_Receipts(ctx context.Context, purchaseOrderID int) ([]*dmodel.Receipt, error) {
+	query := `SELECT ` + receiptColumns + ` FROM purchase_receipts WHERE purchase_order_id = $1 ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []*dmodel.Receipt{}
+	for rows.Next() {
+		r, err := scanReceipt(rows)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, r)
+	}
+
+	return receipts, rows.Err()
+}
+
+// retrieving the units still expected from open purchase orders, per product and
+// location, of a product or of every product when productID is 0
+func (dr *DataRepo_Inventory) Get_Incoming(ctx context.Context, productID int) ([]*dmodel.IncomingStock, error) {
+	query := `
+		SELECT l.product_id, po.location_id, SUM(l.quantity - l.received_quantity), MIN(po.expected_at)
+		FROM purchase_order_lines l
+		JOIN purchase_orders po ON po.id = l.purchase_order_id
+		WHERE po.state IN ('open', 'partially_received') AND l.received_quantity < l.quantity
+			AND ($1 = 0 OR l.product_id = $1)
+		GROUP BY l.product_id, po.location_id
+		ORDER BY l.product_id, po.location_id`
+	rows, err := dr.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	incoming := []*dmodel.IncomingStock{}
+	for rows.Next() {
+		var in dmodel.IncomingStock
+		var expectedAt sql.NullTime
+		if err := rows.Scan(&in.ProductID, &in.LocationID, &in.Quantity, &expectedAt); err != nil {
+			return nil, err
+		}
+		if expectedAt.Valid {
+			in.ExpectedAt = &expectedAt.Time
+		}
+		incoming = append(incoming, &in)
+	}
+
+	return incoming, rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// lock a purchase order that can still receive goods, with its lines
+func lockPurchaseOrder(ctx context.Context, tx *sql.Tx, id int) (*dmodel.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE id = $1 FOR UPDATE`
+	po, err := scanPurchaseOrder(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if po.State != dmodel.PurchaseOrderOpen && po.State != dmodel.PurchaseOrderPartiallyReceived {
+		return nil, internal.ErrPurchaseOrderState
+	}
+
+	if err := loadPurchaseOrderLines(ctx, tx, []*dmodel.PurchaseOrder{po}); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// the error of a purchase order naming a supplier, location or product that does not exist
+func foreignKeyError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return fmt.Errorf("%w: %s", internal.ErrInvalidPurchaseOrder, pqErr.Detail)
+	}
+	return err
+}
+
+// -------------------------------------------------------------------
@@ -3,9 +3,10 @@ package inventory_repository
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	internal "inventory-service/internal"
 	dmodel "inventory-service/pkg"
+	"shared/money"
+	"time"
 )
 
 // -------------------------------------------------------------------
@@ -31,10 +32,10 @@ func New(db *sql.DB) *DataRepo_Inventory {
 // handling requests
 // -------------------------------------------------------------------
 
-// retrieving all items
-func (dr *DataRepo_Inventory) Get_All(ctx context.Context) ([]*dmodel.InventoryItem, error) {
-	query := `SELECT product_id, stock, reserved FROM inventory`
-	rows, err := dr.db.QueryContext(ctx, query)
+// retrieving all items at a location, or the totals of every product when locationID is 0
+func (dr *DataRepo_Inventory) Get_All(ctx context.Context, locationID int) ([]*dmodel.InventoryItem, error) {
+	query := `SELECT ` + itemColumns + ` FROM inventory WHERE ($1 = 0 OR location_id = $1) ORDER BY product_id, location_id`
+	rows, err := dr.db.QueryContext(ctx, query, locationID)
 	if err != nil {
 		return nil, err
 	}
@@ -42,145 +43,221 @@ func (dr *DataRepo_Inventory) Get_All(ctx context.Context) ([]*dmodel.InventoryI
 
 	var items []*dmodel.InventoryItem
 	for rows.Next() {
-		var item dmodel.InventoryItem
-		if err := rows.Scan(&item.ProductID, &item.Stock, &item.Reserved); err != nil {
+		item, err := scanItem(rows)
+		if err != nil {
 			return nil, err
 		}
-		items = append(items, &item)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if locationID != 0 {
+		return items, nil
 	}
 
-	return items, rows.Err()
+	return productTotals(items), nil
 }
 
-// retrieving item by product ID
-func (dr *DataRepo_Inventory) Get_ByProductID(ctx context.Context, productID int) (*dmodel.InventoryItem, error) {
-	query := `SELECT product_id, stock, reserved FROM inventory WHERE product_id = $1`
-	var item dmodel.InventoryItem
+// retrieving item by product ID, at a location or the totals of every location when
+// locationID is 0
+func (dr *DataRepo_Inventory) Get_ByProductID(ctx context.Context, productID, locationID int) (*dmodel.InventoryItem, error) {
+	query := `SELECT ` + itemColumns + ` FROM inventory WHERE product_id = $1 AND ($2 = 0 OR location_id = $2) ORDER BY location_id`
+	rows, err := dr.db.QueryContext(ctx, query, productID, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	err := dr.db.QueryRowContext(ctx, query, productID).Scan(&item.ProductID, &item.Stock, &item.Reserved)
-	if err == sql.ErrNoRows {
-		return nil, internal.ErrItemNotFound
+	var items []*dmodel.InventoryItem
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
 	}
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &item, nil
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+	if locationID != 0 {
+		return items[0], nil
+	}
+
+	return sumItems(productID, items), nil
 }
 
 // -------------------------------------------------------------------
 
-// update the stock property of an inventory item
-func (dr *DataRepo_Inventory) Update_Stock(ctx context.Context, productID, stock int) error {
-	query := `UPDATE inventory SET stock = $1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $2`
-	result, err := dr.db.ExecContext(ctx, query, stock, productID)
+// the stock changes below apply to the item at locationID, or to the item at the
+// product's preferred location when locationID is 0; they return the changed item, or
+// the product's totals when locationID is 0
+
+// update the stock property of an inventory item, recording the difference as a movement;
+// the stock of a serial-tracked product can only be lowered
+// the stock is absolute, so locationID may only be 0 for a product stocked at one location
+// when expectedVersion is not 0 the item (the totals when locationID is 0) must still be
+// at that version
+func (dr *DataRepo_Inventory) Update_Stock(ctx context.Context, productID, locationID, stock, expectedVersion int, reference, actor string) (*dmodel.InventoryItem, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	items, err := lockProductItems(ctx, tx, productID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rows == 0 {
-		return internal.ErrItemNotFound
+	if locationID == 0 && len(items) > 1 {
+		return nil, internal.ErrLocationRequired
 	}
-
-	return nil
-}
-
-// increase the reserved property of an item
-func (dr *DataRepo_Inventory) Reserve_Stock(ctx context.Context, productID, amount_reserved int) error {
-	tx, err := dr.db.BeginTx(ctx, nil)
+	target, err := targetItem(ctx, tx, items, productID, locationID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer tx.Rollback()
 
-	var stock, reserved int
-	query := `SELECT stock, reserved FROM inventory WHERE product_id = $1 FOR UPDATE`
-	err = tx.QueryRowContext(ctx, query, productID).Scan(&stock, &reserved)
-	if err == sql.ErrNoRows {
-		return internal.ErrItemNotFound
+	version := target.Version
+	if locationID == 0 {
+		version = sumItems(productID, items).Version
 	}
-	if err != nil {
-		return err
+	if expectedVersion != 0 && version != expectedVersion {
+		return nil, internal.ErrVersionMismatch
+	}
+	if stock < 0 || stock < target.Reserved {
+		return nil, internal.ErrStockBelowReserved
+	}
+	if err := checkUnnamedIncrease(target, stock-target.Stock); err != nil {
+		return nil, err
 	}
 
-	if (stock - reserved) < amount_reserved {
-		return internal.ErrInsufficientStock
+	updateQuery := `UPDATE inventory SET stock = $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	item, err := scanItem(tx.QueryRowContext(ctx, updateQuery, stock, target.LocationID, productID))
+	if err != nil {
+		return nil, err
 	}
 
-	updateQuery := `UPDATE inventory SET reserved = reserved + $1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $2`
-	_, err = tx.ExecContext(ctx, updateQuery, amount_reserved, productID)
+	err = recordMovement(ctx, tx, item, dmodel.Movement{
+		Reason:      dmodel.MovementManualSet,
+		StockDelta:  stock - target.Stock,
+		ReferenceID: reference,
+		Actor:       actor,
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit()
+	return itemView(items, item, locationID), tx.Commit()
 }
 
-// decrease the reserved property of an item
-func (dr *DataRepo_Inventory) Release_Reservation(ctx context.Context, productID, amount_released int) error {
+// add delta (negative to remove units) to the stock of an item under a row lock,
+// rejecting a result below zero or below the reserved quantity, and units added to a
+// serial-tracked product; added units are valued at unitCost each when it is set
+// the units are added to, or removed from, the lot named by lotNumber when it is set (a new
+// lot expiring at expiresAt)
+func (dr *DataRepo_Inventory) Adjust_Stock(ctx context.Context, productID, locationID, delta int, unitCost *money.Money, lotNumber string, expiresAt *time.Time, reason, reference, actor string) (*dmodel.InventoryItem, error) {
 	tx, err := dr.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	var reserved int
-	query := `SELECT reserved FROM inventory WHERE product_id = $1 FOR UPDATE`
-	err = tx.QueryRowContext(ctx, query, productID).Scan(&reserved)
-	if err == sql.ErrNoRows {
-		return internal.ErrItemNotFound
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
 	}
+	target, err := targetItem(ctx, tx, items, productID, locationID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if target.Stock+delta < 0 || target.Stock+delta < target.Reserved {
+		return nil, internal.ErrStockBelowReserved
+	}
+	if err := checkUnnamedIncrease(target, delta); err != nil {
+		return nil, err
+	}
+	if lotNumber != "" {
+		lot := dmodel.LotQuantity{LotNumber: lotNumber, ExpiresAt: expiresAt, Quantity: delta}
+		if delta < 0 {
+			lot.Quantity = -delta
+			_, err = takeLots(ctx, tx, target, []dmodel.LotQuantity{lot})
+		} else {
+			err = restockLots(ctx, tx, target.LocationID, productID, []dmodel.LotQuantity{lot})
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if reserved < amount_released {
-		return internal.ErrInsufficientReserved
+	updateQuery := `UPDATE inventory SET stock = stock + $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	item, err := scanItem(tx.QueryRowContext(ctx, updateQuery, delta, target.LocationID, productID))
+	if err != nil {
+		return nil, err
 	}
 
-	updateQuery := `UPDATE inventory SET reserved = reserved - $1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $2`
-	_, err = tx.ExecContext(ctx, updateQuery, amount_released, productID)
+	err = recordMovement(ctx, tx, item, dmodel.Movement{
+		Reason:      reason,
+		StockDelta:  delta,
+		ReferenceID: reference,
+		Actor:       actor,
+		UnitCost:    unitCost,
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit()
+	return itemView(items, item, locationID), tx.Commit()
 }
 
-// decrease both the reserved and quantity properties of an item
-// used to fulfill an order
-func (dr *DataRepo_Inventory) Fulfill_Reservation(ctx context.Context, productID, amount_fulfilled int) error {
+// put returned units back into stock, counting the ones that cannot be sold again as damaged
+// the units of a serial-tracked product are named, restocked (serials) and damaged
+// (damagedSerials) apart, and must have been shipped; restocked units go back to the lots
+// given, the rest of them are untracked
+func (dr *DataRepo_Inventory) Receive_Return(ctx context.Context, productID, locationID, restocked, damaged int, serials, damagedSerials []string, lots []dmodel.LotQuantity, reference, actor string) (*dmodel.InventoryItem, error) {
 	tx, err := dr.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	var reserved int
-	query := `SELECT reserved FROM inventory WHERE product_id = $1 FOR UPDATE`
-	err = tx.QueryRowContext(ctx, query, productID).Scan(&reserved)
-	if err == sql.ErrNoRows {
-		return fmt.Errorf("product not found in inventory")
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
 	}
+	target, err := targetItem(ctx, tx, items, productID, locationID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := restockLots(ctx, tx, target.LocationID, productID, lots); err != nil {
+		return nil, err
 	}
 
-	if reserved < amount_fulfilled {
-		return fmt.Errorf("cannot fulfill more items than are reserved")
+	query := `UPDATE inventory SET stock = stock + $1, damaged = damaged + $2, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $3 AND product_id = $4 RETURNING ` + itemColumns
+	item, err := scanItem(tx.QueryRowContext(ctx, query, restocked, damaged, target.LocationID, productID))
+	if err != nil {
+		return nil, err
+	}
+	if err := returnSerials(ctx, tx, item, restocked, damaged, serials, damagedSerials); err != nil {
+		return nil, err
 	}
 
-	updateQuery := `UPDATE inventory SET reserved = reserved - $1, stock = stock - $1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $2`
-	_, err = tx.ExecContext(ctx, updateQuery, amount_fulfilled, productID)
+	err = recordMovement(ctx, tx, item, dmodel.Movement{
+		Reason:       dmodel.MovementReturn,
+		StockDelta:   restocked,
+		DamagedDelta: damaged,
+		ReferenceID:  reference,
+		Actor:        actor,
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit()
+	return itemView(items, item, locationID), tx.Commit()
 }
 
 // -------------------------------------------------------------------
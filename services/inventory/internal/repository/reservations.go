@@ -0,0 +1,394 @@
+package inventory_repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+	"slices"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// reservations
+// -------------------------------------------------------------------
+
+// every change to a reservation updates inventory.reserved and appends a movement in the
+// same transaction, so the reserved quantity of an item is always the sum of its active
+// reservations
+// locks are taken reservations first (by id), then inventory rows (by product_id, then
+// location_id)
+
+const reservationColumns = `id, location_id, product_id, quantity, fulfilled_quantity, released_quantity, owner, state, created_at, expires_at,
+	cost_of_goods_sold, currency`
+
+// expired counts the unreserved units of the item's expired lots (as of the transaction's start)
+const itemColumns = `location_id, product_id, stock, reserved, damaged, in_transit, reorder_point, reorder_quantity, version,
+	(SELECT COALESCE(SUM(l.stock - l.reserved), 0) FROM inventory_lots l
+	 WHERE l.location_id = inventory.location_id AND l.product_id = inventory.product_id AND l.expires_at <= CURRENT_TIMESTAMP) AS expired,
+	EXISTS (SELECT 1 FROM serial_tracked_products s WHERE s.product_id = inventory.product_id) AS serial_tracked`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReservation(row scanner) (*dmodel.Reservation, error) {
+	var r dmodel.Reservation
+	var expiresAt sql.NullTime
+	err := row.Scan(&r.ID, &r.LocationID, &r.ProductID, &r.Quantity, &r.FulfilledQuantity, &r.ReleasedQuantity, &r.Owner, &r.State, &r.CreatedAt, &expiresAt,
+		&r.CostOfGoodsSold, &r.CostOfGoodsSold.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		r.ExpiresAt = &expiresAt.Time
+	}
+
+	return &r, nil
+}
+
+func scanItem(row scanner) (*dmodel.InventoryItem, error) {
+	var item dmodel.InventoryItem
+	if err := row.Scan(&item.LocationID, &item.ProductID, &item.Stock, &item.Reserved, &item.Damaged, &item.InTransit, &item.ReorderPoint, &item.ReorderQuantity, &item.Version, &item.Expired, &item.SerialTracked); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// -------------------------------------------------------------------
+
+func (dr *DataRepo_Inventory) Get_Reservation(ctx context.Context, id int) (*dmodel.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1`
+	r, err := scanReservation(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// retrieving the active reservations of a product and/or an owner (any when 0 or empty)
+func (dr *DataRepo_Inventory) Get_ActiveReservations(ctx context.Context, productID int, owner string) ([]*dmodel.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + ` FROM reservations
+		WHERE state = 'active' AND ($1 = 0 OR product_id = $1) AND ($2 = '' OR owner = $2)
+		ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, productID, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []*dmodel.Reservation{}
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, r)
+	}
+
+	return reservations, rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// hold quantity units of a product for owner, until expiresAt if set, at the location
+// picked by alloc
+func (dr *DataRepo_Inventory) Reserve_Stock(ctx context.Context, productID, quantity int, owner string, expiresAt *time.Time, alloc dmodel.Allocation, actor string) (*dmodel.ReservationUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	item, err := allocate(items, locations, alloc, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := reserve(ctx, tx, item.LocationID, productID, quantity, owner, expiresAt, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, tx.Commit()
+}
+
+// the locked item where quantity units are reserved: the one at the location named by
+// alloc, or the one picked by its policy
+func allocate(items []*dmodel.InventoryItem, locations map[int]*dmodel.Location, alloc dmodel.Allocation, quantity int) (*dmodel.InventoryItem, error) {
+	if alloc.LocationID != 0 {
+		if _, ok := locations[alloc.LocationID]; !ok {
+			return nil, internal.ErrLocationNotFound
+		}
+		for _, item := range items {
+			if item.LocationID == alloc.LocationID && item.Available() >= quantity {
+				return item, nil
+			}
+		}
+		return nil, internal.ErrInsufficientStock
+	}
+
+	item := alloc.Pick(items, locations, quantity)
+	if item == nil {
+		return nil, internal.ErrInsufficientStock
+	}
+
+	return item, nil
+}
+
+// insert a reservation and add its quantity to the item's reserved stock
+// the item must be locked by the caller
+func reserve(ctx context.Context, tx *sql.Tx, locationID, productID, quantity int, owner string, expiresAt *time.Time, actor string) (*dmodel.ReservationUpdate, error) {
+	currency, err := costCurrency(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO reservations (location_id, product_id, quantity, owner, expires_at, currency) VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + reservationColumns
+	r, err := scanReservation(tx.QueryRowContext(ctx, query, locationID, productID, quantity, owner, expiresAt, currency))
+	if err != nil {
+		return nil, err
+	}
+	if err := allocateLots(ctx, tx, r); err != nil {
+		return nil, err
+	}
+
+	updateQuery := `UPDATE inventory SET reserved = reserved + $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	item, err := scanItem(tx.QueryRowContext(ctx, updateQuery, quantity, locationID, productID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordMovement(ctx, tx, item, dmodel.Movement{
+		Reason:        dmodel.MovementReserve,
+		ReservedDelta: quantity,
+		ReservationID: r.ID,
+		ReferenceID:   owner,
+		Actor:         actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dmodel.ReservationUpdate{Item: item, Reservation: r}, nil
+}
+
+// remove quantity units (every remaining unit when 0) from a reservation and from the
+// reserved stock of its item, and from the item's stock as well
+// serials names the shipped units of a serial-tracked product, unless they were picked
+// for the reservation
+func (dr *DataRepo_Inventory) Fulfill_Reservation(ctx context.Context, reservationID, quantity int, serials []string, actor string) (*dmodel.ReservationUpdate, error) {
+	return dr.settleReservation(ctx, reservationID, quantity, true, serials, actor)
+}
+
+// remove quantity units (every remaining unit when 0) from a reservation and from the
+// reserved stock of its item, making them available again
+func (dr *DataRepo_Inventory) Release_Reservation(ctx context.Context, reservationID, quantity int, actor string) (*dmodel.ReservationUpdate, error) {
+	return dr.settleReservation(ctx, reservationID, quantity, false, nil, actor)
+}
+
+func (dr *DataRepo_Inventory) settleReservation(ctx context.Context, reservationID, quantity int, fulfill bool, serials []string, actor string) (*dmodel.ReservationUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1 FOR UPDATE`
+	r, err := scanReservation(tx.QueryRowContext(ctx, query, reservationID))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err = checkSettlement(r, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := settle(ctx, tx, r, quantity, fulfill, serials, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, tx.Commit()
+}
+
+// the quantity to settle from a reservation (every remaining unit when 0)
+func checkSettlement(r *dmodel.Reservation, quantity int) (int, error) {
+	if r.State != dmodel.ReservationActive {
+		return 0, internal.ErrReservationNotActive
+	}
+	if quantity == 0 {
+		quantity = r.Remaining()
+	}
+	if quantity > r.Remaining() {
+		return 0, internal.ErrInsufficientReserved
+	}
+
+	return quantity, nil
+}
+
+// fulfill or release quantity units of a locked reservation, closing it once nothing
+// remains, and update its item, lots and serialized units accordingly; the cost of the
+// units a fulfillment ships is added to the reservation's cost of goods sold
+func settle(ctx context.Context, tx *sql.Tx, r *dmodel.Reservation, quantity int, fulfill bool, serials []string, actor string) (*dmodel.ReservationUpdate, error) {
+	lots, err := settleLots(ctx, tx, r, quantity, fulfill)
+	if err != nil {
+		return nil, err
+	}
+
+	reservationQuery := `
+		UPDATE reservations SET released_quantity = released_quantity + $1,
+			state = CASE WHEN quantity = fulfilled_quantity + released_quantity + $1 THEN 'released' ELSE state END,
+			cost_of_goods_sold = cost_of_goods_sold + $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 RETURNING ` + reservationColumns
+	itemQuery := `UPDATE inventory SET reserved = reserved - $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	if fulfill {
+		reservationQuery = `
+			UPDATE reservations SET fulfilled_quantity = fulfilled_quantity + $1,
+				state = CASE WHEN quantity = fulfilled_quantity + released_quantity + $1 THEN 'fulfilled' ELSE state END,
+				cost_of_goods_sold = cost_of_goods_sold + $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 RETURNING ` + reservationColumns
+		itemQuery = `UPDATE inventory SET reserved = reserved - $1, stock = stock - $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	}
+
+	item, err := scanItem(tx.QueryRowContext(ctx, itemQuery, quantity, r.LocationID, r.ProductID))
+	if err != nil {
+		return nil, err
+	}
+	shipped, err := settleSerials(ctx, tx, item, r, quantity, fulfill, serials)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := dmodel.Movement{
+		Reason:        dmodel.MovementRelease,
+		ReservedDelta: -quantity,
+		ReservationID: r.ID,
+		ReferenceID:   r.Owner,
+		Actor:         actor,
+	}
+	if fulfill {
+		movement.Reason = dmodel.MovementFulfill
+		movement.StockDelta = -quantity
+	}
+	value, err := recordValuedMovement(ctx, tx, item, movement)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err = scanReservation(tx.QueryRowContext(ctx, reservationQuery, quantity, r.ID, money.New(-value.Amount, "")))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dmodel.ReservationUpdate{Item: item, Reservation: r, Lots: lots, Serials: shipped}
+	if fulfill {
+		cost := money.New(-value.Amount, r.CostOfGoodsSold.Currency)
+		res.CostOfGoodsSold = &cost
+	}
+
+	return res, nil
+}
+
+// -------------------------------------------------------------------
+
+// release what is left of active reservations that expired before now, returning how
+// many reservations expired
+// rows locked by another replica are skipped, so every reservation expires exactly once
+func (dr *DataRepo_Inventory) Expire_Reservations(ctx context.Context, now time.Time, limit int, actor string) (int, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT ` + reservationColumns + ` FROM reservations
+		WHERE state = 'active' AND expires_at < $1
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+	rows, err := tx.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []*dmodel.Reservation
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		expired = append(expired, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	// items are updated in (product_id, location_id) order, one movement per reservation
+	slices.SortFunc(expired, func(a, b *dmodel.Reservation) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LocationID, b.LocationID), cmp.Compare(a.ID, b.ID))
+	})
+	for _, r := range expired {
+		if _, err := settleLots(ctx, tx, r, r.Remaining(), false); err != nil {
+			return 0, err
+		}
+		itemQuery := `UPDATE inventory SET reserved = reserved - $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+		item, err := scanItem(tx.QueryRowContext(ctx, itemQuery, r.Remaining(), r.LocationID, r.ProductID))
+		if err != nil {
+			return 0, err
+		}
+		if _, err := settleSerials(ctx, tx, item, r, r.Remaining(), false, nil); err != nil {
+			return 0, err
+		}
+
+		reservationQuery := `UPDATE reservations SET released_quantity = quantity - fulfilled_quantity, state = 'expired', updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, reservationQuery, r.ID); err != nil {
+			return 0, err
+		}
+
+		err = recordMovement(ctx, tx, item, dmodel.Movement{
+			Reason:        dmodel.MovementExpire,
+			ReservedDelta: -r.Remaining(),
+			ReservationID: r.ID,
+			ReferenceID:   r.Owner,
+			Actor:         actor,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(expired), tx.Commit()
+}
+
+// -------------------------------------------------------------------
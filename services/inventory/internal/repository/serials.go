@@ -0,0 +1,466 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// serial numbers
+// -------------------------------------------------------------------
+
+// every unit of a serial-tracked product is registered by serial number when it is
+// received for a purchase order; a unit is picked for a reservation (reserved), or named
+// when the reservation is fulfilled, and then shipped, and may come back with a return
+// a unit is only picked or shipped at the location it is at, and moves to another one with
+// a transfer
+// serialized units change after the item of their product, under its row lock, and are
+// locked in id order
+
+const serialColumns = `id, product_id, serial_number, location_id, state, COALESCE(reservation_id, 0), COALESCE(purchase_order_id, 0), created_at, updated_at`
+
+func scanSerial(row scanner) (*dmodel.SerialUnit, error) {
+	var u dmodel.SerialUnit
+	err := row.Scan(&u.ID, &u.ProductID, &u.SerialNumber, &u.LocationID, &u.State, &u.ReservationID, &u.PurchaseOrderID, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// retrieving the serialized units matching filter, by product and serial number
+func (dr *DataRepo_Inventory) Get_Serials(ctx context.Context, filter dmodel.SerialFilter) ([]*dmodel.SerialUnit, error) {
+	query := `
+		SELECT ` + serialColumns + ` FROM inventory_serials
+		WHERE ($1 = 0 OR product_id = $1) AND ($2 = '' OR serial_number = $2) AND ($3 = '' OR state = $3)
+			AND ($4 = 0 OR location_id = $4) AND ($5 = 0 OR reservation_id = $5)
+		ORDER BY product_id, serial_number`
+	rows, err := dr.db.QueryContext(ctx, query, filter.ProductID, filter.SerialNumber, filter.State, filter.LocationID, filter.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	units := []*dmodel.SerialUnit{}
+	for rows.Next() {
+		u, err := scanSerial(rows)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+
+	return units, rows.Err()
+}
+
+// turn serial tracking of a product on or off, returning the product's totals; it can only
+// be turned on while the product holds no stock, so that every unit it will hold is
+// registered when received
+func (dr *DataRepo_Inventory) Set_SerialTracking(ctx context.Context, productID int, enabled bool) (*dmodel.InventoryItem, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	items, err := lockProductItems(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, internal.ErrItemNotFound
+	}
+
+	query := `DELETE FROM serial_tracked_products WHERE product_id = $1`
+	if enabled {
+		for _, item := range items {
+			if item.Stock > 0 || item.InTransit > 0 {
+				return nil, fmt.Errorf("%w: product %d has %d units at location %d", internal.ErrSerialTrackingUsed, productID, item.Stock+item.InTransit, item.LocationID)
+			}
+		}
+		query = `INSERT INTO serial_tracked_products (product_id) VALUES ($1) ON CONFLICT (product_id) DO NOTHING`
+	}
+	if _, err := tx.ExecContext(ctx, query, productID); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.SerialTracked = enabled
+	}
+
+	return sumItems(productID, items), tx.Commit()
+}
+
+// pick serialized units for a reservation, so that fulfilling it ships them without
+// naming them again; a reservation holds at most as many units as it has remaining
+func (dr *DataRepo_Inventory) Reserve_Serials(ctx context.Context, reservationID int, serials []string) ([]*dmodel.SerialUnit, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1 FOR UPDATE`
+	r, err := scanReservation(tx.QueryRowContext(ctx, query, reservationID))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.State != dmodel.ReservationActive {
+		return nil, internal.ErrReservationNotActive
+	}
+
+	itemQuery := `SELECT ` + itemColumns + ` FROM inventory WHERE location_id = $1 AND product_id = $2 FOR UPDATE`
+	item, err := scanItem(tx.QueryRowContext(ctx, itemQuery, r.LocationID, r.ProductID))
+	if err != nil {
+		return nil, err
+	}
+	if !item.SerialTracked {
+		return nil, fmt.Errorf("%w: product %d is not serial-tracked", internal.ErrInvalidSerial, r.ProductID)
+	}
+
+	held, err := reservedSerials(ctx, tx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(held)+len(serials) > r.Remaining() {
+		return nil, fmt.Errorf("%w: reservation %d has %d units remaining, %d are picked already", internal.ErrInvalidSerial, r.ID, r.Remaining(), len(held))
+	}
+
+	units, err := lockSerials(ctx, tx, r.ProductID, serials)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range units {
+		if err := availableAt(u, r.LocationID); err != nil {
+			return nil, err
+		}
+	}
+
+	updateQuery := `
+		UPDATE inventory_serials SET state = 'reserved', reservation_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($2)
+		RETURNING ` + serialColumns
+	rows, err := tx.QueryContext(ctx, updateQuery, r.ID, pq.Array(serialIDs(units)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	picked := []*dmodel.SerialUnit{}
+	for rows.Next() {
+		u, err := scanSerial(rows)
+		if err != nil {
+			return nil, err
+		}
+		picked = append(picked, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return picked, tx.Commit()
+}
+
+// -------------------------------------------------------------------
+
+// register the serialized units of a receipt line at a location, or reject serial numbers
+// for a product that is not serial-tracked; item is the line's item after the receipt
+// every unit of a serial-tracked product must be named, once
+func receiveSerials(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, purchaseOrderID int, line dmodel.ReceiptLine) error {
+	if err := checkSerials(item, line.Quantity, line.SerialNumbers); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO inventory_serials (product_id, serial_number, location_id, purchase_order_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (product_id, serial_number) DO NOTHING`
+	for _, serial := range line.SerialNumbers {
+		result, err := tx.ExecContext(ctx, query, line.ProductID, serial, item.LocationID, purchaseOrderID)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("%w: unit %s of product %d", internal.ErrSerialExists, serial, line.ProductID)
+		}
+	}
+
+	return nil
+}
+
+// ship the serialized units of quantity fulfilled units of a reservation (its state before
+// the change): the units named, or else the ones picked for it; a release only returns the
+// picked units it no longer has room for
+// item is the reservation's item after the change
+// returns the units a fulfillment shipped
+func settleSerials(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, r *dmodel.Reservation, quantity int, fulfill bool, serials []string) ([]dmodel.SerialConsumption, error) {
+	if !fulfill && len(serials) > 0 {
+		return nil, fmt.Errorf("%w: released units are not named", internal.ErrInvalidSerial)
+	}
+	if !item.SerialTracked {
+		if len(serials) > 0 {
+			return nil, fmt.Errorf("%w: product %d is not serial-tracked", internal.ErrInvalidSerial, r.ProductID)
+		}
+		return nil, nil
+	}
+
+	held, err := reservedSerials(ctx, tx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var shipped []*dmodel.SerialUnit
+	if fulfill {
+		switch {
+		case len(serials) > 0:
+			if err := checkSerials(item, quantity, serials); err != nil {
+				return nil, err
+			}
+			if shipped, err = lockSerials(ctx, tx, r.ProductID, serials); err != nil {
+				return nil, err
+			}
+			for _, u := range shipped {
+				if u.State == dmodel.SerialReserved && u.ReservationID == r.ID {
+					continue
+				}
+				if err := availableAt(u, r.LocationID); err != nil {
+					return nil, err
+				}
+			}
+		case len(held) >= quantity:
+			shipped = held[:quantity]
+		default:
+			return nil, fmt.Errorf("%w: the serial numbers of the %d units of product %d are required", internal.ErrInvalidSerial, quantity, r.ProductID)
+		}
+
+		query := `UPDATE inventory_serials SET state = 'shipped', reservation_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($2)`
+		if _, err := tx.ExecContext(ctx, query, r.ID, pq.Array(serialIDs(shipped))); err != nil {
+			return nil, err
+		}
+	}
+
+	// the picked units that were not shipped and no longer fit what remains of the
+	// reservation become available again, the last picked first
+	var still []*dmodel.SerialUnit
+	for _, u := range held {
+		if !containsSerial(shipped, u.ID) {
+			still = append(still, u)
+		}
+	}
+	if excess := len(still) - (r.Remaining() - quantity); excess > 0 {
+		freed := still[len(still)-excess:]
+		query := `UPDATE inventory_serials SET state = 'available', reservation_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`
+		if _, err := tx.ExecContext(ctx, query, pq.Array(serialIDs(freed))); err != nil {
+			return nil, err
+		}
+	}
+
+	var consumed []dmodel.SerialConsumption
+	for _, u := range shipped {
+		consumed = append(consumed, dmodel.SerialConsumption{ReservationID: r.ID, SerialNumber: u.SerialNumber})
+	}
+
+	return consumed, nil
+}
+
+// take back the shipped serialized units of a customer return at the item's location;
+// item is the return's item after the change
+// a serial-tracked product names every unit that came back, once: the restocked ones
+// (serials) become returned, and can be shipped again, the damaged ones become damaged
+func returnSerials(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, restocked, damaged int, serials, damagedSerials []string) error {
+	if err := checkSerials(item, restocked, serials); err != nil {
+		return err
+	}
+	if err := checkSerials(item, damaged, damagedSerials); err != nil {
+		return err
+	}
+	if !item.SerialTracked {
+		return nil
+	}
+
+	returned, err := lockSerials(ctx, tx, item.ProductID, append(slices.Clip(serials), damagedSerials...))
+	if err != nil {
+		return err
+	}
+	for _, u := range returned {
+		if u.State != dmodel.SerialShipped {
+			return fmt.Errorf("%w: unit %s of product %d is %s, not shipped", internal.ErrSerialUnavailable, u.SerialNumber, u.ProductID, u.State)
+		}
+	}
+
+	query := `UPDATE inventory_serials SET state = $1, reservation_id = NULL, location_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($3)`
+	if _, err := tx.ExecContext(ctx, query, dmodel.SerialReturned, item.LocationID, pq.Array(serialIDs(returned[:len(serials)]))); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, dmodel.SerialDamaged, item.LocationID, pq.Array(serialIDs(returned[len(serials):])))
+	return err
+}
+
+// send the serialized units of a transfer line, which must be available at the source
+// item's location, in transit to the destination; source is the line's item before the
+// change
+func shipSerials(ctx context.Context, tx *sql.Tx, source *dmodel.InventoryItem, line dmodel.TransferLine, destinationID int) error {
+	if err := checkSerials(source, line.Quantity, line.SerialNumbers); err != nil {
+		return err
+	}
+	if !source.SerialTracked {
+		return nil
+	}
+
+	units, err := lockSerials(ctx, tx, line.ProductID, line.SerialNumbers)
+	if err != nil {
+		return err
+	}
+	for _, u := range units {
+		if err := availableAt(u, source.LocationID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE inventory_serials SET state = 'in_transit', location_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($2)`
+	_, err = tx.ExecContext(ctx, query, destinationID, pq.Array(serialIDs(units)))
+	return err
+}
+
+// make the serialized units of a received transfer line available at its destination;
+// item is the line's item at the destination
+func arriveSerials(ctx context.Context, tx *sql.Tx, item *dmodel.InventoryItem, line dmodel.TransferLine) error {
+	if len(line.SerialNumbers) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE inventory_serials SET state = 'available', location_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = $2 AND serial_number = ANY($3) AND state = 'in_transit'`
+	_, err := tx.ExecContext(ctx, query, item.LocationID, line.ProductID, pq.Array(line.SerialNumbers))
+	return err
+}
+
+// whether a unit can be picked or shipped at a location
+func availableAt(u *dmodel.SerialUnit, locationID int) error {
+	if !u.IsAvailable() {
+		return fmt.Errorf("%w: unit %s of product %d is %s", internal.ErrSerialUnavailable, u.SerialNumber, u.ProductID, u.State)
+	}
+	if u.LocationID != locationID {
+		return fmt.Errorf("%w: unit %s of product %d is at location %d, not %d", internal.ErrSerialUnavailable, u.SerialNumber, u.ProductID, u.LocationID, locationID)
+	}
+
+	return nil
+}
+
+// reject adding delta units to the stock of a serial-tracked item without naming them;
+// its units are only added by receipts, returns and transfers, with their serial numbers
+func checkUnnamedIncrease(item *dmodel.InventoryItem, delta int) error {
+	if item.SerialTracked && delta > 0 {
+		return fmt.Errorf("%w: %d units of product %d", internal.ErrSerialUnnamed, delta, item.ProductID)
+	}
+
+	return nil
+}
+
+// the serial numbers of a change to quantity units of a product: one per unit for a
+// serial-tracked product, none otherwise
+func checkSerials(item *dmodel.InventoryItem, quantity int, serials []string) error {
+	if !item.SerialTracked {
+		if len(serials) > 0 {
+			return fmt.Errorf("%w: product %d is not serial-tracked", internal.ErrInvalidSerial, item.ProductID)
+		}
+		return nil
+	}
+	if len(serials) != quantity {
+		return fmt.Errorf("%w: %d serial numbers for %d units of product %d", internal.ErrInvalidSerial, len(serials), quantity, item.ProductID)
+	}
+
+	return nil
+}
+
+// lock the units of a product with the given serial numbers, failing when one is unknown
+func lockSerials(ctx context.Context, tx *sql.Tx, productID int, serials []string) ([]*dmodel.SerialUnit, error) {
+	query := `SELECT ` + serialColumns + ` FROM inventory_serials WHERE product_id = $1 AND serial_number = ANY($2) ORDER BY id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, productID, pq.Array(serials))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]*dmodel.SerialUnit, len(serials))
+	for rows.Next() {
+		u, err := scanSerial(rows)
+		if err != nil {
+			return nil, err
+		}
+		found[u.SerialNumber] = u
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	units := make([]*dmodel.SerialUnit, len(serials))
+	for i, serial := range serials {
+		u, ok := found[serial]
+		if !ok {
+			return nil, fmt.Errorf("%w: unit %s of product %d", internal.ErrSerialNotFound, serial, productID)
+		}
+		units[i] = u
+	}
+
+	return units, nil
+}
+
+// the units picked for a reservation, in the order they were picked
+func reservedSerials(ctx context.Context, tx *sql.Tx, reservationID int) ([]*dmodel.SerialUnit, error) {
+	query := `SELECT ` + serialColumns + ` FROM inventory_serials WHERE reservation_id = $1 AND state = 'reserved' ORDER BY updated_at, id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []*dmodel.SerialUnit
+	for rows.Next() {
+		u, err := scanSerial(rows)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+
+	return units, rows.Err()
+}
+
+func serialIDs(units []*dmodel.SerialUnit) []int64 {
+	ids := make([]int64, len(units))
+	for i, u := range units {
+		ids[i] = int64(u.ID)
+	}
+	return ids
+}
+
+func containsSerial(units []*dmodel.SerialUnit, id int) bool {
+	for _, u := range units {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// whether err rejects a line of a batch because of its serial numbers
+func isSerialError(err error) bool {
+	return errors.Is(err, internal.ErrInvalidSerial) || errors.Is(err, internal.ErrSerialNotFound) || errors.Is(err, internal.ErrSerialUnavailable)
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,549 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// stocktakes
+// -------------------------------------------------------------------
+
+// opening a stocktake snapshots the stock of its items under their row locks, and counting
+// a line snapshots it again, so that its variance is against the stock when it was
+// counted; reviews only touch the stocktake, and posting it adds the approved variances to
+// the stock as it is then, every line or none of them, in a single transaction
+// locks are taken stocktake first, then inventory rows (by product_id, then location_id)
+
+const stocktakeColumns = `id, location_id, state, COALESCE(reference, ''), created_by, created_at, closed_at`
+
+func scanStocktake(row scanner) (*dmodel.Stocktake, error) {
+	var s dmodel.Stocktake
+	var closedAt sql.NullTime
+	err := row.Scan(&s.ID, &s.LocationID, &s.State, &s.Reference, &s.CreatedBy, &s.CreatedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+	if closedAt.Valid {
+		s.ClosedAt = &closedAt.Time
+	}
+
+	return &s, nil
+}
+
+// fill in the lines of the given stocktakes, in product_id order, with their counts in
+// counter order
+func loadStocktakeLines(ctx context.Context, q queryer, stocktakes []*dmodel.Stocktake) error {
+	if len(stocktakes) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(stocktakes))
+	byID := make(map[int]*dmodel.Stocktake, len(stocktakes))
+	for i, s := range stocktakes {
+		ids[i] = int64(s.ID)
+		byID[s.ID] = s
+		s.Lines = []dmodel.StocktakeLine{}
+	}
+
+	query := `SELECT stocktake_id, product_id, expected, state FROM stocktake_lines WHERE stocktake_id = ANY($1) ORDER BY stocktake_id, product_id`
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stocktakeID int
+		line := dmodel.StocktakeLine{Counts: []dmodel.StocktakeCount{}}
+		if err := rows.Scan(&stocktakeID, &line.ProductID, &line.Expected, &line.State); err != nil {
+			return err
+		}
+		byID[stocktakeID].Lines = append(byID[stocktakeID].Lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	countQuery := `
+		SELECT stocktake_id, product_id, counter, quantity, counted_at FROM stocktake_counts
+		WHERE stocktake_id = ANY($1)
+		ORDER BY stocktake_id, product_id, counter`
+	countRows, err := q.QueryContext(ctx, countQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer countRows.Close()
+
+	for countRows.Next() {
+		var stocktakeID, productID int
+		var count dmodel.StocktakeCount
+		if err := countRows.Scan(&stocktakeID, &productID, &count.Counter, &count.Quantity, &count.CountedAt); err != nil {
+			return err
+		}
+		if line := findStocktakeLine(byID[stocktakeID], productID); line != nil {
+			line.Counts = append(line.Counts, count)
+			line.Counted += count.Quantity
+		}
+	}
+
+	return countRows.Err()
+}
+
+// -------------------------------------------------------------------
+
+func (dr *DataRepo_Inventory) Get_Stocktake(ctx context.Context, id int) (*dmodel.Stocktake, error) {
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes WHERE id = $1`
+	s, err := scanStocktake(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrStocktakeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadStocktakeLines(ctx, dr.db, []*dmodel.Stocktake{s}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// retrieving the stocktakes in a state and/or at a location (any when empty or 0)
+func (dr *DataRepo_Inventory) Get_Stocktakes(ctx context.Context, state string, locationID int) ([]*dmodel.Stocktake, error) {
+	query := `
+		SELECT ` + stocktakeColumns + ` FROM stocktakes
+		WHERE ($1 = '' OR state = $1) AND ($2 = 0 OR location_id = $2)
+		ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, state, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocktakes := []*dmodel.Stocktake{}
+	for rows.Next() {
+		s, err := scanStocktake(rows)
+		if err != nil {
+			return nil, err
+		}
+		stocktakes = append(stocktakes, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadStocktakeLines(ctx, dr.db, stocktakes); err != nil {
+		return nil, err
+	}
+
+	return stocktakes, nil
+}
+
+// open a stocktake of the given products at its location (every product held there when
+// none are given), snapshotting their stock as the expected quantities; the location must
+// hold each product, and no other open stocktake there may count it
+func (dr *DataRepo_Inventory) Create_Stocktake(ctx context.Context, stocktake *dmodel.Stocktake, productIDs []int) (*dmodel.Stocktake, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := locations[stocktake.LocationID]; !ok {
+		return nil, fmt.Errorf("%w: location %d", internal.ErrLocationNotFound, stocktake.LocationID)
+	}
+
+	if len(productIDs) == 0 {
+		productIDs, err = heldProducts(ctx, tx, stocktake.LocationID)
+		if err != nil {
+			return nil, err
+		}
+		if len(productIDs) == 0 {
+			return nil, fmt.Errorf("%w: location %d holds no products", internal.ErrInvalidStocktake, stocktake.LocationID)
+		}
+	}
+
+	// holding the item locks keeps the snapshot consistent and serializes stocktakes
+	// opened concurrently for the same products
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*dmodel.InventoryItem, len(productIDs))
+	for i, productID := range productIDs {
+		items[i] = findItem(locked[productID], stocktake.LocationID)
+		if items[i] == nil {
+			return nil, fmt.Errorf("%w: product %d at location %d", internal.ErrItemNotFound, productID, stocktake.LocationID)
+		}
+	}
+
+	overlapQuery := `
+		SELECT l.product_id FROM stocktake_lines l
+		JOIN stocktakes s ON s.id = l.stocktake_id
+		WHERE s.state = 'open' AND s.location_id = $1 AND l.product_id = ANY($2)
+		ORDER BY l.product_id
+		LIMIT 1`
+	var overlapping int
+	err = tx.QueryRowContext(ctx, overlapQuery, stocktake.LocationID, pq.Array(productIDArray(productIDs))).Scan(&overlapping)
+	if err == nil {
+		return nil, fmt.Errorf("%w: product %d", internal.ErrStocktakeOverlap, overlapping)
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	query := `INSERT INTO stocktakes (location_id, reference, created_by) VALUES ($1, NULLIF($2, ''), $3) RETURNING ` + stocktakeColumns
+	created, err := scanStocktake(tx.QueryRowContext(ctx, query, stocktake.LocationID, stocktake.Reference, stocktake.CreatedBy))
+	if err != nil {
+		return nil, err
+	}
+
+	lineQuery := `INSERT INTO stocktake_lines (stocktake_id, product_id, expected) VALUES ($1, $2, $3)`
+	created.Lines = make([]dmodel.StocktakeLine, len(items))
+	for i, item := range items {
+		if _, err := tx.ExecContext(ctx, lineQuery, created.ID, item.ProductID, item.Stock); err != nil {
+			return nil, err
+		}
+		created.Lines[i] = dmodel.StocktakeLine{
+			ProductID: item.ProductID,
+			Expected:  item.Stock,
+			State:     dmodel.StocktakeLinePending,
+			Counts:    []dmodel.StocktakeCount{},
+		}
+	}
+
+	return created, tx.Commit()
+}
+
+// record the units of products counted by a counter, replacing the counter's earlier count
+// of each product, and expect the stock the products have now; a counted line goes back to
+// review even if it was approved or rejected
+func (dr *DataRepo_Inventory) Count_Stocktake(ctx context.Context, id int, counter string, lines []dmodel.StocktakeCountLine) (*dmodel.Stocktake, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := lockStocktake(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]int, len(lines))
+	for i, line := range lines {
+		if findStocktakeLine(stocktake, line.ProductID) == nil {
+			return nil, fmt.Errorf("%w: product %d is not counted by the stocktake", internal.ErrInvalidStocktake, line.ProductID)
+		}
+		productIDs[i] = line.ProductID
+	}
+	// the stock moves while the count runs: the item locks keep it still while the
+	// expected quantities are taken
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	countQuery := `
+		INSERT INTO stocktake_counts (stocktake_id, product_id, counter, quantity)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (stocktake_id, product_id, counter) DO UPDATE
+			SET quantity = EXCLUDED.quantity, counted_at = CURRENT_TIMESTAMP`
+	lineQuery := `UPDATE stocktake_lines SET state = 'counted', expected = $3 WHERE stocktake_id = $1 AND product_id = $2`
+	for _, line := range lines {
+		item := findItem(locked[line.ProductID], stocktake.LocationID)
+		if item == nil {
+			return nil, fmt.Errorf("%w: product %d at location %d", internal.ErrItemNotFound, line.ProductID, stocktake.LocationID)
+		}
+		if _, err := tx.ExecContext(ctx, countQuery, id, line.ProductID, counter, line.Quantity); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, lineQuery, id, line.ProductID, item.Stock); err != nil {
+			return nil, err
+		}
+	}
+
+	return reloadStocktake(ctx, tx, stocktake)
+}
+
+// approve or reject the variances of counted lines; only approved variances are posted
+func (dr *DataRepo_Inventory) Review_Stocktake(ctx context.Context, id int, approve, reject []int) (*dmodel.Stocktake, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := lockStocktake(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, productID := range slices.Concat(approve, reject) {
+		line := findStocktakeLine(stocktake, productID)
+		if line == nil {
+			return nil, fmt.Errorf("%w: product %d is not counted by the stocktake", internal.ErrInvalidStocktake, productID)
+		}
+		if line.State == dmodel.StocktakeLinePending {
+			return nil, fmt.Errorf("%w: product %d has not been counted yet", internal.ErrInvalidStocktake, productID)
+		}
+	}
+
+	query := `UPDATE stocktake_lines SET state = $3 WHERE stocktake_id = $1 AND product_id = ANY($2)`
+	if _, err := tx.ExecContext(ctx, query, id, pq.Array(productIDArray(approve)), dmodel.StocktakeLineApproved); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, query, id, pq.Array(productIDArray(reject)), dmodel.StocktakeLineRejected); err != nil {
+		return nil, err
+	}
+
+	return reloadStocktake(ctx, tx, stocktake)
+}
+
+// add the variance of every approved line to its item's stock, recorded as cycle count
+// movements; every counted line must have been reviewed, and a line whose variance would
+// take the stock below zero or below the reserved quantity, or add units to a serial-tracked
+// product, fails the stocktake
+func (dr *DataRepo_Inventory) Post_Stocktake(ctx context.Context, id int, actor string) (*dmodel.StocktakeUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := lockStocktake(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var approved []dmodel.StocktakeLine
+	for _, line := range stocktake.Lines {
+		switch line.State {
+		case dmodel.StocktakeLineCounted:
+			return nil, fmt.Errorf("%w: product %d is counted but not reviewed", internal.ErrInvalidStocktake, line.ProductID)
+		case dmodel.StocktakeLineApproved:
+			if line.Variance() != 0 {
+				approved = append(approved, line)
+			}
+		}
+	}
+
+	productIDs := make([]int, len(approved))
+	for i, line := range approved {
+		productIDs[i] = line.ProductID
+	}
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []dmodel.LineFailure
+	for _, line := range approved {
+		item := findItem(locked[line.ProductID], stocktake.LocationID)
+		switch {
+		case item == nil:
+			failures = append(failures, stocktakeFailure(stocktake.LocationID, line, internal.ErrItemNotFound))
+		case item.Stock+line.Variance() < 0 || item.Stock+line.Variance() < item.Reserved:
+			failures = append(failures, stocktakeFailure(stocktake.LocationID, line, internal.ErrStockBelowReserved))
+		default:
+			if err := checkUnnamedIncrease(item, line.Variance()); err != nil {
+				failures = append(failures, stocktakeFailure(stocktake.LocationID, line, err))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return nil, &internal.BatchError{Failures: failures}
+	}
+
+	query := `UPDATE inventory SET stock = stock + $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+
+	items := make(map[itemKey]*dmodel.InventoryItem, len(approved))
+	for _, line := range approved {
+		item, err := scanItem(tx.QueryRowContext(ctx, query, line.Variance(), stocktake.LocationID, line.ProductID))
+		if err != nil {
+			return nil, err
+		}
+		err = recordMovement(ctx, tx, item, dmodel.Movement{
+			Reason:      dmodel.MovementCycleCount,
+			StockDelta:  line.Variance(),
+			StocktakeID: stocktake.ID,
+			ReferenceID: stocktake.Reference,
+			Actor:       actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items[keyOf(item)] = item
+	}
+
+	posted, err := closeStocktake(ctx, tx, stocktake, dmodel.StocktakePosted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dmodel.StocktakeUpdate{Stocktake: posted, Items: sortedItems(items)}, tx.Commit()
+}
+
+// close an open stocktake without posting anything
+func (dr *DataRepo_Inventory) Cancel_Stocktake(ctx context.Context, id int) (*dmodel.Stocktake, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stocktake, err := lockStocktake(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := closeStocktake(ctx, tx, stocktake, dmodel.StocktakeCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelled, tx.Commit()
+}
+
+// totals of the variances posted by the stocktakes matching filter, per product
+func (dr *DataRepo_Inventory) Get_Shrinkage(ctx context.Context, filter dmodel.ShrinkageFilter) ([]*dmodel.ShrinkageLine, error) {
+	query := `
+		WITH posted AS (
+			SELECT l.product_id, l.expected,
+				(SELECT COALESCE(SUM(c.quantity), 0) FROM stocktake_counts c
+					WHERE c.stocktake_id = l.stocktake_id AND c.product_id = l.product_id) AS counted
+			FROM stocktake_lines l
+			JOIN stocktakes s ON s.id = l.stocktake_id
+			WHERE s.state = 'posted' AND l.state = 'approved'
+				AND ($1 = 0 OR s.location_id = $1)
+				AND ($2 = 0 OR s.id = $2)
+				AND ($3::timestamp IS NULL OR s.closed_at >= $3)
+				AND ($4::timestamp IS NULL OR s.closed_at < $4)
+		)
+		SELECT product_id, COUNT(*), SUM(expected), SUM(counted),
+			SUM(GREATEST(expected - counted, 0)), SUM(GREATEST(counted - expected, 0))
+		FROM posted
+		GROUP BY product_id
+		ORDER BY product_id`
+	rows, err := dr.db.QueryContext(ctx, query, filter.LocationID, filter.StocktakeID, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []*dmodel.ShrinkageLine{}
+	for rows.Next() {
+		var line dmodel.ShrinkageLine
+		if err := rows.Scan(&line.ProductID, &line.Stocktakes, &line.Expected, &line.Counted, &line.Shrinkage, &line.Found); err != nil {
+			return nil, err
+		}
+		line.Variance = line.Found - line.Shrinkage
+		report = append(report, &line)
+	}
+
+	return report, rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// lock an open stocktake, with its lines
+func lockStocktake(ctx context.Context, tx *sql.Tx, id int) (*dmodel.Stocktake, error) {
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes WHERE id = $1 FOR UPDATE`
+	stocktake, err := scanStocktake(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrStocktakeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.State != dmodel.StocktakeOpen {
+		return nil, internal.ErrStocktakeState
+	}
+
+	if err := loadStocktakeLines(ctx, tx, []*dmodel.Stocktake{stocktake}); err != nil {
+		return nil, err
+	}
+
+	return stocktake, nil
+}
+
+// reload the lines of a stocktake changed by the transaction and commit
+func reloadStocktake(ctx context.Context, tx *sql.Tx, stocktake *dmodel.Stocktake) (*dmodel.Stocktake, error) {
+	if err := loadStocktakeLines(ctx, tx, []*dmodel.Stocktake{stocktake}); err != nil {
+		return nil, err
+	}
+
+	return stocktake, tx.Commit()
+}
+
+// move a stocktake to its final state
+func closeStocktake(ctx context.Context, tx *sql.Tx, stocktake *dmodel.Stocktake, state string) (*dmodel.Stocktake, error) {
+	query := `UPDATE stocktakes SET state = $1, closed_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING ` + stocktakeColumns
+	closed, err := scanStocktake(tx.QueryRowContext(ctx, query, state, stocktake.ID))
+	if err != nil {
+		return nil, err
+	}
+	closed.Lines = stocktake.Lines
+
+	return closed, nil
+}
+
+// the products a location holds an item of, in product_id order
+func heldProducts(ctx context.Context, tx *sql.Tx, locationID int) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT product_id FROM inventory WHERE location_id = $1 ORDER BY product_id`, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var productIDs []int
+	for rows.Next() {
+		var productID int
+		if err := rows.Scan(&productID); err != nil {
+			return nil, err
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	return productIDs, rows.Err()
+}
+
+// product IDs as a postgres integer array parameter
+func productIDArray(productIDs []int) []int64 {
+	ids := make([]int64, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = int64(id)
+	}
+	return ids
+}
+
+func findStocktakeLine(stocktake *dmodel.Stocktake, productID int) *dmodel.StocktakeLine {
+	for i := range stocktake.Lines {
+		if stocktake.Lines[i].ProductID == productID {
+			return &stocktake.Lines[i]
+		}
+	}
+	return nil
+}
+
+func stocktakeFailure(locationID int, line dmodel.StocktakeLine, err error) dmodel.LineFailure {
+	return dmodel.LineFailure{
+		LocationID: locationID,
+		ProductID:  line.ProductID,
+		Quantity:   line.Variance(),
+		Reason:     err.Error(),
+	}
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,467 @@
+package inventory_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	internal "inventory-service/internal"
+	dmodel "inventory-service/pkg"
+	"shared/money"
+
+	"github.com/lib/pq"
+)
+
+// -------------------------------------------------------------------
+// transfers
+// -------------------------------------------------------------------
+
+// shipping a transfer removes its units from the source's stock and counts them as in
+// transit at the destination; receiving it moves them from in transit to the destination's
+// stock. Both apply every line or none of them, in a single transaction
+// the units of a line leave the lots it names, the rest the untracked units and then the
+// lots expiring first, like any other units leaving the stock; shipping records every lot
+// they left, and receiving adds them to the same lots at the destination
+// locks are taken transfer first, then inventory rows (by product_id, then location_id)
+
+const transferColumns = `id, source_location_id, destination_location_id, state, created_at, shipped_at, received_at`
+
+func scanTransfer(row scanner) (*dmodel.Transfer, error) {
+	var t dmodel.Transfer
+	var shippedAt, receivedAt sql.NullTime
+	err := row.Scan(&t.ID, &t.SourceLocationID, &t.DestinationLocationID, &t.State, &t.CreatedAt, &shippedAt, &receivedAt)
+	if err != nil {
+		return nil, err
+	}
+	if shippedAt.Valid {
+		t.ShippedAt = &shippedAt.Time
+	}
+	if receivedAt.Valid {
+		t.ReceivedAt = &receivedAt.Time
+	}
+
+	return &t, nil
+}
+
+// a *sql.DB or a *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// fill in the lines of the given transfers, in product_id order, with their serial numbers
+// and lots
+func loadTransferLines(ctx context.Context, q queryer, transfers []*dmodel.Transfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(transfers))
+	byID := make(map[int]*dmodel.Transfer, len(transfers))
+	for i, t := range transfers {
+		ids[i] = int64(t.ID)
+		byID[t.ID] = t
+		t.Lines = []dmodel.TransferLine{}
+	}
+
+	query := `SELECT transfer_id, product_id, quantity FROM transfer_lines WHERE transfer_id = ANY($1) ORDER BY transfer_id, product_id`
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transferID int
+		var line dmodel.TransferLine
+		if err := rows.Scan(&transferID, &line.ProductID, &line.Quantity); err != nil {
+			return err
+		}
+		byID[transferID].Lines = append(byID[transferID].Lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	serialQuery := `SELECT transfer_id, product_id, serial_number FROM transfer_line_serials WHERE transfer_id = ANY($1) ORDER BY transfer_id, product_id, serial_number`
+	serialRows, err := q.QueryContext(ctx, serialQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer serialRows.Close()
+
+	for serialRows.Next() {
+		var transferID, productID int
+		var serial string
+		if err := serialRows.Scan(&transferID, &productID, &serial); err != nil {
+			return err
+		}
+		lines := byID[transferID].Lines
+		for i := range lines {
+			if lines[i].ProductID == productID {
+				lines[i].SerialNumbers = append(lines[i].SerialNumbers, serial)
+			}
+		}
+	}
+	if err := serialRows.Err(); err != nil {
+		return err
+	}
+
+	lotQuery := `SELECT transfer_id, product_id, lot_number, expires_at, quantity FROM transfer_line_lots WHERE transfer_id = ANY($1) ORDER BY transfer_id, product_id, lot_number`
+	lotRows, err := q.QueryContext(ctx, lotQuery, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer lotRows.Close()
+
+	for lotRows.Next() {
+		var transferID, productID int
+		var lot dmodel.LotQuantity
+		var expiresAt sql.NullTime
+		if err := lotRows.Scan(&transferID, &productID, &lot.LotNumber, &expiresAt, &lot.Quantity); err != nil {
+			return err
+		}
+		if expiresAt.Valid {
+			lot.ExpiresAt = &expiresAt.Time
+		}
+		lines := byID[transferID].Lines
+		for i := range lines {
+			if lines[i].ProductID == productID {
+				lines[i].Lots = append(lines[i].Lots, lot)
+			}
+		}
+	}
+
+	return lotRows.Err()
+}
+
+// replace the lots recorded for a transfer line
+func saveTransferLots(ctx context.Context, tx *sql.Tx, transferID, productID int, lots []dmodel.LotQuantity) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM transfer_line_lots WHERE transfer_id = $1 AND product_id = $2`, transferID, productID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO transfer_line_lots (transfer_id, product_id, lot_number, expires_at, quantity) VALUES ($1, $2, $3, $4, $5)`
+	for _, lot := range lots {
+		if _, err := tx.ExecContext(ctx, query, transferID, productID, lot.LotNumber, lot.ExpiresAt, lot.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+func (dr *DataRepo_Inventory) Get_Transfer(ctx context.Context, id int) (*dmodel.Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM transfers WHERE id = $1`
+	t, err := scanTransfer(dr.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadTransferLines(ctx, dr.db, []*dmodel.Transfer{t}); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// retrieving the transfers in a state and/or from or to a location (any when empty or 0)
+func (dr *DataRepo_Inventory) Get_Transfers(ctx context.Context, state string, locationID int) ([]*dmodel.Transfer, error) {
+	query := `
+		SELECT ` + transferColumns + ` FROM transfers
+		WHERE ($1 = '' OR state = $1) AND ($2 = 0 OR source_location_id = $2 OR destination_location_id = $2)
+		ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, state, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []*dmodel.Transfer{}
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTransferLines(ctx, dr.db, transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// create a draft transfer; both locations must exist and the source must hold every
+// product of the lines (lines must name each product once), with one serial number for
+// each unit of a serial-tracked product; the lots a line names are checked when it ships
+func (dr *DataRepo_Inventory) Create_Transfer(ctx context.Context, transfer *dmodel.Transfer) (*dmodel.Transfer, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	locations, err := loadLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []int{transfer.SourceLocationID, transfer.DestinationLocationID} {
+		if _, ok := locations[id]; !ok {
+			return nil, fmt.Errorf("%w: location %d", internal.ErrLocationNotFound, id)
+		}
+	}
+
+	query := `INSERT INTO transfers (source_location_id, destination_location_id) VALUES ($1, $2) RETURNING ` + transferColumns
+	created, err := scanTransfer(tx.QueryRowContext(ctx, query, transfer.SourceLocationID, transfer.DestinationLocationID))
+	if err != nil {
+		return nil, err
+	}
+
+	// the source must hold the product, with or without stock to ship yet
+	itemQuery := `SELECT ` + itemColumns + ` FROM inventory WHERE location_id = $1 AND product_id = $2`
+	lineQuery := `INSERT INTO transfer_lines (transfer_id, product_id, quantity) VALUES ($1, $2, $3)`
+	serialQuery := `INSERT INTO transfer_line_serials (transfer_id, product_id, serial_number) VALUES ($1, $2, $3)`
+	for _, line := range transfer.Lines {
+		source, err := scanItem(tx.QueryRowContext(ctx, itemQuery, transfer.SourceLocationID, line.ProductID))
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: product %d at location %d", internal.ErrItemNotFound, line.ProductID, transfer.SourceLocationID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSerials(source, line.Quantity, line.SerialNumbers); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, lineQuery, created.ID, line.ProductID, line.Quantity); err != nil {
+			return nil, err
+		}
+		for _, serial := range line.SerialNumbers {
+			if _, err := tx.ExecContext(ctx, serialQuery, created.ID, line.ProductID, serial); err != nil {
+				return nil, err
+			}
+		}
+		if err := saveTransferLots(ctx, tx, created.ID, line.ProductID, line.Lots); err != nil {
+			return nil, err
+		}
+	}
+	created.Lines = transfer.Lines
+
+	return created, tx.Commit()
+}
+
+// remove the units of every line from the source's stock and count them as in transit at
+// the destination, recording the lots they left; a line whose source has fewer available
+// units, whose serialized units are not available there, or whose lots hold fewer
+// unreserved units there, fails the transfer
+func (dr *DataRepo_Inventory) Ship_Transfer(ctx context.Context, id int, actor string) (*dmodel.TransferUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transfer, locked, err := lockTransfer(ctx, tx, id, dmodel.TransferDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []dmodel.LineFailure
+	taken := make(map[int][]dmodel.LotQuantity, len(transfer.Lines))
+	for _, line := range transfer.Lines {
+		source := findItem(locked[line.ProductID], transfer.SourceLocationID)
+		switch {
+		case source == nil:
+			failures = append(failures, transferFailure(transfer.SourceLocationID, line, internal.ErrItemNotFound))
+		case source.Available() < line.Quantity:
+			failures = append(failures, transferFailure(transfer.SourceLocationID, line, internal.ErrInsufficientStock))
+		default:
+			err := shipSerials(ctx, tx, source, line, transfer.DestinationLocationID)
+			if err == nil {
+				taken[line.ProductID], err = takeLots(ctx, tx, source, line.Lots)
+			}
+			if isSerialError(err) || errors.Is(err, internal.ErrInvalidLot) {
+				failures = append(failures, transferFailure(transfer.SourceLocationID, line, err))
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return nil, &internal.BatchError{Failures: failures}
+	}
+
+	sourceQuery := `UPDATE inventory SET stock = stock - $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	destinationQuery := `
+		INSERT INTO inventory (location_id, product_id, in_transit) VALUES ($1, $2, $3)
+		ON CONFLICT (location_id, product_id) DO UPDATE
+			SET in_transit = inventory.in_transit + EXCLUDED.in_transit, version = inventory.version + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + itemColumns
+
+	items := make(map[itemKey]*dmodel.InventoryItem, 2*len(transfer.Lines))
+	for i, line := range transfer.Lines {
+		source, err := scanItem(tx.QueryRowContext(ctx, sourceQuery, line.Quantity, transfer.SourceLocationID, line.ProductID))
+		if err != nil {
+			return nil, err
+		}
+		trimmed, err := trimLots(ctx, tx, source)
+		if err != nil {
+			return nil, err
+		}
+		lots := mergeLots(append(taken[line.ProductID], trimmed...))
+		if err := saveTransferLots(ctx, tx, transfer.ID, line.ProductID, lots); err != nil {
+			return nil, err
+		}
+		transfer.Lines[i].Lots = lots
+
+		err = recordMovement(ctx, tx, source, dmodel.Movement{
+			Reason:     dmodel.MovementTransferOut,
+			StockDelta: -line.Quantity,
+			TransferID: transfer.ID,
+			Actor:      actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items[keyOf(source)] = source
+
+		destination, err := scanItem(tx.QueryRowContext(ctx, destinationQuery, transfer.DestinationLocationID, line.ProductID, line.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		items[keyOf(destination)] = destination
+	}
+
+	return finishTransfer(ctx, tx, transfer, dmodel.TransferInTransit, items)
+}
+
+// move the units of every line from in transit to the destination's stock, at the cost
+// they were shipped at, and into the lots they left the source with
+func (dr *DataRepo_Inventory) Receive_Transfer(ctx context.Context, id int, actor string) (*dmodel.TransferUpdate, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transfer, _, err := lockTransfer(ctx, tx, id, dmodel.TransferInTransit)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE inventory SET stock = stock + $1, in_transit = in_transit - $1, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $2 AND product_id = $3 RETURNING ` + itemColumns
+	shippedQuery := `SELECT COALESCE(-SUM(value_delta), 0) FROM inventory_movements WHERE transfer_id = $1 AND product_id = $2 AND reason = 'transfer_out'`
+
+	items := make(map[itemKey]*dmodel.InventoryItem, len(transfer.Lines))
+	for _, line := range transfer.Lines {
+		for _, lot := range line.Lots {
+			if err := receiveLot(ctx, tx, transfer.DestinationLocationID, line.ProductID, lot); err != nil {
+				return nil, err
+			}
+		}
+		item, err := scanItem(tx.QueryRowContext(ctx, query, line.Quantity, transfer.DestinationLocationID, line.ProductID))
+		if err != nil {
+			return nil, err
+		}
+		if err := arriveSerials(ctx, tx, item, line); err != nil {
+			return nil, err
+		}
+		var shipped money.Money
+		if err := tx.QueryRowContext(ctx, shippedQuery, transfer.ID, line.ProductID).Scan(&shipped); err != nil {
+			return nil, err
+		}
+		unitCost := prorate(shipped, 1, line.Quantity)
+		err = recordMovement(ctx, tx, item, dmodel.Movement{
+			Reason:     dmodel.MovementTransferIn,
+			StockDelta: line.Quantity,
+			TransferID: transfer.ID,
+			Actor:      actor,
+			UnitCost:   &unitCost,
+			Value:      shipped,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items[keyOf(item)] = item
+	}
+
+	return finishTransfer(ctx, tx, transfer, dmodel.TransferReceived, items)
+}
+
+// -------------------------------------------------------------------
+
+// lock a transfer, which must be in state, and then the items of its products
+func lockTransfer(ctx context.Context, tx *sql.Tx, id int, state string) (*dmodel.Transfer, map[int][]*dmodel.InventoryItem, error) {
+	query := `SELECT ` + transferColumns + ` FROM transfers WHERE id = $1 FOR UPDATE`
+	transfer, err := scanTransfer(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil, internal.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if transfer.State != state {
+		return nil, nil, internal.ErrTransferState
+	}
+
+	if err := loadTransferLines(ctx, tx, []*dmodel.Transfer{transfer}); err != nil {
+		return nil, nil, err
+	}
+	productIDs := make([]int, len(transfer.Lines))
+	for i, line := range transfer.Lines {
+		productIDs[i] = line.ProductID
+	}
+	locked, err := lockItems(ctx, tx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return transfer, locked, nil
+}
+
+// move a transfer to its next state and commit
+func finishTransfer(ctx context.Context, tx *sql.Tx, transfer *dmodel.Transfer, state string, items map[itemKey]*dmodel.InventoryItem) (*dmodel.TransferUpdate, error) {
+	query := `
+		UPDATE transfers SET state = $1,
+			shipped_at = CASE WHEN $1 = 'in_transit' THEN CURRENT_TIMESTAMP ELSE shipped_at END,
+			received_at = CASE WHEN $1 = 'received' THEN CURRENT_TIMESTAMP ELSE received_at END
+		WHERE id = $2
+		RETURNING ` + transferColumns
+	updated, err := scanTransfer(tx.QueryRowContext(ctx, query, state, transfer.ID))
+	if err != nil {
+		return nil, err
+	}
+	updated.Lines = transfer.Lines
+
+	return &dmodel.TransferUpdate{Transfer: updated, Items: sortedItems(items)}, tx.Commit()
+}
+
+// the item at a location among the items of a product
+func findItem(items []*dmodel.InventoryItem, locationID int) *dmodel.InventoryItem {
+	for _, item := range items {
+		if item.LocationID == locationID {
+			return item
+		}
+	}
+	return nil
+}
+
+func transferFailure(locationID int, line dmodel.TransferLine, err error) dmodel.LineFailure {
+	return dmodel.LineFailure{
+		LocationID: locationID,
+		ProductID:  line.ProductID,
+		Quantity:   line.Quantity,
+		Reason:     err.Error(),
+	}
+}
+
+// -------------------------------------------------------------------
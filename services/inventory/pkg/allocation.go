@@ -0,0 +1,94 @@
+package dmodel
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// -------------------------------------------------------------------
+// allocation of reservations to locations
+// -------------------------------------------------------------------
+
+// allocation policies, used to pick the location of a reservation that does not name one
+const (
+	AllocationPriority  = "priority"   // the location with the lowest priority number
+	AllocationMostStock = "most_stock" // the location with the most available units
+	AllocationNearest   = "nearest"    // the location closest to the destination
+)
+
+var allocationPolicies = []string{AllocationPriority, AllocationMostStock, AllocationNearest}
+
+func IsAllocationPolicy(policy string) bool {
+	return slices.Contains(allocationPolicies, policy)
+}
+
+// Coordinates
+// a point on the map, in decimal degrees
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Allocation
+// where to reserve stock: at LocationID, or at the location picked by Policy among the
+// ones with enough available units
+type Allocation struct {
+	LocationID  int          `json:"location_id,omitempty"`
+	Policy      string       `json:"policy,omitempty"`
+	Destination *Coordinates `json:"destination,omitempty"` // used by the nearest policy
+}
+
+// Pick returns the item of the location where quantity units should be reserved, nil
+// when no location has enough available units
+// ties (and the nearest policy without a destination) fall back to priority order, then
+// to the location ID
+func (a Allocation) Pick(items []*InventoryItem, locations map[int]*Location, quantity int) *InventoryItem {
+	var candidates []*InventoryItem
+	for _, item := range items {
+		if _, ok := locations[item.LocationID]; ok && item.Available() >= quantity {
+			candidates = append(candidates, item)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	byPriority := func(x, y *InventoryItem) int {
+		return cmp.Or(
+			cmp.Compare(locations[x.LocationID].Priority, locations[y.LocationID].Priority),
+			cmp.Compare(x.LocationID, y.LocationID),
+		)
+	}
+
+	compare := byPriority
+	switch {
+	case a.Policy == AllocationMostStock:
+		compare = func(x, y *InventoryItem) int {
+			return cmp.Or(cmp.Compare(y.Available(), x.Available()), byPriority(x, y))
+		}
+	case a.Policy == AllocationNearest && a.Destination != nil:
+		compare = func(x, y *InventoryItem) int {
+			return cmp.Or(
+				cmp.Compare(distance(*a.Destination, locations[x.LocationID]), distance(*a.Destination, locations[y.LocationID])),
+				byPriority(x, y),
+			)
+		}
+	}
+
+	return slices.MinFunc(candidates, compare)
+}
+
+// great-circle distance in kilometres between a point and a location
+func distance(from Coordinates, to *Location) float64 {
+	const earthRadius = 6371.0
+
+	lat1, lat2 := from.Latitude*math.Pi/180, to.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// -------------------------------------------------------------------
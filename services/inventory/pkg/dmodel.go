@@ -1,7 +1,685 @@
 package dmodel
 
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"shared/money"
+)
+
+// InventoryItem
+// stock of a product at a location, or the totals of every location holding the product
+// (LocationID 0, with the stock of each location in Locations and the sums of their
+// reorder points and quantities)
 type InventoryItem struct {
+	LocationID      int              `json:"location_id,omitempty"`
+	ProductID       int              `json:"product_id"`
+	Stock           int              `json:"stock"`
+	Reserved        int              `json:"reserved"`
+	Damaged         int              `json:"damaged"`          // returned units that cannot be sold again
+	InTransit       int              `json:"in_transit"`       // units shipped to the location by transfers, not received yet
+	Expired         int              `json:"expired"`          // unreserved units of expired lots, which cannot be reserved
+	Version         int              `json:"version"`          // incremented by every change to the item (the sum of them in totals)
+	ReorderPoint    int              `json:"reorder_point"`    // low on stock below this many available units (never when 0)
+	ReorderQuantity int              `json:"reorder_quantity"` // units to order when low on stock
+	SerialTracked   bool             `json:"serial_tracked"`   // every unit of the product has a serial number
+	Locations       []*InventoryItem `json:"locations,omitempty"`
+}
+
+// units that can still be reserved
+func (item InventoryItem) Available() int {
+	return item.Stock - item.Reserved - item.Expired
+}
+
+// whether fewer units than the reorder point are available
+func (item InventoryItem) IsLowStock() bool {
+	return item.ReorderPoint > 0 && item.Available() < item.ReorderPoint
+}
+
+// -------------------------------------------------------------------
+// locations
+// -------------------------------------------------------------------
+
+// Location
+// a warehouse holding stock
+type Location struct {
+	ID        int     `json:"id"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Priority  int     `json:"priority"` // lower is preferred by the priority allocation policy
+}
+
+// -------------------------------------------------------------------
+// transfers
+// -------------------------------------------------------------------
+
+// transfer states
+const (
+	TransferDraft     = "draft"      // lines can still change, no stock has moved
+	TransferInTransit = "in_transit" // shipped: removed from the source's stock, in transit to the destination
+	TransferReceived  = "received"   // added to the destination's stock
+)
+
+// TransferLine
+// quantity of a product moved by a transfer
+type TransferLine struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+	// serialized units moved, one for each unit of a serial-tracked product
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// units of lots moved: the lots named on creation, then every lot the units were taken
+	// from once shipped; the rest of the units are untracked
+	Lots []LotQuantity `json:"lots,omitempty"`
+}
+
+// Transfer
+// units moved from one location to another
+type Transfer struct {
+	ID                    int            `json:"id"`
+	SourceLocationID      int            `json:"source_location_id"`
+	DestinationLocationID int            `json:"destination_location_id"`
+	State                 string         `json:"state"`
+	Lines                 []TransferLine `json:"lines"`
+	CreatedAt             time.Time      `json:"created_at"`
+	ShippedAt             *time.Time     `json:"shipped_at,omitempty"`
+	ReceivedAt            *time.Time     `json:"received_at,omitempty"`
+}
+
+// result of shipping or receiving a transfer: the transfer and the items it changed
+type TransferUpdate struct {
+	Transfer *Transfer        `json:"transfer"`
+	Items    []*InventoryItem `json:"items"`
+}
+
+// -------------------------------------------------------------------
+// stocktakes
+// -------------------------------------------------------------------
+
+// stocktake states
+const (
+	StocktakeOpen      = "open"      // counts can be submitted and reviewed
+	StocktakePosted    = "posted"    // the approved variances were posted as adjustments
+	StocktakeCancelled = "cancelled" // closed without posting anything
+)
+
+// states of a stocktake line
+const (
+	StocktakeLinePending  = "pending"  // not counted yet
+	StocktakeLineCounted  = "counted"  // counted, waiting for review
+	StocktakeLineApproved = "approved" // its variance is posted with the stocktake
+	StocktakeLineRejected = "rejected" // its variance is not posted; a new count reopens it
+)
+
+// StocktakeCount
+// units of a product counted by one counter; a counter's new count replaces their last one
+type StocktakeCount struct {
+	Counter   string    `json:"counter"`
+	Quantity  int       `json:"quantity"`
+	CountedAt time.Time `json:"counted_at"`
+}
+
+// StocktakeLine
+// a product counted by a stocktake: the stock expected when it was last counted (when the
+// stocktake was opened until then) and the units counted, the sum of every counter's count
+type StocktakeLine struct {
+	ProductID int              `json:"product_id"`
+	Expected  int              `json:"expected"`
+	Counted   int              `json:"counted"`
+	State     string           `json:"state"`
+	Counts    []StocktakeCount `json:"counts"`
+}
+
+// units counted more than expected (negative when units are missing), 0 until counted
+func (line StocktakeLine) Variance() int {
+	if line.State == StocktakeLinePending {
+		return 0
+	}
+	return line.Counted - line.Expected
+}
+
+func (line StocktakeLine) MarshalJSON() ([]byte, error) {
+	type plain StocktakeLine
+	return json.Marshal(struct {
+		plain
+		Variance int `json:"variance"`
+	}{plain(line), line.Variance()})
+}
+
+// Stocktake
+// a physical count of products at a location; their stock is snapshotted when it is
+// opened, and posting it adds each approved variance to the stock as it is by then, so
+// units moved during the count are kept
+type Stocktake struct {
+	ID         int             `json:"id"`
+	LocationID int             `json:"location_id"`
+	State      string          `json:"state"`
+	Reference  string          `json:"reference,omitempty"`
+	CreatedBy  string          `json:"created_by"`
+	Lines      []StocktakeLine `json:"lines"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ClosedAt   *time.Time      `json:"closed_at,omitempty"` // when it was posted or cancelled
+}
+
+// StocktakeCountLine
+// units of a product counted, as submitted by a counter
+type StocktakeCountLine struct {
 	ProductID int `json:"product_id"`
-	Stock     int `json:"stock"`
-	Reserved  int `json:"reserved"`
+	Quantity  int `json:"quantity"`
+}
+
+// result of posting a stocktake: the stocktake and the items its variances changed
+type StocktakeUpdate struct {
+	Stocktake *Stocktake       `json:"stocktake"`
+	Items     []*InventoryItem `json:"items"`
+}
+
+// ShrinkageLine
+// variances of a product posted by stocktakes: shrinkage counts the units found missing,
+// found the units counted in excess
+type ShrinkageLine struct {
+	ProductID  int `json:"product_id"`
+	Stocktakes int `json:"stocktakes"`
+	Expected   int `json:"expected"`
+	Counted    int `json:"counted"`
+	Shrinkage  int `json:"shrinkage"`
+	Found      int `json:"found"`
+	Variance   int `json:"variance"` // found - shrinkage
+}
+
+// ShrinkageFilter
+// selects the posted stocktakes of a shrinkage report; zero fields do not filter
+type ShrinkageFilter struct {
+	LocationID  int
+	StocktakeID int
+	From        *time.Time // posted at or after
+	To          *time.Time // posted before
+}
+
+// -------------------------------------------------------------------
+// purchasing
+// -------------------------------------------------------------------
+
+// Supplier
+// a vendor that purchase orders are placed with
+type Supplier struct {
+	ID        int       `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// purchase order states; units are only expected while the order is open or partially received
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"  // every line received in full, or more
+	PurchaseOrderCancelled         = "cancelled" // nothing more is expected
+)
+
+// PurchaseOrderLine
+// units of a product ordered from the supplier, and how many of them have arrived
+type PurchaseOrderLine struct {
+	ProductID        int         `json:"product_id"`
+	Quantity         int         `json:"quantity"`
+	ReceivedQuantity int         `json:"received_quantity"` // above Quantity after an over-receipt
+	UnitCost         money.Money `json:"unit_cost"`         // cost of each unit, in the currency of the product's costs
+}
+
+// units of the line still expected
+func (l PurchaseOrderLine) Outstanding() int {
+	return max(l.Quantity-l.ReceivedQuantity, 0)
+}
+
+// PurchaseOrder
+// units ordered from a supplier, to be received at a location
+type PurchaseOrder struct {
+	ID         int                 `json:"id"`
+	SupplierID int                 `json:"supplier_id"`
+	LocationID int                 `json:"location_id"`
+	State      string              `json:"state"`
+	Reference  string              `json:"reference,omitempty"` // e.g. the supplier's order number
+	ExpectedAt *time.Time          `json:"expected_at,omitempty"`
+	Lines      []PurchaseOrderLine `json:"lines"`
+	CreatedAt  time.Time           `json:"created_at"`
+	ClosedAt   *time.Time          `json:"closed_at,omitempty"` // when it was received in full or cancelled
+}
+
+// ReceiptLine
+// units of a product that arrived for a purchase order
+type ReceiptLine struct {
+	ProductID     int          `json:"product_id"`
+	Quantity      int          `json:"quantity"`
+	LotNumber     string       `json:"lot_number,omitempty"`     // the lot the units belong to (untracked when empty)
+	ExpiresAt     *time.Time   `json:"expires_at,omitempty"`     // expiry date of the lot
+	SerialNumbers []string     `json:"serial_numbers,omitempty"` // one per unit, for serial-tracked products
+	UnitCost      *money.Money `json:"unit_cost,omitempty"`      // cost of each unit, when it differs from the order's
+}
+
+// Receipt
+// units of a product received for a purchase order, added to the stock of its location
+type Receipt struct {
+	ID              int         `json:"id"`
+	PurchaseOrderID int         `json:"purchase_order_id"`
+	LocationID      int         `json:"location_id"`
+	ProductID       int         `json:"product_id"`
+	Quantity        int         `json:"quantity"`
+	LotNumber       string      `json:"lot_number,omitempty"`
+	ExpiresAt       *time.Time  `json:"expires_at,omitempty"`
+	UnitCost        money.Money `json:"unit_cost"`           // cost each unit was valued at
+	Reference       string      `json:"reference,omitempty"` // e.g. the delivery note
+	Actor           string      `json:"actor"`
+	ReceivedAt      time.Time   `json:"received_at"`
+}
+
+// result of receiving goods: the purchase order, the receipts and the items they changed
+type ReceiptUpdate struct {
+	PurchaseOrder *PurchaseOrder   `json:"purchase_order"`
+	Receipts      []*Receipt       `json:"receipts"`
+	Items         []*InventoryItem `json:"items"`
+}
+
+// IncomingStock
+// units of a product still expected at a location from open purchase orders
+type IncomingStock struct {
+	ProductID  int        `json:"product_id"`
+	LocationID int        `json:"location_id"`
+	Quantity   int        `json:"quantity"`
+	ExpectedAt *time.Time `json:"expected_at,omitempty"` // the earliest expected date of those orders
+}
+
+// -------------------------------------------------------------------
+// stock alerts
+// -------------------------------------------------------------------
+
+// stock alert kinds
+const (
+	AlertLowStock  = "low_stock" // the item dropped below its reorder point
+	AlertRecovered = "recovered" // the item is back at or above its reorder point
+)
+
+// StockAlert
+// raised once when an item crosses its reorder point, in either direction
+type StockAlert struct {
+	ID              int       `json:"id"` // increasing in the order the alerts were raised
+	LocationID      int       `json:"location_id"`
+	ProductID       int       `json:"product_id"`
+	Kind            string    `json:"kind"`
+	Available       int       `json:"available"`
+	ReorderPoint    int       `json:"reorder_point"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// -------------------------------------------------------------------
+// lots
+// -------------------------------------------------------------------
+
+// Lot
+// units of a product at a location from one production lot; lots break down part of the
+// item's stock and reserved quantities, the rest of its units are not tracked by lot
+type Lot struct {
+	ID         int        `json:"id"`
+	LocationID int        `json:"location_id"`
+	ProductID  int        `json:"product_id"`
+	LotNumber  string     `json:"lot_number"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // never expires when nil
+	Stock      int        `json:"stock"`
+	Reserved   int        `json:"reserved"`
+	Expired    bool       `json:"expired"` // past its expiry date: its unreserved units cannot be reserved
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// LotQuantity
+// units of a product from one lot, moved by a transfer or added back to the stock by a
+// return or an adjustment
+type LotQuantity struct {
+	LotNumber string     `json:"lot_number"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // expiry date of the lot
+	Quantity  int        `json:"quantity"`
+}
+
+// LotConsumption
+// units of a lot removed from stock by fulfilling a reservation
+type LotConsumption struct {
+	ReservationID int        `json:"reservation_id"`
+	LotNumber     string     `json:"lot_number"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	Quantity      int        `json:"quantity"`
+}
+
+// -------------------------------------------------------------------
+// serial numbers
+// -------------------------------------------------------------------
+
+// states of a serialized unit
+const (
+	SerialAvailable = "available"
+	SerialReserved  = "reserved" // picked for a reservation, shipped when it is fulfilled
+	SerialShipped   = "shipped"
+	SerialReturned  = "returned"   // taken back from a customer and restocked; can be shipped again
+	SerialDamaged   = "damaged"    // taken back from a customer damaged; cannot be shipped again
+	SerialInTransit = "in_transit" // shipped with a transfer, available at its destination once received
+)
+
+// SerialUnit
+// one unit of a serial-tracked product, registered when it is received
+type SerialUnit struct {
+	ID              int       `json:"id"`
+	ProductID       int       `json:"product_id"`
+	SerialNumber    string    `json:"serial_number"`
+	LocationID      int       `json:"location_id"` // where the unit was received, returned or shipped from
+	State           string    `json:"state"`
+	ReservationID   int       `json:"reservation_id,omitempty"`    // reservation the unit is reserved for or was shipped by
+	PurchaseOrderID int       `json:"purchase_order_id,omitempty"` // purchase order the unit was received for
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// units that can be picked for a reservation or shipped
+func (u SerialUnit) IsAvailable() bool {
+	return u.State == SerialAvailable || u.State == SerialReturned
+}
+
+// SerialFilter
+// serialized units to list; zero fields match any unit
+type SerialFilter struct {
+	ProductID     int
+	SerialNumber  string
+	State         string
+	LocationID    int
+	ReservationID int
+}
+
+// SerialConsumption
+// serialized unit shipped by fulfilling a reservation
+type SerialConsumption struct {
+	ReservationID int    `json:"reservation_id"`
+	SerialNumber  string `json:"serial_number"`
+}
+
+// -------------------------------------------------------------------
+// reservations
+// -------------------------------------------------------------------
+
+// reservation states; a reservation holds stock only while it is active
+const (
+	ReservationActive    = "active"
+	ReservationFulfilled = "fulfilled" // every unit fulfilled, or the last ones
+	ReservationReleased  = "released"  // the last units released
+	ReservationExpired   = "expired"   // released by the expiry worker
+)
+
+// Reservation
+// units of a product held for an owner (e.g. an order) until they are fulfilled,
+// released or the reservation expires
+type Reservation struct {
+	ID                int         `json:"id"`
+	LocationID        int         `json:"location_id"`
+	ProductID         int         `json:"product_id"`
+	Quantity          int         `json:"quantity"`
+	FulfilledQuantity int         `json:"fulfilled_quantity"`
+	ReleasedQuantity  int         `json:"released_quantity"`
+	Owner             string      `json:"owner"`
+	State             string      `json:"state"`
+	CostOfGoodsSold   money.Money `json:"cost_of_goods_sold"` // cost of the units fulfilled so far
+	CreatedAt         time.Time   `json:"created_at"`
+	ExpiresAt         *time.Time  `json:"expires_at,omitempty"`
+}
+
+// quantity still held by the reservation
+func (r Reservation) Remaining() int {
+	return r.Quantity - r.FulfilledQuantity - r.ReleasedQuantity
+}
+
+// result of an operation on a reservation: the reservation and its product's item
+type ReservationUpdate struct {
+	Item        *InventoryItem      `json:"item"`
+	Reservation *Reservation        `json:"reservation"`
+	Lots        []LotConsumption    `json:"lots,omitempty"`    // the lots a fulfillment consumed
+	Serials     []SerialConsumption `json:"serials,omitempty"` // the serialized units a fulfillment shipped
+	// cost of the units a fulfillment shipped, valued by their product's costing method
+	CostOfGoodsSold *money.Money `json:"cost_of_goods_sold,omitempty"`
+}
+
+// -------------------------------------------------------------------
+// batch operations
+// -------------------------------------------------------------------
+
+// line of a batch operation: a quantity of a product to reserve (at a location, or one
+// picked by the allocation policy when zero), or a quantity of a reservation to fulfill
+// or release (every remaining unit when zero); a fulfillment of a serial-tracked product
+// names the serial numbers of the units it ships, unless they were picked for the
+// reservation beforehand
+type StockLine struct {
+	LocationID    int      `json:"location_id,omitempty"`
+	ProductID     int      `json:"product_id,omitempty"`
+	ReservationID int      `json:"reservation_id,omitempty"`
+	Quantity      int      `json:"quantity"`
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+// line of a batch operation that could not be applied, and why
+type LineFailure struct {
+	LocationID    int    `json:"location_id,omitempty"`
+	ProductID     int    `json:"product_id"`
+	ReservationID int    `json:"reservation_id,omitempty"`
+	Quantity      int    `json:"quantity"`
+	Reason        string `json:"reason"`
+}
+
+// result of a batch operation: the reservations of every line and the items of their products
+type BatchUpdate struct {
+	Items        []*InventoryItem    `json:"items"`
+	Reservations []*Reservation      `json:"reservations"`
+	Lots         []LotConsumption    `json:"lots,omitempty"`    // the lots a fulfillment consumed
+	Serials      []SerialConsumption `json:"serials,omitempty"` // the serialized units a fulfillment shipped
+}
+
+// -------------------------------------------------------------------
+// bulk import
+// -------------------------------------------------------------------
+
+// import modes
+const (
+	ImportAllOrNothing = "all_or_nothing" // any failed row rejects the whole import
+	ImportBestEffort   = "best_effort"    // failed rows are skipped, the others are applied
+)
+
+// ImportRow
+// a row of an import: sets the stock of a product at a location (the product's preferred
+// location when 0); Line is its position in the file, used to report its errors
+type ImportRow struct {
+	Line       int `json:"line"`
+	ProductID  int `json:"product_id"`
+	LocationID int `json:"location_id"`
+	Stock      int `json:"stock"`
+}
+
+// ImportOptions
+// how an import is applied; Reference is recorded with the movements it makes
+type ImportOptions struct {
+	Mode      string
+	DryRun    bool // validate every row, and report what would change, without saving anything
+	Reference string
+}
+
+// row of an import that could not be applied, and why
+type ImportError struct {
+	Line       int    `json:"line"`
+	ProductID  int    `json:"product_id,omitempty"`
+	LocationID int    `json:"location_id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// ImportResult
+// outcome of an import: rows changing an item's stock are applied (or would be, on a dry
+// run), rows matching its stock are unchanged; Committed tells whether the changes were saved
+type ImportResult struct {
+	Mode      string        `json:"mode"`
+	DryRun    bool          `json:"dry_run"`
+	Committed bool          `json:"committed"`
+	Rows      int           `json:"rows"`
+	Applied   int           `json:"applied"`
+	Unchanged int           `json:"unchanged"`
+	Failed    int           `json:"failed"`
+	Errors    []ImportError `json:"errors"`
+}
+
+// -------------------------------------------------------------------
+// costing and valuation
+// -------------------------------------------------------------------
+
+// costing methods, deciding the cost of the units leaving the stock of a product; costs
+// are kept over every location holding it, in the currency its stock was first costed in
+const (
+	CostingFIFO            = "fifo"             // the cost of the oldest units received
+	CostingWeightedAverage = "weighted_average" // the average cost of every unit in stock
+)
+
+// costing method of the products that were never given one
+const DefaultCostingMethod = CostingFIFO
+
+func IsCostingMethod(method string) bool {
+	return method == CostingFIFO || method == CostingWeightedAverage
+}
+
+// CostLayer
+// units of a product received at one unit cost, Remaining of them still in stock and worth
+// Value; a product costed by weighted average keeps a single layer, at the average cost
+type CostLayer struct {
+	ID         int         `json:"id"`
+	Quantity   int         `json:"quantity"` // units received into the layer
+	Remaining  int         `json:"remaining"`
+	UnitCost   money.Money `json:"unit_cost"`
+	Value      money.Money `json:"value"`
+	ReceivedAt time.Time   `json:"received_at"`
+}
+
+// ProductCosting
+// costing method of a product and the cost of its stock, with the layers still holding units
+type ProductCosting struct {
+	ProductID int         `json:"product_id"`
+	Method    string      `json:"method"`
+	Currency  string      `json:"currency"`
+	Quantity  int         `json:"quantity"`
+	Value     money.Money `json:"value"`
+	UnitCost  money.Money `json:"unit_cost"` // average cost of the units in stock
+	Layers    []CostLayer `json:"layers"`
+}
+
+// ValuationLine
+// units of a product on hand and their cost, in one currency
+type ValuationLine struct {
+	ProductID int         `json:"product_id"`
+	Name      string      `json:"name"`
+	Category  string      `json:"category"`
+	Method    string      `json:"method"`
+	Currency  string      `json:"currency"`
+	Quantity  int         `json:"quantity"`
+	Value     money.Money `json:"value"`
+	UnitCost  money.Money `json:"unit_cost"` // average cost of the units on hand
+}
+
+// ValuationCategory
+// totals of the products of a category valued in one currency
+type ValuationCategory struct {
+	Category string      `json:"category"`
+	Currency string      `json:"currency"`
+	Products int         `json:"products"`
+	Quantity int         `json:"quantity"`
+	Value    money.Money `json:"value"`
+}
+
+// Valuation
+// cost of the units on hand at a point in time (units in transit between locations are
+// counted at their destination from the moment they were shipped), per product and per category
+type Valuation struct {
+	AsOf       time.Time           `json:"as_of"`
+	LocationID int                 `json:"location_id,omitempty"`
+	Products   []ValuationLine     `json:"products"`
+	Categories []ValuationCategory `json:"categories"`
+}
+
+// ValuationFilter
+// selects the stock of a valuation; zero fields do not filter
+type ValuationFilter struct {
+	AsOf       time.Time // the stock as it was then (required)
+	LocationID int
+	Category   string
+}
+
+// -------------------------------------------------------------------
+// stock movements
+// -------------------------------------------------------------------
+
+// movement reasons
+const (
+	MovementOpeningBalance = "opening_balance" // balances of an item when the ledger starts
+	MovementManualSet      = "manual_set"      // stock set to an absolute value
+	MovementReserve        = "reserve"
+	MovementRelease        = "release"
+	MovementExpire         = "expire" // released by the reservation expiry worker
+	MovementFulfill        = "fulfill"
+	MovementReturn         = "return"
+	MovementTransferOut    = "transfer_out" // shipped to another location by a transfer
+	MovementTransferIn     = "transfer_in"  // received from another location by a transfer
+	MovementPurchase       = "purchase"     // received from a supplier for a purchase order
+	MovementImport         = "import"       // stock set by a bulk import
+)
+
+// reasons of relative stock adjustments, recorded as the reason of their movement
+const (
+	MovementCycleCount = "cycle_count" // difference found when counting the stock
+	MovementDamage     = "damage"      // units damaged in the warehouse and written off
+	MovementShrinkage  = "shrinkage"   // units lost or stolen
+	MovementFound      = "found"       // units found that were not accounted for
+	MovementCorrection = "correction"  // fix of an earlier wrong entry
+)
+
+var adjustmentReasons = []string{MovementCycleCount, MovementDamage, MovementShrinkage, MovementFound, MovementCorrection}
+
+func IsAdjustmentReason(reason string) bool {
+	return slices.Contains(adjustmentReasons, reason)
+}
+
+// Movement
+// one entry of the append-only stock ledger: how a change moved the quantities of an
+// item, and the item's balances right after it
+type Movement struct {
+	ID              int       `json:"id"`
+	LocationID      int       `json:"location_id"`
+	ProductID       int       `json:"product_id"`
+	Reason          string    `json:"reason"`
+	StockDelta      int       `json:"stock_delta"`
+	ReservedDelta   int       `json:"reserved_delta"`
+	DamagedDelta    int       `json:"damaged_delta"`
+	Stock           int       `json:"stock"`
+	Reserved        int       `json:"reserved"`
+	Damaged         int       `json:"damaged"`
+	ReservationID   int       `json:"reservation_id,omitempty"`
+	TransferID      int       `json:"transfer_id,omitempty"`
+	PurchaseOrderID int       `json:"purchase_order_id,omitempty"`
+	StocktakeID     int       `json:"stocktake_id,omitempty"`
+	ReferenceID     string    `json:"reference_id,omitempty"` // e.g. the reservation owner or a return
+	Actor           string    `json:"actor"`
+	CreatedAt       time.Time `json:"created_at"`
+	// cost of each unit that entered or left the stock; units entering it are valued at
+	// Value when it is set, otherwise at UnitCost each, or at the product's current unit
+	// cost when neither is set
+	UnitCost *money.Money `json:"unit_cost,omitempty"`
+	Value    money.Money  `json:"value"` // cost added to the stock (negative when units left it)
+}
+
+// MovementFilter
+// selects the movements of a product; zero fields do not filter
+type MovementFilter struct {
+	LocationID int        // movements at this location
+	From       *time.Time // created at or after
+	To         *time.Time // created before
+	AfterID    int        // movements with a greater ID, for paging
+	Limit      int
 }
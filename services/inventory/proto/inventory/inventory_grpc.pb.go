@@ -19,12 +19,55 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_GetInventory_FullMethodName       = "/inventory.InventoryService/GetInventory"
-	InventoryService_ListInventory_FullMethodName      = "/inventory.InventoryService/ListInventory"
-	InventoryService_UpdateStock_FullMethodName        = "/inventory.InventoryService/UpdateStock"
-	InventoryService_ReserveStock_FullMethodName       = "/inventory.InventoryService/ReserveStock"
-	InventoryService_FulfillReservation_FullMethodName = "/inventory.InventoryService/FulfillReservation"
-	InventoryService_ReleaseReservation_FullMethodName = "/inventory.InventoryService/ReleaseReservation"
+	InventoryService_GetInventory_FullMethodName            = "/inventory.InventoryService/GetInventory"
+	InventoryService_ListInventory_FullMethodName           = "/inventory.InventoryService/ListInventory"
+	InventoryService_UpdateStock_FullMethodName             = "/inventory.InventoryService/UpdateStock"
+	InventoryService_AdjustStock_FullMethodName             = "/inventory.InventoryService/AdjustStock"
+	InventoryService_SetReorderPoint_FullMethodName         = "/inventory.InventoryService/SetReorderPoint"
+	InventoryService_ListLowStock_FullMethodName            = "/inventory.InventoryService/ListLowStock"
+	InventoryService_ReserveStock_FullMethodName            = "/inventory.InventoryService/ReserveStock"
+	InventoryService_FulfillReservation_FullMethodName      = "/inventory.InventoryService/FulfillReservation"
+	InventoryService_ReleaseReservation_FullMethodName      = "/inventory.InventoryService/ReleaseReservation"
+	InventoryService_ReceiveReturn_FullMethodName           = "/inventory.InventoryService/ReceiveReturn"
+	InventoryService_GetReservation_FullMethodName          = "/inventory.InventoryService/GetReservation"
+	InventoryService_ListReservations_FullMethodName        = "/inventory.InventoryService/ListReservations"
+	InventoryService_ListMovements_FullMethodName           = "/inventory.InventoryService/ListMovements"
+	InventoryService_ListLocations_FullMethodName           = "/inventory.InventoryService/ListLocations"
+	InventoryService_GetLocation_FullMethodName             = "/inventory.InventoryService/GetLocation"
+	InventoryService_CreateLocation_FullMethodName          = "/inventory.InventoryService/CreateLocation"
+	InventoryService_ListTransfers_FullMethodName           = "/inventory.InventoryService/ListTransfers"
+	InventoryService_GetTransfer_FullMethodName             = "/inventory.InventoryService/GetTransfer"
+	InventoryService_CreateTransfer_FullMethodName          = "/inventory.InventoryService/CreateTransfer"
+	InventoryService_ShipTransfer_FullMethodName            = "/inventory.InventoryService/ShipTransfer"
+	InventoryService_ReceiveTransfer_FullMethodName         = "/inventory.InventoryService/ReceiveTransfer"
+	InventoryService_ListStocktakes_FullMethodName          = "/inventory.InventoryService/ListStocktakes"
+	InventoryService_GetStocktake_FullMethodName            = "/inventory.InventoryService/GetStocktake"
+	InventoryService_CreateStocktake_FullMethodName         = "/inventory.InventoryService/CreateStocktake"
+	InventoryService_SubmitStocktakeCounts_FullMethodName   = "/inventory.InventoryService/SubmitStocktakeCounts"
+	InventoryService_ReviewStocktake_FullMethodName         = "/inventory.InventoryService/ReviewStocktake"
+	InventoryService_PostStocktake_FullMethodName           = "/inventory.InventoryService/PostStocktake"
+	InventoryService_CancelStocktake_FullMethodName         = "/inventory.InventoryService/CancelStocktake"
+	InventoryService_GetShrinkageReport_FullMethodName      = "/inventory.InventoryService/GetShrinkageReport"
+	InventoryService_ListSuppliers_FullMethodName           = "/inventory.InventoryService/ListSuppliers"
+	InventoryService_GetSupplier_FullMethodName             = "/inventory.InventoryService/GetSupplier"
+	InventoryService_CreateSupplier_FullMethodName          = "/inventory.InventoryService/CreateSupplier"
+	InventoryService_ListPurchaseOrders_FullMethodName      = "/inventory.InventoryService/ListPurchaseOrders"
+	InventoryService_GetPurchaseOrder_FullMethodName        = "/inventory.InventoryService/GetPurchaseOrder"
+	InventoryService_CreatePurchaseOrder_FullMethodName     = "/inventory.InventoryService/CreatePurchaseOrder"
+	InventoryService_ReceivePurchaseOrder_FullMethodName    = "/inventory.InventoryService/ReceivePurchaseOrder"
+	InventoryService_CancelPurchaseOrder_FullMethodName     = "/inventory.InventoryService/CancelPurchaseOrder"
+	InventoryService_ListIncoming_FullMethodName            = "/inventory.InventoryService/ListIncoming"
+	InventoryService_ListLots_FullMethodName                = "/inventory.InventoryService/ListLots"
+	InventoryService_SetSerialTracking_FullMethodName       = "/inventory.InventoryService/SetSerialTracking"
+	InventoryService_ListSerials_FullMethodName             = "/inventory.InventoryService/ListSerials"
+	InventoryService_ReserveSerials_FullMethodName          = "/inventory.InventoryService/ReserveSerials"
+	InventoryService_ImportInventory_FullMethodName         = "/inventory.InventoryService/ImportInventory"
+	InventoryService_GetCosting_FullMethodName              = "/inventory.InventoryService/GetCosting"
+	InventoryService_SetCostingMethod_FullMethodName        = "/inventory.InventoryService/SetCostingMethod"
+	InventoryService_GetValuation_FullMethodName            = "/inventory.InventoryService/GetValuation"
+	InventoryService_ReserveStockBatch_FullMethodName       = "/inventory.InventoryService/ReserveStockBatch"
+	InventoryService_FulfillReservationBatch_FullMethodName = "/inventory.InventoryService/FulfillReservationBatch"
+	InventoryService_ReleaseReservationBatch_FullMethodName = "/inventory.InventoryService/ReleaseReservationBatch"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -34,9 +77,59 @@ type InventoryServiceClient interface {
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error)
 	ListInventory(ctx context.Context, in *ListInventoryRequest, opts ...grpc.CallOption) (*ListInventoryResponse, error)
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error)
+	ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error)
 	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
 	FulfillReservation(ctx context.Context, in *FulfillReservationRequest, opts ...grpc.CallOption) (*FulfillReservationResponse, error)
 	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*ReceiveReturnResponse, error)
+	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*GetReservationResponse, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error)
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	GetLocation(ctx context.Context, in *GetLocationRequest, opts ...grpc.CallOption) (*GetLocationResponse, error)
+	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*GetTransferResponse, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error)
+	// shipping fails with FAILED_PRECONDITION carrying a BatchFailure detail when a line's
+	// source has too few available units
+	ShipTransfer(ctx context.Context, in *ShipTransferRequest, opts ...grpc.CallOption) (*ShipTransferResponse, error)
+	ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*ReceiveTransferResponse, error)
+	ListStocktakes(ctx context.Context, in *ListStocktakesRequest, opts ...grpc.CallOption) (*ListStocktakesResponse, error)
+	GetStocktake(ctx context.Context, in *GetStocktakeRequest, opts ...grpc.CallOption) (*GetStocktakeResponse, error)
+	CreateStocktake(ctx context.Context, in *CreateStocktakeRequest, opts ...grpc.CallOption) (*CreateStocktakeResponse, error)
+	SubmitStocktakeCounts(ctx context.Context, in *SubmitStocktakeCountsRequest, opts ...grpc.CallOption) (*SubmitStocktakeCountsResponse, error)
+	ReviewStocktake(ctx context.Context, in *ReviewStocktakeRequest, opts ...grpc.CallOption) (*ReviewStocktakeResponse, error)
+	// posting fails with FAILED_PRECONDITION carrying a BatchFailure detail when an approved
+	// variance would take an item's stock below zero or its reserved quantity
+	PostStocktake(ctx context.Context, in *PostStocktakeRequest, opts ...grpc.CallOption) (*PostStocktakeResponse, error)
+	CancelStocktake(ctx context.Context, in *CancelStocktakeRequest, opts ...grpc.CallOption) (*CancelStocktakeResponse, error)
+	GetShrinkageReport(ctx context.Context, in *GetShrinkageReportRequest, opts ...grpc.CallOption) (*GetShrinkageReportResponse, error)
+	ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*ListSuppliersResponse, error)
+	GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*GetSupplierResponse, error)
+	CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*CreateSupplierResponse, error)
+	ListPurchaseOrders(ctx context.Context, in *ListPurchaseOrdersRequest, opts ...grpc.CallOption) (*ListPurchaseOrdersResponse, error)
+	GetPurchaseOrder(ctx context.Context, in *GetPurchaseOrderRequest, opts ...grpc.CallOption) (*GetPurchaseOrderResponse, error)
+	CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*CreatePurchaseOrderResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, in *ReceivePurchaseOrderRequest, opts ...grpc.CallOption) (*ReceivePurchaseOrderResponse, error)
+	CancelPurchaseOrder(ctx context.Context, in *CancelPurchaseOrderRequest, opts ...grpc.CallOption) (*CancelPurchaseOrderResponse, error)
+	ListIncoming(ctx context.Context, in *ListIncomingRequest, opts ...grpc.CallOption) (*ListIncomingResponse, error)
+	ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error)
+	SetSerialTracking(ctx context.Context, in *SetSerialTrackingRequest, opts ...grpc.CallOption) (*SetSerialTrackingResponse, error)
+	ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*ListSerialsResponse, error)
+	ReserveSerials(ctx context.Context, in *ReserveSerialsRequest, opts ...grpc.CallOption) (*ReserveSerialsResponse, error)
+	// sets the stock of the streamed rows; options are read from the first message
+	ImportInventory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportInventoryRequest, ImportInventoryResponse], error)
+	GetCosting(ctx context.Context, in *GetCostingRequest, opts ...grpc.CallOption) (*GetCostingResponse, error)
+	SetCostingMethod(ctx context.Context, in *SetCostingMethodRequest, opts ...grpc.CallOption) (*SetCostingMethodResponse, error)
+	GetValuation(ctx context.Context, in *GetValuationRequest, opts ...grpc.CallOption) (*GetValuationResponse, error)
+	// all-or-nothing variants over several products; a rejected batch fails with
+	// FAILED_PRECONDITION carrying a BatchFailure detail
+	ReserveStockBatch(ctx context.Context, in *ReserveStockBatchRequest, opts ...grpc.CallOption) (*ReserveStockBatchResponse, error)
+	FulfillReservationBatch(ctx context.Context, in *FulfillReservationBatchRequest, opts ...grpc.CallOption) (*FulfillReservationBatchResponse, error)
+	ReleaseReservationBatch(ctx context.Context, in *ReleaseReservationBatchRequest, opts ...grpc.CallOption) (*ReleaseReservationBatchResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -77,6 +170,36 @@ func (c *inventoryServiceClient) UpdateStock(ctx context.Context, in *UpdateStoc
 	return out, nil
 }
 
+func (c *inventoryServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReorderPointResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetReorderPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLowStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReserveStockResponse)
@@ -84,192 +207,1537 @@ func (c *inventoryServiceClient) ReserveStock(ctx context.Context, in *ReserveSt
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *inventoryServiceClient) FulfillReservation(ctx context.Context, in *FulfillReservationRequest, opts ...grpc.CallOption) (*FulfillReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FulfillReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_FulfillReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*ReceiveReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceiveReturnResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*GetReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReservationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovementsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetLocation(ctx context.Context, in *GetLocationRequest, opts ...grpc.CallOption) (*GetLocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLocationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLocationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*GetTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ShipTransfer(ctx context.Context, in *ShipTransferRequest, opts ...grpc.CallOption) (*ShipTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipTransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ShipTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*ReceiveTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceiveTransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListStocktakes(ctx context.Context, in *ListStocktakesRequest, opts ...grpc.CallOption) (*ListStocktakesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStocktakesResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListStocktakes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetStocktake(ctx context.Context, in *GetStocktakeRequest, opts ...grpc.CallOption) (*GetStocktakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStocktakeResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetStocktake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateStocktake(ctx context.Context, in *CreateStocktakeRequest, opts ...grpc.CallOption) (*CreateStocktakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateStocktakeResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateStocktake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SubmitStocktakeCounts(ctx context.Context, in *SubmitStocktakeCountsRequest, opts ...grpc.CallOption) (*SubmitStocktakeCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitStocktakeCountsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SubmitStocktakeCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReviewStocktake(ctx context.Context, in *ReviewStocktakeRequest, opts ...grpc.CallOption) (*ReviewStocktakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewStocktakeResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReviewStocktake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) PostStocktake(ctx context.Context, in *PostStocktakeRequest, opts ...grpc.CallOption) (*PostStocktakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostStocktakeResponse)
+	err := c.cc.Invoke(ctx, InventoryService_PostStocktake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelStocktake(ctx context.Context, in *CancelStocktakeRequest, opts ...grpc.CallOption) (*CancelStocktakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelStocktakeResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelStocktake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetShrinkageReport(ctx context.Context, in *GetShrinkageReportRequest, opts ...grpc.CallOption) (*GetShrinkageReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShrinkageReportResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetShrinkageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*ListSuppliersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuppliersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListSuppliers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*GetSupplierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSupplierResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*CreateSupplierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSupplierResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListPurchaseOrders(ctx context.Context, in *ListPurchaseOrdersRequest, opts ...grpc.CallOption) (*ListPurchaseOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurchaseOrdersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListPurchaseOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetPurchaseOrder(ctx context.Context, in *GetPurchaseOrderRequest, opts ...grpc.CallOption) (*GetPurchaseOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPurchaseOrderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetPurchaseOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*CreatePurchaseOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePurchaseOrderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreatePurchaseOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceivePurchaseOrder(ctx context.Context, in *ReceivePurchaseOrderRequest, opts ...grpc.CallOption) (*ReceivePurchaseOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceivePurchaseOrderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceivePurchaseOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelPurchaseOrder(ctx context.Context, in *CancelPurchaseOrderRequest, opts ...grpc.CallOption) (*CancelPurchaseOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelPurchaseOrderResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelPurchaseOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListIncoming(ctx context.Context, in *ListIncomingRequest, opts ...grpc.CallOption) (*ListIncomingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomingResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListIncoming_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLotsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetSerialTracking(ctx context.Context, in *SetSerialTrackingRequest, opts ...grpc.CallOption) (*SetSerialTrackingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSerialTrackingResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetSerialTracking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*ListSerialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSerialsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReserveSerials(ctx context.Context, in *ReserveSerialsRequest, opts ...grpc.CallOption) (*ReserveSerialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveSerialsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReserveSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ImportInventory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportInventoryRequest, ImportInventoryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_ImportInventory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportInventoryRequest, ImportInventoryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_ImportInventoryClient = grpc.ClientStreamingClient[ImportInventoryRequest, ImportInventoryResponse]
+
+func (c *inventoryServiceClient) GetCosting(ctx context.Context, in *GetCostingRequest, opts ...grpc.CallOption) (*GetCostingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCostingResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetCosting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetCostingMethod(ctx context.Context, in *SetCostingMethodRequest, opts ...grpc.CallOption) (*SetCostingMethodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCostingMethodResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetCostingMethod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetValuation(ctx context.Context, in *GetValuationRequest, opts ...grpc.CallOption) (*GetValuationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetValuationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetValuation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReserveStockBatch(ctx context.Context, in *ReserveStockBatchRequest, opts ...grpc.CallOption) (*ReserveStockBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockBatchResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReserveStockBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) FulfillReservationBatch(ctx context.Context, in *FulfillReservationBatchRequest, opts ...grpc.CallOption) (*FulfillReservationBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FulfillReservationBatchResponse)
+	err := c.cc.Invoke(ctx, InventoryService_FulfillReservationBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReleaseReservationBatch(ctx context.Context, in *ReleaseReservationBatchRequest, opts ...grpc.CallOption) (*ReleaseReservationBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationBatchResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReleaseReservationBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InventoryServiceServer is the server API for InventoryService service.
+// All implementations must embed UnimplementedInventoryServiceServer
+// for forward compatibility.
+type InventoryServiceServer interface {
+	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
+	ListInventory(context.Context, *ListInventoryRequest) (*ListInventoryResponse, error)
+	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error)
+	ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	FulfillReservation(context.Context, *FulfillReservationRequest) (*FulfillReservationResponse, error)
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	ReceiveReturn(context.Context, *ReceiveReturnRequest) (*ReceiveReturnResponse, error)
+	GetReservation(context.Context, *GetReservationRequest) (*GetReservationResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error)
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	GetLocation(context.Context, *GetLocationRequest) (*GetLocationResponse, error)
+	CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	GetTransfer(context.Context, *GetTransferRequest) (*GetTransferResponse, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error)
+	// shipping fails with FAILED_PRECONDITION carrying a BatchFailure detail when a line's
+	// source has too few available units
+	ShipTransfer(context.Context, *ShipTransferRequest) (*ShipTransferResponse, error)
+	ReceiveTransfer(context.Context, *ReceiveTransferRequest) (*ReceiveTransferResponse, error)
+	ListStocktakes(context.Context, *ListStocktakesRequest) (*ListStocktakesResponse, error)
+	GetStocktake(context.Context, *GetStocktakeRequest) (*GetStocktakeResponse, error)
+	CreateStocktake(context.Context, *CreateStocktakeRequest) (*CreateStocktakeResponse, error)
+	SubmitStocktakeCounts(context.Context, *SubmitStocktakeCountsRequest) (*SubmitStocktakeCountsResponse, error)
+	ReviewStocktake(context.Context, *ReviewStocktakeRequest) (*ReviewStocktakeResponse, error)
+	// posting fails with FAILED_PRECONDITION carrying a BatchFailure detail when an approved
+	// variance would take an item's stock below zero or its reserved quantity
+	PostStocktake(context.Context, *PostStocktakeRequest) (*PostStocktakeResponse, error)
+	CancelStocktake(context.Context, *CancelStocktakeRequest) (*CancelStocktakeResponse, error)
+	GetShrinkageReport(context.Context, *GetShrinkageReportRequest) (*GetShrinkageReportResponse, error)
+	ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error)
+	GetSupplier(context.Context, *GetSupplierRequest) (*GetSupplierResponse, error)
+	CreateSupplier(context.Context, *CreateSupplierRequest) (*CreateSupplierResponse, error)
+	ListPurchaseOrders(context.Context, *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error)
+	GetPurchaseOrder(context.Context, *GetPurchaseOrderRequest) (*GetPurchaseOrderResponse, error)
+	CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*CreatePurchaseOrderResponse, error)
+	ReceivePurchaseOrder(context.Context, *ReceivePurchaseOrderRequest) (*ReceivePurchaseOrderResponse, error)
+	CancelPurchaseOrder(context.Context, *CancelPurchaseOrderRequest) (*CancelPurchaseOrderResponse, error)
+	ListIncoming(context.Context, *ListIncomingRequest) (*ListIncomingResponse, error)
+	ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error)
+	SetSerialTracking(context.Context, *SetSerialTrackingRequest) (*SetSerialTrackingResponse, error)
+	ListSerials(context.Context, *ListSerialsRequest) (*ListSerialsResponse, error)
+	ReserveSerials(context.Context, *ReserveSerialsRequest) (*ReserveSerialsResponse, error)
+	// sets the stock of the streamed rows; options are read from the first message
+	ImportInventory(grpc.ClientStreamingServer[ImportInventoryRequest, ImportInventoryResponse]) error
+	GetCosting(context.Context, *GetCostingRequest) (*GetCostingResponse, error)
+	SetCostingMethod(context.Context, *SetCostingMethodRequest) (*SetCostingMethodResponse, error)
+	GetValuation(context.Context, *GetValuationRequest) (*GetValuationResponse, error)
+	// all-or-nothing variants over several products; a rejected batch fails with
+	// FAILED_PRECONDITION carrying a BatchFailure detail
+	ReserveStockBatch(context.Context, *ReserveStockBatchRequest) (*ReserveStockBatchResponse, error)
+	FulfillReservationBatch(context.Context, *FulfillReservationBatchRequest) (*FulfillReservationBatchResponse, error)
+	ReleaseReservationBatch(context.Context, *ReleaseReservationBatchRequest) (*ReleaseReservationBatchResponse, error)
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+// UnimplementedInventoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryServiceServer struct{}
+
+func (UnimplementedInventoryServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListInventory(context.Context, *ListInventoryRequest) (*ListInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReorderPoint not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLowStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) FulfillReservation(context.Context, *FulfillReservationRequest) (*FulfillReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfillReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveReturn(context.Context, *ReceiveReturnRequest) (*ReceiveReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturn not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetReservation(context.Context, *GetReservationRequest) (*GetReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovements not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocations not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetLocation(context.Context, *GetLocationRequest) (*GetLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetTransfer(context.Context, *GetTransferRequest) (*GetTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) ShipTransfer(context.Context, *ShipTransferRequest) (*ShipTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveTransfer(context.Context, *ReceiveTransferRequest) (*ReceiveTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListStocktakes(context.Context, *ListStocktakesRequest) (*ListStocktakesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStocktakes not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetStocktake(context.Context, *GetStocktakeRequest) (*GetStocktakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStocktake not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateStocktake(context.Context, *CreateStocktakeRequest) (*CreateStocktakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStocktake not implemented")
+}
+func (UnimplementedInventoryServiceServer) SubmitStocktakeCounts(context.Context, *SubmitStocktakeCountsRequest) (*SubmitStocktakeCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitStocktakeCounts not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReviewStocktake(context.Context, *ReviewStocktakeRequest) (*ReviewStocktakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewStocktake not implemented")
+}
+func (UnimplementedInventoryServiceServer) PostStocktake(context.Context, *PostStocktakeRequest) (*PostStocktakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostStocktake not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelStocktake(context.Context, *CancelStocktakeRequest) (*CancelStocktakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelStocktake not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetShrinkageReport(context.Context, *GetShrinkageReportRequest) (*GetShrinkageReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShrinkageReport not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetSupplier(context.Context, *GetSupplierRequest) (*GetSupplierResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateSupplier(context.Context, *CreateSupplierRequest) (*CreateSupplierResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListPurchaseOrders(context.Context, *ListPurchaseOrdersRequest) (*ListPurchaseOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurchaseOrders not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetPurchaseOrder(context.Context, *GetPurchaseOrderRequest) (*GetPurchaseOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*CreatePurchaseOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceivePurchaseOrder(context.Context, *ReceivePurchaseOrderRequest) (*ReceivePurchaseOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceivePurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelPurchaseOrder(context.Context, *CancelPurchaseOrderRequest) (*CancelPurchaseOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPurchaseOrder not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListIncoming(context.Context, *ListIncomingRequest) (*ListIncomingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncoming not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLots not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetSerialTracking(context.Context, *SetSerialTrackingRequest) (*SetSerialTrackingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSerialTracking not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSerials(context.Context, *ListSerialsRequest) (*ListSerialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReserveSerials(context.Context, *ReserveSerialsRequest) (*ReserveSerialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ImportInventory(grpc.ClientStreamingServer[ImportInventoryRequest, ImportInventoryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetCosting(context.Context, *GetCostingRequest) (*GetCostingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCosting not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetCostingMethod(context.Context, *SetCostingMethodRequest) (*SetCostingMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCostingMethod not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetValuation(context.Context, *GetValuationRequest) (*GetValuationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValuation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReserveStockBatch(context.Context, *ReserveStockBatchRequest) (*ReserveStockBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStockBatch not implemented")
+}
+func (UnimplementedInventoryServiceServer) FulfillReservationBatch(context.Context, *FulfillReservationBatchRequest) (*FulfillReservationBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfillReservationBatch not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReleaseReservationBatch(context.Context, *ReleaseReservationBatchRequest) (*ReleaseReservationBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservationBatch not implemented")
+}
+func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
+func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServiceServer will
+// result in compilation errors.
+type UnsafeInventoryServiceServer interface {
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InventoryService_ServiceDesc, srv)
+}
+
+func _InventoryService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListInventory(ctx, req.(*ListInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateStock(ctx, req.(*UpdateStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SetReorderPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReorderPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetReorderPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, req.(*SetReorderPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListLowStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLowStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLowStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLowStock(ctx, req.(*ListLowStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_FulfillReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).FulfillReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_FulfillReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).FulfillReservation(ctx, req.(*FulfillReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReceiveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveReturn(ctx, req.(*ReceiveReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetReservation(ctx, req.(*GetReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLocations(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetLocation(ctx, req.(*GetLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateLocation(ctx, req.(*CreateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetTransfer(ctx, req.(*GetTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ShipTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ShipTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ShipTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ShipTransfer(ctx, req.(*ShipTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReceiveTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveTransfer(ctx, req.(*ReceiveTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListStocktakes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStocktakesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListStocktakes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListStocktakes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListStocktakes(ctx, req.(*ListStocktakesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetStocktake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStocktakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetStocktake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetStocktake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetStocktake(ctx, req.(*GetStocktakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateStocktake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStocktakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateStocktake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateStocktake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateStocktake(ctx, req.(*CreateStocktakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SubmitStocktakeCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitStocktakeCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SubmitStocktakeCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SubmitStocktakeCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SubmitStocktakeCounts(ctx, req.(*SubmitStocktakeCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReviewStocktake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewStocktakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReviewStocktake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReviewStocktake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReviewStocktake(ctx, req.(*ReviewStocktakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_PostStocktake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostStocktakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).PostStocktake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_PostStocktake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).PostStocktake(ctx, req.(*PostStocktakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelStocktake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelStocktakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelStocktake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelStocktake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelStocktake(ctx, req.(*CancelStocktakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetShrinkageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShrinkageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetShrinkageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetShrinkageReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetShrinkageReport(ctx, req.(*GetShrinkageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSuppliers(ctx, req.(*ListSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetSupplier(ctx, req.(*GetSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListPurchaseOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurchaseOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListPurchaseOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListPurchaseOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListPurchaseOrders(ctx, req.(*ListPurchaseOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetPurchaseOrder(ctx, req.(*GetPurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreatePurchaseOrder(ctx, req.(*CreatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *inventoryServiceClient) FulfillReservation(ctx context.Context, in *FulfillReservationRequest, opts ...grpc.CallOption) (*FulfillReservationResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(FulfillReservationResponse)
-	err := c.cc.Invoke(ctx, InventoryService_FulfillReservation_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _InventoryService_ReceivePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceivePurchaseOrderRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceivePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceivePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceivePurchaseOrder(ctx, req.(*ReceivePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *inventoryServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ReleaseReservationResponse)
-	err := c.cc.Invoke(ctx, InventoryService_ReleaseReservation_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _InventoryService_CancelPurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPurchaseOrderRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelPurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelPurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelPurchaseOrder(ctx, req.(*CancelPurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// InventoryServiceServer is the server API for InventoryService service.
-// All implementations must embed UnimplementedInventoryServiceServer
-// for forward compatibility.
-type InventoryServiceServer interface {
-	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
-	ListInventory(context.Context, *ListInventoryRequest) (*ListInventoryResponse, error)
-	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
-	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
-	FulfillReservation(context.Context, *FulfillReservationRequest) (*FulfillReservationResponse, error)
-	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
-	mustEmbedUnimplementedInventoryServiceServer()
+func _InventoryService_ListIncoming_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListIncoming(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListIncoming_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListIncoming(ctx, req.(*ListIncomingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedInventoryServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedInventoryServiceServer struct{}
-
-func (UnimplementedInventoryServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
-}
-func (UnimplementedInventoryServiceServer) ListInventory(context.Context, *ListInventoryRequest) (*ListInventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListInventory not implemented")
-}
-func (UnimplementedInventoryServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
-}
-func (UnimplementedInventoryServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
-}
-func (UnimplementedInventoryServiceServer) FulfillReservation(context.Context, *FulfillReservationRequest) (*FulfillReservationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FulfillReservation not implemented")
+func _InventoryService_ListLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLots(ctx, req.(*ListLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+
+func _InventoryService_SetSerialTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSerialTrackingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetSerialTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetSerialTracking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetSerialTracking(ctx, req.(*SetSerialTrackingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
-func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to InventoryServiceServer will
-// result in compilation errors.
-type UnsafeInventoryServiceServer interface {
-	mustEmbedUnimplementedInventoryServiceServer()
+func _InventoryService_ListSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSerials(ctx, req.(*ListSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
-	// If the following call pancis, it indicates UnimplementedInventoryServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _InventoryService_ReserveSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&InventoryService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReserveSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReserveSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReserveSerials(ctx, req.(*ReserveSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInventoryRequest)
+func _InventoryService_ImportInventory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InventoryServiceServer).ImportInventory(&grpc.GenericServerStream[ImportInventoryRequest, ImportInventoryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_ImportInventoryServer = grpc.ClientStreamingServer[ImportInventoryRequest, ImportInventoryResponse]
+
+func _InventoryService_GetCosting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCostingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetInventory(ctx, in)
+		return srv.(InventoryServiceServer).GetCosting(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetInventory_FullMethodName,
+		FullMethod: InventoryService_GetCosting_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+		return srv.(InventoryServiceServer).GetCosting(ctx, req.(*GetCostingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ListInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListInventoryRequest)
+func _InventoryService_SetCostingMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCostingMethodRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ListInventory(ctx, in)
+		return srv.(InventoryServiceServer).SetCostingMethod(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ListInventory_FullMethodName,
+		FullMethod: InventoryService_SetCostingMethod_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ListInventory(ctx, req.(*ListInventoryRequest))
+		return srv.(InventoryServiceServer).SetCostingMethod(ctx, req.(*SetCostingMethodRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateStockRequest)
+func _InventoryService_GetValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValuationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateStock(ctx, in)
+		return srv.(InventoryServiceServer).GetValuation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateStock_FullMethodName,
+		FullMethod: InventoryService_GetValuation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateStock(ctx, req.(*UpdateStockRequest))
+		return srv.(InventoryServiceServer).GetValuation(ctx, req.(*GetValuationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveStockRequest)
+func _InventoryService_ReserveStockBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockBatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ReserveStock(ctx, in)
+		return srv.(InventoryServiceServer).ReserveStockBatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ReserveStock_FullMethodName,
+		FullMethod: InventoryService_ReserveStockBatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+		return srv.(InventoryServiceServer).ReserveStockBatch(ctx, req.(*ReserveStockBatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_FulfillReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FulfillReservationRequest)
+func _InventoryService_FulfillReservationBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillReservationBatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).FulfillReservation(ctx, in)
+		return srv.(InventoryServiceServer).FulfillReservationBatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_FulfillReservation_FullMethodName,
+		FullMethod: InventoryService_FulfillReservationBatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).FulfillReservation(ctx, req.(*FulfillReservationRequest))
+		return srv.(InventoryServiceServer).FulfillReservationBatch(ctx, req.(*FulfillReservationBatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReleaseReservationRequest)
+func _InventoryService_ReleaseReservationBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationBatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).ReleaseReservation(ctx, in)
+		return srv.(InventoryServiceServer).ReleaseReservationBatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_ReleaseReservation_FullMethodName,
+		FullMethod: InventoryService_ReleaseReservationBatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+		return srv.(InventoryServiceServer).ReleaseReservationBatch(ctx, req.(*ReleaseReservationBatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -293,6 +1761,18 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateStock",
 			Handler:    _InventoryService_UpdateStock_Handler,
 		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _InventoryService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "SetReorderPoint",
+			Handler:    _InventoryService_SetReorderPoint_Handler,
+		},
+		{
+			MethodName: "ListLowStock",
+			Handler:    _InventoryService_ListLowStock_Handler,
+		},
 		{
 			MethodName: "ReserveStock",
 			Handler:    _InventoryService_ReserveStock_Handler,
@@ -305,7 +1785,169 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseReservation",
 			Handler:    _InventoryService_ReleaseReservation_Handler,
 		},
+		{
+			MethodName: "ReceiveReturn",
+			Handler:    _InventoryService_ReceiveReturn_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _InventoryService_GetReservation_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _InventoryService_ListReservations_Handler,
+		},
+		{
+			MethodName: "ListMovements",
+			Handler:    _InventoryService_ListMovements_Handler,
+		},
+		{
+			MethodName: "ListLocations",
+			Handler:    _InventoryService_ListLocations_Handler,
+		},
+		{
+			MethodName: "GetLocation",
+			Handler:    _InventoryService_GetLocation_Handler,
+		},
+		{
+			MethodName: "CreateLocation",
+			Handler:    _InventoryService_CreateLocation_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _InventoryService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "GetTransfer",
+			Handler:    _InventoryService_GetTransfer_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _InventoryService_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "ShipTransfer",
+			Handler:    _InventoryService_ShipTransfer_Handler,
+		},
+		{
+			MethodName: "ReceiveTransfer",
+			Handler:    _InventoryService_ReceiveTransfer_Handler,
+		},
+		{
+			MethodName: "ListStocktakes",
+			Handler:    _InventoryService_ListStocktakes_Handler,
+		},
+		{
+			MethodName: "GetStocktake",
+			Handler:    _InventoryService_GetStocktake_Handler,
+		},
+		{
+			MethodName: "CreateStocktake",
+			Handler:    _InventoryService_CreateStocktake_Handler,
+		},
+		{
+			MethodName: "SubmitStocktakeCounts",
+			Handler:    _InventoryService_SubmitStocktakeCounts_Handler,
+		},
+		{
+			MethodName: "ReviewStocktake",
+			Handler:    _InventoryService_ReviewStocktake_Handler,
+		},
+		{
+			MethodName: "PostStocktake",
+			Handler:    _InventoryService_PostStocktake_Handler,
+		},
+		{
+			MethodName: "CancelStocktake",
+			Handler:    _InventoryService_CancelStocktake_Handler,
+		},
+		{
+			MethodName: "GetShrinkageReport",
+			Handler:    _InventoryService_GetShrinkageReport_Handler,
+		},
+		{
+			MethodName: "ListSuppliers",
+			Handler:    _InventoryService_ListSuppliers_Handler,
+		},
+		{
+			MethodName: "GetSupplier",
+			Handler:    _InventoryService_GetSupplier_Handler,
+		},
+		{
+			MethodName: "CreateSupplier",
+			Handler:    _InventoryService_CreateSupplier_Handler,
+		},
+		{
+			MethodName: "ListPurchaseOrders",
+			Handler:    _InventoryService_ListPurchaseOrders_Handler,
+		},
+		{
+			MethodName: "GetPurchaseOrder",
+			Handler:    _InventoryService_GetPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "CreatePurchaseOrder",
+			Handler:    _InventoryService_CreatePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "ReceivePurchaseOrder",
+			Handler:    _InventoryService_ReceivePurchaseOrder_Handler,
+		},
+		{
+			MethodName: "CancelPurchaseOrder",
+			Handler:    _InventoryService_CancelPurchaseOrder_Handler,
+		},
+		{
+			MethodName: "ListIncoming",
+			Handler:    _InventoryService_ListIncoming_Handler,
+		},
+		{
+			MethodName: "ListLots",
+			Handler:    _InventoryService_ListLots_Handler,
+		},
+		{
+			MethodName: "SetSerialTracking",
+			Handler:    _InventoryService_SetSerialTracking_Handler,
+		},
+		{
+			MethodName: "ListSerials",
+			Handler:    _InventoryService_ListSerials_Handler,
+		},
+		{
+			MethodName: "ReserveSerials",
+			Handler:    _InventoryService_ReserveSerials_Handler,
+		},
+		{
+			MethodName: "GetCosting",
+			Handler:    _InventoryService_GetCosting_Handler,
+		},
+		{
+			MethodName: "SetCostingMethod",
+			Handler:    _InventoryService_SetCostingMethod_Handler,
+		},
+		{
+			MethodName: "GetValuation",
+			Handler:    _InventoryService_GetValuation_Handler,
+		},
+		{
+			MethodName: "ReserveStockBatch",
+			Handler:    _InventoryService_ReserveStockBatch_Handler,
+		},
+		{
+			MethodName: "FulfillReservationBatch",
+			Handler:    _InventoryService_FulfillReservationBatch_Handler,
+		},
+		{
+			MethodName: "ReleaseReservationBatch",
+			Handler:    _InventoryService_ReleaseReservationBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportInventory",
+			Handler:       _InventoryService_ImportInventory_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/inventory/inventory.proto",
 }
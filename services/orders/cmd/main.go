@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 
 	"fmt"
@@ -11,7 +12,9 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
+	orders_client "orders-service/internal/client"
 	orders_controller "orders-service/internal/controller"
 	orders_handler_http "orders-service/internal/handler"
 	orders_repository "orders-service/internal/repository"
@@ -69,13 +72,23 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
 func main() {
 	var err error
-	//var ctx context.Context
+	var ctx context.Context
+	var cancel context.CancelFunc
 
 	var port int
 	var grpcPort int
 	var datarepo *orders_repository.DataRepo_Orders
+	var inventoryClient *orders_client.Client_Inventory
 	var controller *orders_controller.Controller_Orders
 	var handler *orders_handler_http.Handler_Orders
 	var grpcHandler *orders_handler_http.Handler_Orders_GRPC
@@ -115,20 +128,43 @@ func main() {
 		inventoryAddr = "inventory-service:9002"
 	}
 
+	// create order saga recovery settings
+	sagaRecoveryInterval := getEnvDuration("SAGA_RECOVERY_INTERVAL", 30*time.Second)
+	sagaStaleAfter := getEnvDuration("SAGA_STALE_AFTER", time.Minute)
+
+	// pending orders older than the reservation TTL are expired and their stock released
+	reservationTTL := getEnvDuration("RESERVATION_TTL", 30*time.Minute)
+	reservationExpiryInterval := getEnvDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute)
+
+	// releases of cancelled orders that failed are retried once they are this stale
+	releaseRetryInterval := getEnvDuration("RELEASE_RETRY_INTERVAL", 30*time.Second)
+	releaseStaleAfter := getEnvDuration("RELEASE_STALE_AFTER", time.Minute)
+
+	// retention window of idempotency keys, and how long a request that never finished
+	// (a crash) holds its key before a retry may execute it again
+	idempotencyKeyTTL := getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	idempotencyKeyLease := getEnvDuration("IDEMPOTENCY_KEY_LEASE", time.Minute)
+
 	// setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// initializing context
-	//ctx = context.Background()
+	// initializing context (cancelled on shutdown to stop background workers)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
 	// volatile data repository
 	datarepo = orders_repository.New(db)
+	// inventory gRPC client used by the controller
+	inventoryClient, err = orders_client.NewInventory(inventoryAddr)
+	if err != nil {
+		log.Fatalf("Failed to create inventory client: %v", err)
+	}
 	// controller
-	controller = orders_controller.New(datarepo)
+	controller = orders_controller.New(datarepo, inventoryClient, idempotencyKeyTTL, idempotencyKeyLease)
 	// handler (HTTP still uses consul for backward compatibility, but pass nil now)
 	handler = orders_handler_http.New(controller, nil)
 	// gRPC handler
-	grpcHandler, err = orders_handler_http.NewGRPC(controller, productsAddr, inventoryAddr)
+	grpcHandler, err = orders_handler_http.NewGRPC(controller, productsAddr)
 	if err != nil {
 		log.Fatalf("Failed to create gRPC handler: %v", err)
 	}
@@ -138,12 +174,17 @@ func main() {
 	// service endpoints
 	// -------------------------------------------------------------------
 	r := mux.NewRouter()
+	// the caller of every request is recorded in the order status history
+	r.Use(orders_handler_http.AddActor)
 	// CORS preflight (OPTIONS) requests for all endpoints
-	r.PathPrefix("/orders").Methods(http.MethodOptions).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	preflight := func(w http.ResponseWriter, r *http.Request) {
 		orders_handler_http.AddCORSHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
 		})).ServeHTTP(w, r)
-	})
+	}
+	r.PathPrefix("/orders").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/lots").Methods(http.MethodOptions).HandlerFunc(preflight)
+	r.PathPrefix("/serials").Methods(http.MethodOptions).HandlerFunc(preflight)
 	// GET all orders
 	r.Handle("/orders", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_All))).Methods(http.MethodGet)
 	// GET order by ID
@@ -152,6 +193,24 @@ func main() {
 	r.Handle("/orders", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Order))).Methods(http.MethodPost)
 	// POST fulfill order
 	r.Handle("/orders/{orderId}/fulfill", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Fulfill_Order))).Methods(http.MethodPost)
+	// POST cancel order
+	r.Handle("/orders/{orderId}/cancel", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Cancel_Order))).Methods(http.MethodPost)
+	// PATCH order status
+	r.Handle("/orders/{orderId}/status", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Update_OrderStatus))).Methods(http.MethodPatch)
+	// GET order status history
+	r.Handle("/orders/{orderId}/history", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_OrderHistory))).Methods(http.MethodGet)
+	// GET serialized units shipped with an order
+	r.Handle("/orders/{orderId}/serials", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_OrderSerials))).Methods(http.MethodGet)
+	// POST authorize a return
+	r.Handle("/orders/{orderId}/returns", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Return))).Methods(http.MethodPost)
+	// GET returns of an order
+	r.Handle("/orders/{orderId}/returns", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_Returns))).Methods(http.MethodGet)
+	// POST receive a return
+	r.Handle("/orders/{orderId}/returns/{returnId}/receive", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Receive_Return))).Methods(http.MethodPost)
+	// GET orders a lot went out with (recall tracing, ?product_id= narrows the lot to a product)
+	r.Handle("/lots/{lotNumber}/orders", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_LotShipments))).Methods(http.MethodGet)
+	// GET orders a serialized unit went out with (?product_id= narrows the serial number to a product)
+	r.Handle("/serials/{serialNumber}/orders", orders_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_SerialShipments))).Methods(http.MethodGet)
 	// -------------------------------------------------------------------
 	// Health check endpoint
 	r.Handle("/health", orders_handler_http.AddCORSHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -163,7 +222,7 @@ func main() {
 	// -------------------------------------------------------------------
 	// Start gRPC server
 	// -------------------------------------------------------------------
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(orders_handler_http.ActorInterceptor))
 	pb.RegisterOrderServiceServer(grpcServer, grpcHandler)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
@@ -179,6 +238,19 @@ func main() {
 	}()
 	// -------------------------------------------------------------------
 
+	// -------------------------------------------------------------------
+	// Start background workers
+	// -------------------------------------------------------------------
+	// releases the reservations of create order sagas left unfinished by a crash
+	go controller.Run_SagaRecovery(ctx, sagaRecoveryInterval, sagaStaleAfter)
+	// expires pending orders whose reservations outlived the reservation TTL
+	go controller.Run_ReservationExpiry(ctx, reservationExpiryInterval, reservationTTL)
+	// retries the releases of reserved stock that failed or were interrupted
+	go controller.Run_ReleaseRetry(ctx, releaseRetryInterval, releaseStaleAfter)
+	// deletes idempotency keys past their retention window
+	go controller.Run_IdempotencyPurge(ctx, time.Hour)
+	// -------------------------------------------------------------------
+
 	// -------------------------------------------------------------------
 	// Start HTTP server
 	// -------------------------------------------------------------------
@@ -200,6 +272,7 @@ func main() {
 	// -------------------------------------------------------------------
 	<-sigChan
 	log.Println("Received shutdown signal, shutting down gracefully...")
+	cancel()
 	grpcServer.GracefulStop()
 	log.Println("Servers stopped")
 	// -------------------------------------------------------------------
@@ -8,6 +8,7 @@ require (
 	github.com/lib/pq v1.10.9
 	google.golang.org/grpc v1.77.0
 	google.golang.org/protobuf v1.36.10
+	shared v0.0.0
 )
 
 require (
@@ -16,3 +17,5 @@ require (
 	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251111163417-95abcf5c77ba // indirect
 )
+
+replace shared => ../../shared
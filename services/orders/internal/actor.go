@@ -0,0 +1,24 @@
+package internal
+
+import "context"
+
+// actor recorded in the order status history when a change has no identified caller
+const DefaultActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor returns a context carrying who is performing the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	if actor == "" {
+		actor = DefaultActor
+	}
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns who is performing the request
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok {
+		return actor
+	}
+	return DefaultActor
+}
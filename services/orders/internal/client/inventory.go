@@ -0,0 +1,182 @@
+package orders_client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	internal "orders-service/internal"
+	orders_dmodel "orders-service/pkg"
+	inventory_pb "orders-service/proto/inventory"
+)
+
+// gRPC metadata keys carrying the idempotency key of a request and who performs it
+const (
+	idempotencyKeyMetadata = "idempotency-key"
+	actorMetadata          = "x-actor"
+)
+
+// Client_Inventory
+// thin wrapper over the Inventory service gRPC client, used by the controller
+type Client_Inventory struct {
+	client inventory_pb.InventoryServiceClient
+}
+
+func NewInventory(addr string) (*Client_Inventory, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client_Inventory{
+		client: inventory_pb.NewInventoryServiceClient(conn),
+	}, nil
+}
+
+// attach the idempotency key (if any) to the outgoing request, along with the actor of
+// the request being served, so the inventory records who caused each stock movement
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, actorMetadata, internal.ActorFromContext(ctx))
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, idempotencyKeyMetadata, key)
+}
+
+// tell apart requests the inventory service refused (and did not apply)
+// from failures whose outcome is unknown
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.NotFound, codes.FailedPrecondition, codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", internal.ErrInventoryRejected, describeRejection(status.Convert(err)))
+	}
+	return err
+}
+
+// message of a rejected request, followed by the failed lines of a rejected batch
+func describeRejection(st *status.Status) string {
+	var failures []string
+	for _, detail := range st.Details() {
+		batch, ok := detail.(*inventory_pb.BatchFailure)
+		if !ok {
+			continue
+		}
+		for _, failure := range batch.Failures {
+			if failure.ReservationId != 0 {
+				failures = append(failures, fmt.Sprintf("reservation %d x%d: %s", failure.ReservationId, failure.Quantity, failure.Reason))
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("product %d x%d: %s", failure.ProductId, failure.Quantity, failure.Reason))
+		}
+	}
+	if len(failures) == 0 {
+		return st.Message()
+	}
+
+	return fmt.Sprintf("%s (%s)", st.Message(), strings.Join(failures, "; "))
+}
+
+// -------------------------------------------------------------------
+
+// reserves stock for owner without expiry, returning the id of the reservation
+func (c *Client_Inventory) Reserve_Stock(ctx context.Context, idempotencyKey, owner string, productID, amount_reserved int) (int, error) {
+	res, err := c.client.ReserveStock(withIdempotencyKey(ctx, idempotencyKey), &inventory_pb.ReserveStockRequest{
+		ProductId: int32(productID),
+		Stock:     int32(amount_reserved),
+		Owner:     owner,
+	})
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return int(res.Reservation.GetId()), nil
+}
+
+func (c *Client_Inventory) Release_Reservation(ctx context.Context, idempotencyKey string, reservationID, amount_released int) error {
+	_, err := c.client.ReleaseReservation(withIdempotencyKey(ctx, idempotencyKey), &inventory_pb.ReleaseReservationRequest{
+		ReservationId: int32(reservationID),
+		Stock:         int32(amount_released),
+	})
+	return translateError(err)
+}
+
+// deducts the quantity of every line from its reservation, or from none of them
+// returns the lines with the lots the inventory took their units from and the serialized
+// units it shipped
+func (c *Client_Inventory) Fulfill_ReservationBatch(ctx context.Context, idempotencyKey string, lines []orders_dmodel.OrderItemFulfillment) ([]orders_dmodel.OrderItemFulfillment, error) {
+	pbLines := make([]*inventory_pb.StockLine, len(lines))
+	for i, line := range lines {
+		pbLines[i] = &inventory_pb.StockLine{
+			ReservationId: int32(line.ReservationID),
+			Quantity:      int32(line.Quantity),
+			SerialNumbers: line.SerialNumbers,
+		}
+	}
+
+	res, err := c.client.FulfillReservationBatch(withIdempotencyKey(ctx, idempotencyKey), &inventory_pb.FulfillReservationBatchRequest{
+		Lines: pbLines,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	lots := make(map[int][]orders_dmodel.OrderItemLot)
+	for _, l := range res.Lots {
+		lot := orders_dmodel.OrderItemLot{LotNumber: l.LotNumber, Quantity: int(l.Quantity)}
+		if l.ExpiresAt != "" {
+			expiresAt, err := time.Parse(time.RFC3339, l.ExpiresAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiry date of lot %s: %w", l.LotNumber, err)
+			}
+			lot.ExpiresAt = &expiresAt
+		}
+		lots[int(l.ReservationId)] = append(lots[int(l.ReservationId)], lot)
+	}
+	serials := make(map[int][]string)
+	for _, s := range res.Serials {
+		serials[int(s.ReservationId)] = append(serials[int(s.ReservationId)], s.SerialNumber)
+	}
+
+	shipped := slices.Clone(lines)
+	for i := range shipped {
+		shipped[i].Lots = lots[shipped[i].ReservationID]
+		shipped[i].SerialNumbers = serials[shipped[i].ReservationID]
+	}
+	return shipped, nil
+}
+
+// reference is recorded with the inventory's stock movement; serials and damagedSerials
+// name the restocked and damaged serialized units that came back; the restocked units go
+// back to lots
+func (c *Client_Inventory) Receive_Return(ctx context.Context, idempotencyKey, reference string, productID, restocked, damaged int, serials, damagedSerials []string, lots []orders_dmodel.OrderItemLot) error {
+	pbLots := make([]*inventory_pb.LotQuantity, len(lots))
+	for i, lot := range lots {
+		pbLots[i] = &inventory_pb.LotQuantity{LotNumber: lot.LotNumber, Quantity: int32(lot.Quantity)}
+		if lot.ExpiresAt != nil {
+			pbLots[i].ExpiresAt = lot.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+
+	_, err := c.client.ReceiveReturn(withIdempotencyKey(ctx, idempotencyKey), &inventory_pb.ReceiveReturnRequest{
+		ProductId:            int32(productID),
+		Restock:              int32(restocked),
+		Damaged:              int32(damaged),
+		Reference:            reference,
+		SerialNumbers:        serials,
+		DamagedSerialNumbers: damagedSerials,
+		Lots:                 pbLots,
+	})
+	return translateError(err)
+}
+
+// -------------------------------------------------------------------
@@ -2,24 +2,70 @@ package orders_controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	internal "orders-service/internal"
 	orders_dmodel "orders-service/pkg"
 )
 
 type if_repo_orders interface {
 	Get_All(_ context.Context) ([]*orders_dmodel.Order, error)
 	Get_ByOrderID(_ context.Context, id int) (*orders_dmodel.Order, error)
-	Create_Order(_ context.Context, order *orders_dmodel.Order) (*orders_dmodel.Order, error)
-	Update_OrderStatus(_ context.Context, orderID int, status string) error
+	Update_OrderStatus(_ context.Context, orderID int, from []string, status, actor, reason string) error
+	Cancel_Order(_ context.Context, orderID int, from []string, actor, reason string) error
+	Get_PendingReleases(_ context.Context, orderID int) ([]*orders_dmodel.Release, error)
+	Claim_PendingReleases(_ context.Context, staleBefore time.Time, limit int) ([]*orders_dmodel.Release, error)
+	Update_ReleaseAttempt(_ context.Context, releaseID int, cause error) error
+	Expire_PendingOrders(_ context.Context, createdBefore time.Time, limit int, actor string) ([]*orders_dmodel.Order, error)
+	Get_OrderHistory(_ context.Context, orderID int) ([]orders_dmodel.OrderStatusChange, error)
+	Record_Fulfillment(_ context.Context, orderID int, from []string, status, actor, reason string, lines []orders_dmodel.OrderItemFulfillment) error
+	Get_LotShipments(_ context.Context, lotNumber string, productID int) ([]*orders_dmodel.LotShipment, error)
+	Get_OrderSerials(_ context.Context, orderID int) ([]*orders_dmodel.OrderSerial, error)
+	Get_SerialShipments(_ context.Context, serialNumber string, productID int) ([]*orders_dmodel.SerialShipment, error)
+	// returns
+	Create_Return(_ context.Context, orderID int, from []string, reason string, items []orders_dmodel.ReturnItem) (*orders_dmodel.Return, error)
+	Get_Returns(_ context.Context, orderID int) ([]*orders_dmodel.Return, error)
+	Get_Return(_ context.Context, orderID, returnID int) (*orders_dmodel.Return, error)
+	Receive_Return(_ context.Context, orderID, returnID int, items []orders_dmodel.ReturnItem, actor string) error
+	// create order saga
+	Create_Saga(_ context.Context, customerID int) (int, error)
+	Create_SagaStep(_ context.Context, sagaID, productID, quantity int) (int, error)
+	Update_SagaStepStatus(_ context.Context, stepID int, status string) error
+	Update_SagaStepReserved(_ context.Context, stepID, reservationID int) error
+	Update_SagaStatus(_ context.Context, sagaID int, status, reason string) error
+	Get_SagaSteps(_ context.Context, sagaID int) ([]orders_dmodel.SagaStep, error)
+	Complete_Saga(_ context.Context, sagaID int, order *orders_dmodel.Order, actor string) (*orders_dmodel.Order, error)
+	Claim_StaleSagas(_ context.Context, staleBefore time.Time, limit int) ([]*orders_dmodel.Saga, error)
+	// idempotency keys
+	Begin_IdempotentRequest(_ context.Context, scope, key, requestHash string, expiredBefore time.Time, lease time.Duration) ([]byte, error)
+	Complete_IdempotentRequest(_ context.Context, scope, key string, response []byte) error
+	Delete_IdempotentRequest(_ context.Context, scope, key string) error
+	Purge_IdempotencyKeys(_ context.Context, createdBefore time.Time) (int64, error)
+}
+
+// inventory calls are made with an idempotency key, so they can be retried safely
+type if_inventory interface {
+	Reserve_Stock(_ context.Context, idempotencyKey, owner string, productID, amount_reserved int) (int, error)
+	Release_Reservation(_ context.Context, idempotencyKey string, reservationID, amount_released int) error
+	Fulfill_ReservationBatch(_ context.Context, idempotencyKey string, lines []orders_dmodel.OrderItemFulfillment) ([]orders_dmodel.OrderItemFulfillment, error)
+	Receive_Return(_ context.Context, idempotencyKey, reference string, productID, restocked, damaged int, serials, damagedSerials []string, lots []orders_dmodel.OrderItemLot) error
 }
 
 type Controller_Orders struct {
-	repo if_repo_orders
+	repo      if_repo_orders
+	inventory if_inventory
+	// retention window of idempotency keys, and how long an unfinished request holds its key
+	idempotencyTTL   time.Duration
+	idempotencyLease time.Duration
 }
 
-func New(repo if_repo_orders) *Controller_Orders {
+func New(repo if_repo_orders, inventory if_inventory, idempotencyTTL, idempotencyLease time.Duration) *Controller_Orders {
 	return &Controller_Orders{
-		repo: repo,
+		repo:             repo,
+		inventory:        inventory,
+		idempotencyTTL:   idempotencyTTL,
+		idempotencyLease: idempotencyLease,
 	}
 }
 
@@ -43,8 +89,35 @@ func (c *Controller_Orders) Get_ByOrderID(ctx context.Context, id int) (*orders_
 	return res, nil
 }
 
-func (c *Controller_Orders) Create_Order(ctx context.Context, order *orders_dmodel.Order) (*orders_dmodel.Order, error) {
-	res, err := c.repo.Create_Order(ctx, order)
+// Update_OrderStatus moves an order to a new status, enforcing the order lifecycle
+// the change is recorded in the order's history with the actor found in ctx
+func (c *Controller_Orders) Update_OrderStatus(ctx context.Context, orderID int, status, reason string) error {
+	if !orders_dmodel.IsOrderStatus(status) {
+		return internal.ErrInvalidStatus
+	}
+
+	return c.repo.Update_OrderStatus(ctx, orderID, orders_dmodel.TransitionSources(status), status, internal.ActorFromContext(ctx), reason)
+}
+
+// Set_OrderStatus changes the status of an order on request of a client; statuses
+// with side effects on the inventory must be reached through their own operation
+func (c *Controller_Orders) Set_OrderStatus(ctx context.Context, orderID int, status, reason string) (*orders_dmodel.Order, error) {
+	if !orders_dmodel.IsOrderStatus(status) {
+		return nil, internal.ErrInvalidStatus
+	}
+	if !orders_dmodel.IsManualOrderStatus(status) {
+		return nil, fmt.Errorf("%w: %s", internal.ErrStatusRequiresOperation, status)
+	}
+
+	if err := c.Update_OrderStatus(ctx, orderID, status, reason); err != nil {
+		return nil, err
+	}
+
+	return c.repo.Get_ByOrderID(ctx, orderID)
+}
+
+func (c *Controller_Orders) Get_OrderHistory(ctx context.Context, orderID int) ([]orders_dmodel.OrderStatusChange, error) {
+	res, err := c.repo.Get_OrderHistory(ctx, orderID)
 
 	if err != nil {
 		return nil, err
@@ -53,12 +126,21 @@ func (c *Controller_Orders) Create_Order(ctx context.Context, order *orders_dmod
 	return res, nil
 }
 
-func (c *Controller_Orders) Update_OrderStatus(ctx context.Context, orderID int, status string) error {
-	err := c.repo.Update_OrderStatus(ctx, orderID, status)
+// Cancel_Order cancels an order that still holds its reservations and returns the
+// reserved stock to the inventory
+// the order is marked as cancelled together with the releases of its reserved stock, which
+// are then sent to the inventory; a release that fails is retried by the release worker,
+// and the cancelled order is returned with a warning for every item still reserved
+func (c *Controller_Orders) Cancel_Order(ctx context.Context, orderID int, reason string) (*orders_dmodel.Order, []string, error) {
+	from := orders_dmodel.TransitionSources(orders_dmodel.OrderStatusCancelled)
+	if err := c.repo.Cancel_Order(ctx, orderID, from, internal.ActorFromContext(ctx), reason); err != nil {
+		return nil, nil, err
+	}
 
+	order, err := c.repo.Get_ByOrderID(ctx, orderID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	return nil
+	return order, c.sendReleases(ctx, order), nil
 }
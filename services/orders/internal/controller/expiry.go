@@ -0,0 +1,67 @@
+package orders_controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	internal "orders-service/internal"
+)
+
+// maximum number of orders expired per pass
+const expiryBatchSize = 100
+
+// actor recorded in the history of expired orders, and in the inventory movements of
+// their released reservations
+const expiryActor = "system:reservation-expiry"
+
+// -------------------------------------------------------------------
+// reservation expiry
+// -------------------------------------------------------------------
+
+// Expire_Orders marks pending orders older than ttl as expired, together with the
+// releases of their reserved stock, and sends the releases; the ones that fail are retried
+// by the release worker. It returns the number of orders expired
+func (c *Controller_Orders) Expire_Orders(ctx context.Context, ttl time.Duration) (int, error) {
+	ctx = internal.WithActor(ctx, expiryActor)
+	orders, err := c.repo.Expire_PendingOrders(ctx, time.Now().Add(-ttl), expiryBatchSize, expiryActor)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, order := range orders {
+		if warnings := c.sendReleases(ctx, order); len(warnings) > 0 {
+			log.Printf("Order ID %d expired, reservations not released yet: %v", order.ID, warnings)
+			continue
+		}
+		log.Printf("Order ID %d expired, reservations released", order.ID)
+	}
+
+	return len(orders), nil
+}
+
+// Run_ReservationExpiry expires stale pending orders every interval until the
+// context is cancelled; a full batch is followed immediately by another pass
+func (c *Controller_Orders) Run_ReservationExpiry(ctx context.Context, interval, ttl time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := c.Expire_Orders(ctx, ttl)
+		if err != nil {
+			log.Printf("Error expiring pending orders: %v", err)
+		}
+
+		if n == expiryBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// -------------------------------------------------------------------
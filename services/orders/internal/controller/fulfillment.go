@@ -0,0 +1,193 @@
+package orders_controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	internal "orders-service/internal"
+	orders_dmodel "orders-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// fulfillment
+// -------------------------------------------------------------------
+
+// Fulfill_Order deducts the reserved stock of the requested lines from the inventory
+// (every unfulfilled quantity when no lines are given); the order becomes fulfilled
+// once every line is, and partially_fulfilled until then
+// lines of serial-tracked products name the units shipped, unless the inventory has
+// units picked for their reservations
+func (c *Controller_Orders) Fulfill_Order(ctx context.Context, orderID int, lines []orders_dmodel.FulfillmentLine) (*orders_dmodel.Order, error) {
+	order, err := c.repo.Get_ByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orders_dmodel.CanTransition(order.Status, orders_dmodel.OrderStatusPartiallyFulfilled) &&
+		!orders_dmodel.CanTransition(order.Status, orders_dmodel.OrderStatusFulfilled) {
+		return nil, fmt.Errorf("%w: order %d is %s, cannot be fulfilled", internal.ErrInvalidStatusTransition, order.ID, order.Status)
+	}
+
+	wave, err := planFulfillment(order, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	// record whatever the inventory deducted even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+
+	// the whole wave is deducted in one all-or-nothing inventory call
+	// its idempotency key is derived from the order's fulfilled quantity after the wave,
+	// so retrying a wave that failed to be recorded replays the deduction instead of
+	// repeating it
+	fulfilled := 0
+	for _, line := range wave {
+		fulfilled += line.Quantity
+	}
+	for _, item := range order.Items {
+		fulfilled += item.FulfilledQuantity
+	}
+
+	key := fmt.Sprintf("order-%d-fulfill-%d", order.ID, fulfilled)
+	// the lots and serialized units the inventory shipped are recorded with the lines, to
+	// trace a recall or a unit back to the order
+	shipped, err := c.inventory.Fulfill_ReservationBatch(ctx, key, wave)
+	if err != nil {
+		log.Printf("Order %d: failed to fulfill %s: %v", order.ID, describeFulfillment(wave), err)
+		return nil, fmt.Errorf("%w: order %d: %w", internal.ErrFulfillmentFailed, order.ID, err)
+	}
+	wave = shipped
+
+	status := fulfillmentStatus(order, wave)
+	from := orders_dmodel.TransitionSources(status)
+	if err := c.repo.Record_Fulfillment(ctx, orderID, from, status, internal.ActorFromContext(ctx), "fulfilled "+describeFulfillment(wave), wave); err != nil {
+		return nil, err
+	}
+
+	return c.repo.Get_ByOrderID(ctx, orderID)
+}
+
+// spread the requested quantities, and the serial numbers of the units, over the order's
+// lines, in line order
+func planFulfillment(order *orders_dmodel.Order, lines []orders_dmodel.FulfillmentLine) ([]orders_dmodel.OrderItemFulfillment, error) {
+	planned := make([]int, len(order.Items))
+	serials := make([][]string, len(order.Items))
+
+	if len(lines) == 0 {
+		for i, item := range order.Items {
+			planned[i] = item.Unfulfilled()
+		}
+	}
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of product %d must be positive", internal.ErrInvalidFulfillment, line.ProductID)
+		}
+		if len(line.SerialNumbers) > 0 && len(line.SerialNumbers) != line.Quantity {
+			return nil, fmt.Errorf("%w: %d serial numbers for %d units of product %d", internal.ErrInvalidFulfillment, len(line.SerialNumbers), line.Quantity, line.ProductID)
+		}
+
+		remaining := line.Quantity
+		for i, item := range order.Items {
+			if item.ProductID != line.ProductID {
+				continue
+			}
+			n := min(remaining, item.Unfulfilled()-planned[i])
+			if len(line.SerialNumbers) > 0 {
+				taken := line.Quantity - remaining
+				serials[i] = append(serials[i], line.SerialNumbers[taken:taken+n]...)
+			}
+			planned[i] += n
+			remaining -= n
+		}
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d exceed its unfulfilled quantity", internal.ErrInvalidFulfillment, line.Quantity, line.ProductID)
+		}
+	}
+
+	var wave []orders_dmodel.OrderItemFulfillment
+	for i, item := range order.Items {
+		if planned[i] == 0 {
+			continue
+		}
+		if item.ReservationID == 0 {
+			return nil, fmt.Errorf("%w: no reservation recorded for product %d of order %d", internal.ErrInvalidFulfillment, item.ProductID, order.ID)
+		}
+		wave = append(wave, orders_dmodel.OrderItemFulfillment{
+			ItemID:        item.ID,
+			ProductID:     item.ProductID,
+			ReservationID: item.ReservationID,
+			Fulfilled:     item.FulfilledQuantity,
+			Quantity:      planned[i],
+			SerialNumbers: serials[i],
+		})
+	}
+	if len(wave) == 0 {
+		return nil, fmt.Errorf("%w: order %d has nothing left to fulfill", internal.ErrInvalidFulfillment, order.ID)
+	}
+
+	return wave, nil
+}
+
+// status of the order once the given lines are fulfilled
+func fulfillmentStatus(order *orders_dmodel.Order, done []orders_dmodel.OrderItemFulfillment) string {
+	for _, item := range order.Items {
+		unfulfilled := item.Unfulfilled()
+		for _, line := range done {
+			if line.ItemID == item.ID {
+				unfulfilled -= line.Quantity
+			}
+		}
+		if unfulfilled > 0 {
+			return orders_dmodel.OrderStatusPartiallyFulfilled
+		}
+	}
+
+	return orders_dmodel.OrderStatusFulfilled
+}
+
+// lines of a wave as recorded in the status history, e.g. "product 1 x2, product 3 x1"
+func describeFulfillment(wave []orders_dmodel.OrderItemFulfillment) string {
+	parts := make([]string, len(wave))
+	for i, line := range wave {
+		parts[i] = fmt.Sprintf("product %d x%d", line.ProductID, line.Quantity)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Get_LotShipments lists the orders a lot of a product (of any product when productID is
+// 0) went out with, and their customers, to trace a recall
+func (c *Controller_Orders) Get_LotShipments(ctx context.Context, lotNumber string, productID int) ([]*orders_dmodel.LotShipment, error) {
+	lotNumber = strings.TrimSpace(lotNumber)
+	if lotNumber == "" || productID < 0 {
+		return nil, internal.ErrInvalidLot
+	}
+
+	return c.repo.Get_LotShipments(ctx, lotNumber, productID)
+}
+
+// Get_OrderSerials lists the serialized units shipped with an order
+func (c *Controller_Orders) Get_OrderSerials(ctx context.Context, orderID int) ([]*orders_dmodel.OrderSerial, error) {
+	res, err := c.repo.Get_OrderSerials(ctx, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Get_SerialShipments lists the orders a serialized unit (of a product, or of any product
+// when productID is 0) went out with, and their customers
+func (c *Controller_Orders) Get_SerialShipments(ctx context.Context, serialNumber string, productID int) ([]*orders_dmodel.SerialShipment, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" || productID < 0 {
+		return nil, internal.ErrInvalidSerial
+	}
+
+	return c.repo.Get_SerialShipments(ctx, serialNumber, productID)
+}
+
+// -------------------------------------------------------------------
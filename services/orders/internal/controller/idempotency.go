@@ -0,0 +1,74 @@
+package orders_controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	orders_dmodel "orders-service/pkg"
+	"shared/idempotency"
+)
+
+// operations protected by idempotency keys
+const scopeCreateOrder = "orders.create_order"
+
+// -------------------------------------------------------------------
+// idempotent requests
+// -------------------------------------------------------------------
+
+// Create_OrderIdempotent creates an order at most once per idempotency key; a repeated
+// request returns the order created by the first one (replayed is then true)
+func (c *Controller_Orders) Create_OrderIdempotent(ctx context.Context, key string, order *orders_dmodel.Order) (*orders_dmodel.Order, bool, error) {
+	// the fingerprint only covers what the client sent, not the prices looked up for it
+	type requestItem struct {
+		ProductID int `json:"product_id"`
+		Quantity  int `json:"quantity"`
+	}
+	request := struct {
+		CustomerID int           `json:"customer_id"`
+		Items      []requestItem `json:"items"`
+	}{CustomerID: order.CustomerID}
+	for _, item := range order.Items {
+		request.Items = append(request.Items, requestItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+
+	return runIdempotent(ctx, c, scopeCreateOrder, key, request, func() (*orders_dmodel.Order, error) {
+		return c.Create_Order(ctx, order)
+	})
+}
+
+// runIdempotent applies an operation at most once per idempotency key (see idempotency.Run),
+// with the controller's retention window and lease
+func runIdempotent[T any](ctx context.Context, c *Controller_Orders, scope, key string, request any, run func() (T, error)) (T, bool, error) {
+	return idempotency.Run(ctx, c.repo, c.idempotencyTTL, c.idempotencyLease, scope, key, request, run)
+}
+
+// -------------------------------------------------------------------
+// idempotency key retention
+// -------------------------------------------------------------------
+
+// Run_IdempotencyPurge deletes idempotency keys older than the retention window
+// every interval until the context is cancelled
+func (c *Controller_Orders) Run_IdempotencyPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := c.repo.Purge_IdempotencyKeys(ctx, time.Now().Add(-c.idempotencyTTL))
+		if err != nil {
+			log.Printf("Error purging idempotency keys: %v", err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Purged %d expired idempotency keys", n)
+		}
+	}
+}
+
+// -------------------------------------------------------------------
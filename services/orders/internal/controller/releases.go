@@ -0,0 +1,115 @@
+package orders_controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	internal "orders-service/internal"
+	orders_dmodel "orders-service/pkg"
+)
+
+// maximum number of pending releases retried per pass
+const releaseBatchSize = 100
+
+// actor recorded in the inventory movements of the releases retried by the release worker
+const releaseActor = "system:release-retry"
+
+// -------------------------------------------------------------------
+// reservation releases
+// -------------------------------------------------------------------
+
+// send the releases recorded for an order that was cancelled or expired to the inventory;
+// the ones that fail stay pending for the release worker
+// it returns a warning for every item whose stock is still reserved: the ones the release
+// worker retries, and the ones without a recorded reservation, which need manual attention
+func (c *Controller_Orders) sendReleases(ctx context.Context, order *orders_dmodel.Order) []string {
+	// release the reservations even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+
+	var warnings []string
+	for _, item := range order.Items {
+		// items of orders placed before reservations were recorded have no reservation to release
+		if item.Unfulfilled() > 0 && item.ReservationID == 0 {
+			log.Printf("Order %d: no reservation recorded for %d units of product %d, release them manually", order.ID, item.Unfulfilled(), item.ProductID)
+			warnings = append(warnings, fmt.Sprintf("%d units of product %d have no recorded reservation and must be released manually", item.Unfulfilled(), item.ProductID))
+		}
+	}
+
+	releases, err := c.repo.Get_PendingReleases(ctx, order.ID)
+	if err != nil {
+		log.Printf("Order %d: failed to load the pending releases: %v", order.ID, err)
+		return append(warnings, "the reserved stock will be released by the release worker")
+	}
+	for _, release := range releases {
+		if err := c.sendRelease(ctx, release); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%d units of product %d are still reserved, the release will be retried", release.Quantity, release.ProductID))
+		}
+	}
+
+	return warnings
+}
+
+// release the reservation of a pending release and record the attempt
+// the call is keyed by the order item, so a release retried after a crash is never applied twice
+func (c *Controller_Orders) sendRelease(ctx context.Context, release *orders_dmodel.Release) error {
+	key := fmt.Sprintf("order-%d-item-%d-release", release.OrderID, release.OrderItemID)
+	err := c.inventory.Release_Reservation(ctx, key, release.ReservationID, release.Quantity)
+	if err != nil {
+		log.Printf("Order %d: failed to release %d units of product %d: %v", release.OrderID, release.Quantity, release.ProductID, err)
+	}
+
+	// a release whose outcome cannot be recorded stays pending and is replayed
+	if recordErr := c.repo.Update_ReleaseAttempt(ctx, release.ID, err); recordErr != nil {
+		log.Printf("Order %d: failed to record the release of product %d: %v", release.OrderID, release.ProductID, recordErr)
+	}
+
+	return err
+}
+
+// Retry_Releases sends the pending releases that have not been attempted for longer than
+// staleAfter (e.g. after a failure or a crash) to the inventory again; it returns the
+// number of releases retried
+func (c *Controller_Orders) Retry_Releases(ctx context.Context, staleAfter time.Duration) (int, error) {
+	ctx = internal.WithActor(ctx, releaseActor)
+	releases, err := c.repo.Claim_PendingReleases(ctx, time.Now().Add(-staleAfter), releaseBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, release := range releases {
+		if err := c.sendRelease(ctx, release); err != nil {
+			continue
+		}
+		log.Printf("Order %d: released %d units of product %d after %d attempts", release.OrderID, release.Quantity, release.ProductID, release.Attempts+1)
+	}
+
+	return len(releases), nil
+}
+
+// Run_ReleaseRetry retries pending releases every interval until the context is
+// cancelled; a full batch is followed immediately by another pass
+func (c *Controller_Orders) Run_ReleaseRetry(ctx context.Context, interval, staleAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := c.Retry_Releases(ctx, staleAfter)
+		if err != nil {
+			log.Printf("Error retrying reservation releases: %v", err)
+		}
+
+		if n == releaseBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// -------------------------------------------------------------------
@@ -0,0 +1,327 @@
+package orders_controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	internal "orders-service/internal"
+	orders_dmodel "orders-service/pkg"
+)
+
+// -------------------------------------------------------------------
+// returns
+// -------------------------------------------------------------------
+
+// Create_Return authorizes the return of fulfilled quantities of an order (every
+// quantity not returned yet when no lines are given)
+func (c *Controller_Orders) Create_Return(ctx context.Context, orderID int, lines []orders_dmodel.ReturnLine, reason string) (*orders_dmodel.Return, error) {
+	order, err := c.repo.Get_ByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// orders can be returned from the statuses that may become returned
+	from := orders_dmodel.TransitionSources(orders_dmodel.OrderStatusReturned)
+	if !slices.Contains(from, order.Status) {
+		return nil, fmt.Errorf("%w: order %d is %s", internal.ErrInvalidReturn, order.ID, order.Status)
+	}
+
+	items, err := planReturn(order, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Create_Return(ctx, orderID, from, reason, items)
+}
+
+func (c *Controller_Orders) Get_Returns(ctx context.Context, orderID int) ([]*orders_dmodel.Return, error) {
+	res, err := c.repo.Get_Returns(ctx, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Receive_Return takes back the units of an authorized return: the damaged quantities
+// are counted as such by the inventory and everything else is restocked
+// the serialized units that came back are named, the restocked ones (serials) apart from
+// the damaged ones (damagedSerials), one for each unit of a line that shipped serial numbers
+// the restocked units go back to the lots they came from (lots), which the lines of the
+// return must have shipped
+func (c *Controller_Orders) Receive_Return(ctx context.Context, orderID, returnID int, damaged []orders_dmodel.ReturnLine, serials, damagedSerials []string, lots []orders_dmodel.ReturnLot) (*orders_dmodel.Return, error) {
+	ret, err := c.repo.Get_Return(ctx, orderID, returnID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != orders_dmodel.ReturnStatusAuthorized {
+		return nil, internal.ErrReturnNotAuthorized
+	}
+
+	items, err := planReceipt(ret, damaged)
+	if err != nil {
+		return nil, err
+	}
+	order, err := c.repo.Get_ByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := assignReturnSerials(order, items, serials, damagedSerials); err != nil {
+		return nil, err
+	}
+	if err := assignReturnLots(order, items, lots); err != nil {
+		return nil, err
+	}
+
+	// record the receipt even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+
+	// the return stays authorized until every item was taken back by the inventory;
+	// retrying it replays the items already restocked instead of restocking them twice
+	var failed []int
+	reference := fmt.Sprintf("order-%d-return-%d", orderID, ret.ID)
+	for _, item := range items {
+		key := fmt.Sprintf("order-return-%d-item-%d-receive", ret.ID, item.ID)
+		if err := c.inventory.Receive_Return(ctx, key, reference, item.ProductID, item.Restocked, item.Damaged, item.SerialNumbers, item.DamagedSerialNumbers, item.Lots); err != nil {
+			log.Printf("Return %d: failed to restock %d units of product %d: %v", ret.ID, item.Quantity, item.ProductID, err)
+			failed = append(failed, item.ProductID)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("%w: return %d, products %v", internal.ErrRestockFailed, ret.ID, failed)
+	}
+
+	if err := c.repo.Receive_Return(ctx, orderID, returnID, items, internal.ActorFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	return c.repo.Get_Return(ctx, orderID, returnID)
+}
+
+// spread the returned quantities over the order's lines, in line order
+func planReturn(order *orders_dmodel.Order, lines []orders_dmodel.ReturnLine) ([]orders_dmodel.ReturnItem, error) {
+	planned := make([]int, len(order.Items))
+
+	if len(lines) == 0 {
+		for i, item := range order.Items {
+			planned[i] = item.FulfilledQuantity - item.ReturnedQuantity
+		}
+	}
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of product %d must be positive", internal.ErrInvalidReturn, line.ProductID)
+		}
+
+		remaining := line.Quantity
+		for i, item := range order.Items {
+			if item.ProductID != line.ProductID {
+				continue
+			}
+			n := min(remaining, item.FulfilledQuantity-item.ReturnedQuantity-planned[i])
+			planned[i] += n
+			remaining -= n
+		}
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w: %d units of product %d exceed its fulfilled quantity not yet returned", internal.ErrInvalidReturn, line.Quantity, line.ProductID)
+		}
+	}
+
+	var items []orders_dmodel.ReturnItem
+	for i, item := range order.Items {
+		if planned[i] == 0 {
+			continue
+		}
+		items = append(items, orders_dmodel.ReturnItem{
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			Quantity:    planned[i],
+		})
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: order %d has nothing left to return", internal.ErrInvalidReturn, order.ID)
+	}
+
+	return items, nil
+}
+
+// spread the damaged quantities over the return's items; the rest is restocked
+func planReceipt(ret *orders_dmodel.Return, damaged []orders_dmodel.ReturnLine) ([]orders_dmodel.ReturnItem, error) {
+	items := slices.Clone(ret.Items)
+	for i := range items {
+		items[i].Restocked, items[i].Damaged = items[i].Quantity, 0
+	}
+
+	for _, line := range damaged {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: damaged quantity of product %d must be positive", internal.ErrInvalidReturn, line.ProductID)
+		}
+
+		remaining := line.Quantity
+		for i := range items {
+			if items[i].ProductID != line.ProductID {
+				continue
+			}
+			n := min(remaining, items[i].Restocked)
+			items[i].Restocked -= n
+			items[i].Damaged += n
+			remaining -= n
+		}
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w: %d damaged units of product %d exceed the returned quantity", internal.ErrInvalidReturn, line.Quantity, line.ProductID)
+		}
+	}
+
+	return items, nil
+}
+
+// give every returned serial number to the item of the return whose order line shipped it:
+// an item gets one serial number for each restocked unit (serials) and one for each damaged
+// unit (damagedSerials), and must get them all when its line shipped serial numbers
+func assignReturnSerials(order *orders_dmodel.Order, items []orders_dmodel.ReturnItem, serials, damagedSerials []string) error {
+	seen := make(map[string]bool, len(serials)+len(damagedSerials))
+	assign := func(serial string, damaged bool) error {
+		serial = strings.TrimSpace(serial)
+		if seen[serial] {
+			return fmt.Errorf("%w: serial number %s is listed twice", internal.ErrInvalidReturn, serial)
+		}
+		seen[serial] = true
+
+		for i := range items {
+			if !shippedSerial(order, items[i].OrderItemID, serial) {
+				continue
+			}
+			if damaged && len(items[i].DamagedSerialNumbers) < items[i].Damaged {
+				items[i].DamagedSerialNumbers = append(items[i].DamagedSerialNumbers, serial)
+				return nil
+			}
+			if !damaged && len(items[i].SerialNumbers) < items[i].Restocked {
+				items[i].SerialNumbers = append(items[i].SerialNumbers, serial)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: serial number %s was not shipped with the returned lines, or exceeds their units", internal.ErrInvalidReturn, serial)
+	}
+	for _, serial := range serials {
+		if err := assign(serial, false); err != nil {
+			return err
+		}
+	}
+	for _, serial := range damagedSerials {
+		if err := assign(serial, true); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		if !shippedSerials(order, item.OrderItemID) {
+			continue
+		}
+		if len(item.SerialNumbers) != item.Restocked || len(item.DamagedSerialNumbers) != item.Damaged {
+			return fmt.Errorf("%w: product %d needs the serial numbers of its %d restocked and %d damaged units", internal.ErrInvalidReturn, item.ProductID, item.Restocked, item.Damaged)
+		}
+	}
+
+	return nil
+}
+
+// give the restocked units of every returned lot to the items of the return whose order
+// line shipped units of that lot, up to what the line shipped of it and the item's restocked
+// units; the restocked units of a line shipped from a single lot go back to that lot when no
+// lot of its product is named
+func assignReturnLots(order *orders_dmodel.Order, items []orders_dmodel.ReturnItem, lots []orders_dmodel.ReturnLot) error {
+	named := make(map[int]bool, len(lots))
+	for _, lot := range lots {
+		lot.LotNumber = strings.TrimSpace(lot.LotNumber)
+		if lot.LotNumber == "" || lot.Quantity <= 0 {
+			return fmt.Errorf("%w: lot %q of product %d needs a lot number and a positive quantity", internal.ErrInvalidReturn, lot.LotNumber, lot.ProductID)
+		}
+		named[lot.ProductID] = true
+
+		remaining := lot.Quantity
+		for i := range items {
+			if items[i].ProductID != lot.ProductID {
+				continue
+			}
+			shipped, ok := shippedLot(order, items[i].OrderItemID, lot.LotNumber)
+			if !ok {
+				continue
+			}
+			n := min(remaining, shipped.Quantity-lotUnits(items[i].Lots, lot.LotNumber), items[i].Restocked-lotUnits(items[i].Lots, ""))
+			if n <= 0 {
+				continue
+			}
+			items[i].Lots = append(items[i].Lots, orders_dmodel.OrderItemLot{LotNumber: lot.LotNumber, ExpiresAt: shipped.ExpiresAt, Quantity: n})
+			remaining -= n
+		}
+		if remaining > 0 {
+			return fmt.Errorf("%w: %d units of lot %s of product %d were not shipped with the returned lines, or exceed their restocked units", internal.ErrInvalidReturn, lot.Quantity, lot.LotNumber, lot.ProductID)
+		}
+	}
+
+	for i := range items {
+		if named[items[i].ProductID] || items[i].Restocked == 0 {
+			continue
+		}
+		for _, item := range order.Items {
+			if item.ID == items[i].OrderItemID && len(item.Lots) == 1 && item.Lots[0].Quantity == item.FulfilledQuantity {
+				lot := item.Lots[0]
+				lot.Quantity = items[i].Restocked
+				items[i].Lots = []orders_dmodel.OrderItemLot{lot}
+			}
+		}
+	}
+
+	return nil
+}
+
+// the units of a lot shipped with a line of the order
+func shippedLot(order *orders_dmodel.Order, orderItemID int, lotNumber string) (orders_dmodel.OrderItemLot, bool) {
+	for _, item := range order.Items {
+		if item.ID != orderItemID {
+			continue
+		}
+		for _, lot := range item.Lots {
+			if lot.LotNumber == lotNumber {
+				return lot, true
+			}
+		}
+	}
+	return orders_dmodel.OrderItemLot{}, false
+}
+
+// the units of a lot in a list of lots, of every lot when lotNumber is empty
+func lotUnits(lots []orders_dmodel.OrderItemLot, lotNumber string) int {
+	n := 0
+	for _, lot := range lots {
+		if lotNumber == "" || lot.LotNumber == lotNumber {
+			n += lot.Quantity
+		}
+	}
+	return n
+}
+
+// whether the unit with a serial number was shipped with a line of the order
+func shippedSerial(order *orders_dmodel.Order, orderItemID int, serial string) bool {
+	for _, item := range order.Items {
+		if item.ID == orderItemID {
+			return slices.Contains(item.SerialNumbers, serial)
+		}
+	}
+	return false
+}
+
+// whether a line of the order shipped serialized units
+func shippedSerials(order *orders_dmodel.Order, orderItemID int) bool {
+	for _, item := range order.Items {
+		if item.ID == orderItemID {
+			return len(item.SerialNumbers) > 0
+		}
+	}
+	return false
+}
+
+// -------------------------------------------------------------------
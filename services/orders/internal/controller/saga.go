@@ -0,0 +1,220 @@
+package orders_controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	internal "orders-service/internal"
+	orders_dmodel "orders-service/pkg"
+)
+
+// compensation keeps running after the request that triggered it is gone
+const compensationTimeout = 30 * time.Second
+
+// maximum number of stale sagas claimed per recovery pass
+const recoveryBatchSize = 50
+
+// actor recorded in the inventory movements of the releases made by saga recovery
+const recoveryActor = "system:saga-recovery"
+
+var errSagaInterrupted = errors.New("saga interrupted before completion")
+
+// -------------------------------------------------------------------
+// create order saga
+// -------------------------------------------------------------------
+
+// Create_Order runs the create order saga:
+//  1. persist the saga
+//  2. reserve inventory item by item, recording each step before and after the call
+//     together with the id of the reservation holding the item's stock
+//  3. insert the order and complete the saga in one transaction
+//
+// the unit price of every line must be set; line and order totals are computed from them
+// if any step fails, every reservation made so far is released
+func (c *Controller_Orders) Create_Order(ctx context.Context, order *orders_dmodel.Order) (*orders_dmodel.Order, error) {
+	if err := order.CalculateTotals(); err != nil {
+		return nil, fmt.Errorf("%w: %v", internal.ErrMixedCurrencies, err)
+	}
+
+	sagaID, err := c.repo.Create_Saga(ctx, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range order.Items {
+		stepID, err := c.repo.Create_SagaStep(ctx, sagaID, item.ProductID, item.Quantity)
+		if err != nil {
+			c.compensate(ctx, sagaID, err)
+			return nil, err
+		}
+
+		reservationID, err := c.inventory.Reserve_Stock(ctx, reserveKey(sagaID, stepID), sagaOwner(sagaID), item.ProductID, item.Quantity)
+		if err != nil {
+			reserveErr := fmt.Errorf("%w for product %d: %v", internal.ErrReservationFailed, item.ProductID, err)
+			// a rejected reservation was not applied; any other error leaves the step as
+			// reserving, and compensation replays it to find out whether it was applied
+			if errors.Is(err, internal.ErrInventoryRejected) {
+				if err := c.repo.Update_SagaStepStatus(ctx, stepID, orders_dmodel.SagaStepFailed); err != nil {
+					log.Printf("Saga %d: failed to record failed step %d: %v", sagaID, stepID, err)
+				}
+			}
+			c.compensate(ctx, sagaID, reserveErr)
+			return nil, reserveErr
+		}
+
+		if err := c.repo.Update_SagaStepReserved(ctx, stepID, reservationID); err != nil {
+			c.compensate(ctx, sagaID, err)
+			return nil, err
+		}
+		order.Items[i].ReservationID = reservationID
+	}
+
+	res, err := c.repo.Complete_Saga(ctx, sagaID, order, internal.ActorFromContext(ctx))
+	if err != nil {
+		c.compensate(ctx, sagaID, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// compensate releases every reservation recorded as held by the saga
+// it returns true once the saga is fully compensated
+func (c *Controller_Orders) compensate(ctx context.Context, sagaID int, cause error) bool {
+	// detach from the caller: a cancelled request must still release its reservations
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), compensationTimeout)
+	defer cancel()
+
+	reason := ""
+	if cause != nil {
+		reason = cause.Error()
+	}
+	if err := c.repo.Update_SagaStatus(ctx, sagaID, orders_dmodel.SagaStatusCompensating, reason); err != nil {
+		log.Printf("Saga %d: failed to mark as compensating: %v", sagaID, err)
+		return false
+	}
+
+	steps, err := c.repo.Get_SagaSteps(ctx, sagaID)
+	if err != nil {
+		log.Printf("Saga %d: failed to load steps: %v", sagaID, err)
+		return false
+	}
+
+	released, unresolved := true, false
+	for _, step := range steps {
+		if step.Status == orders_dmodel.SagaStepReserving {
+			// the outcome of the reservation call is unknown; replaying it with the same
+			// idempotency key returns the stored result if it was applied, or applies it now
+			reservationID, err := c.inventory.Reserve_Stock(ctx, reserveKey(sagaID, step.ID), sagaOwner(sagaID), step.ProductID, step.Quantity)
+			if errors.Is(err, internal.ErrInventoryRejected) {
+				if err := c.repo.Update_SagaStepStatus(ctx, step.ID, orders_dmodel.SagaStepFailed); err != nil {
+					log.Printf("Saga %d: failed to record failed step %d: %v", sagaID, step.ID, err)
+				}
+				continue
+			}
+			if err != nil {
+				log.Printf("Saga %d: reservation of %d units of product %d is in an unknown state: %v", sagaID, step.Quantity, step.ProductID, err)
+				unresolved = true
+				continue
+			}
+			step.Status = orders_dmodel.SagaStepReserved
+			step.ReservationID = reservationID
+		}
+
+		if step.Status != orders_dmodel.SagaStepReserved {
+			continue
+		}
+		// the release is keyed as well, so retrying it after a crash never releases twice
+		if err := c.inventory.Release_Reservation(ctx, releaseKey(sagaID, step.ID), step.ReservationID, step.Quantity); err != nil {
+			log.Printf("Saga %d: failed to release %d units of product %d: %v", sagaID, step.Quantity, step.ProductID, err)
+			released = false
+			continue
+		}
+		if err := c.repo.Update_SagaStepStatus(ctx, step.ID, orders_dmodel.SagaStepReleased); err != nil {
+			log.Printf("Saga %d: failed to record released step %d: %v", sagaID, step.ID, err)
+			released = false
+		}
+	}
+
+	// leave the saga as compensating so the recovery worker retries the releases
+	if !released {
+		return false
+	}
+
+	status := orders_dmodel.SagaStatusCompensated
+	if unresolved {
+		status = orders_dmodel.SagaStatusFailed
+	}
+	if err := c.repo.Update_SagaStatus(ctx, sagaID, status, ""); err != nil {
+		log.Printf("Saga %d: failed to mark as %s: %v", sagaID, status, err)
+		return false
+	}
+
+	return true
+}
+
+// owner of the inventory reservations made by a saga
+func sagaOwner(sagaID int) string {
+	return fmt.Sprintf("order-saga-%d", sagaID)
+}
+
+// idempotency keys of the inventory calls made by a saga step
+func reserveKey(sagaID, stepID int) string {
+	return fmt.Sprintf("order-saga-%d-step-%d-reserve", sagaID, stepID)
+}
+
+func releaseKey(sagaID, stepID int) string {
+	return fmt.Sprintf("order-saga-%d-step-%d-release", sagaID, stepID)
+}
+
+// -------------------------------------------------------------------
+// saga recovery
+// -------------------------------------------------------------------
+
+// Recover_Sagas compensates sagas that were left unfinished (e.g. by a crash)
+// and have not been updated for longer than staleAfter
+func (c *Controller_Orders) Recover_Sagas(ctx context.Context, staleAfter time.Duration) error {
+	ctx = internal.WithActor(ctx, recoveryActor)
+	sagas, err := c.repo.Claim_StaleSagas(ctx, time.Now().Add(-staleAfter), recoveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		log.Printf("Saga %d: recovering unfinished saga (status: %s)", saga.ID, saga.Status)
+		// keep the original failure reason of sagas that were already compensating
+		var cause error
+		if saga.Status == orders_dmodel.SagaStatusStarted {
+			cause = errSagaInterrupted
+		}
+		if c.compensate(ctx, saga.ID, cause) {
+			log.Printf("Saga %d: recovered", saga.ID)
+		}
+	}
+
+	return nil
+}
+
+// Run_SagaRecovery recovers stale sagas on startup and then every interval
+// until the context is cancelled
+func (c *Controller_Orders) Run_SagaRecovery(ctx context.Context, interval, staleAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Recover_Sagas(ctx, staleAfter); err != nil {
+			log.Printf("Error recovering sagas: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// -------------------------------------------------------------------
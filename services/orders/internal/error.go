@@ -1,7 +1,33 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+
+	"shared/idempotency"
+)
 
 var (
-	ErrItemNotFound = errors.New("item (order) not found")
+	ErrItemNotFound      = errors.New("item (order) not found")
+	ErrReservationFailed = errors.New("failed to reserve inventory")
+	ErrInventoryRejected = errors.New("request rejected by the inventory service")
+	ErrMixedCurrencies   = errors.New("order lines are priced in different currencies")
+	// order lifecycle
+	ErrInvalidStatus           = errors.New("invalid order status")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrStatusRequiresOperation = errors.New("order status can only be reached through its own operation")
+	// fulfillment
+	ErrInvalidFulfillment  = errors.New("invalid fulfillment")
+	ErrFulfillmentFailed   = errors.New("failed to fulfill inventory reservation")
+	ErrFulfillmentConflict = errors.New("order lines were fulfilled concurrently")
+	ErrInvalidLot          = errors.New("invalid lot number")
+	ErrInvalidSerial       = errors.New("invalid serial number")
+	// returns
+	ErrReturnNotFound      = errors.New("return not found")
+	ErrInvalidReturn       = errors.New("invalid return")
+	ErrReturnNotAuthorized = errors.New("return is not awaiting receipt")
+	ErrRestockFailed       = errors.New("failed to restock returned inventory")
+	// idempotency keys
+	ErrInvalidIdempotencyKey    = idempotency.ErrInvalidKey
+	ErrIdempotencyKeyInProgress = idempotency.ErrInProgress
+	ErrIdempotencyKeyReused     = idempotency.ErrKeyReused
 )
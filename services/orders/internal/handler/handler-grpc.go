@@ -2,31 +2,32 @@ package orders_handler_http
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	internal "orders-service/internal"
 	orders_controller "orders-service/internal/controller"
 	orders_dmodel "orders-service/pkg"
 	pb "orders-service/proto/orders"
-	
+	"shared/money"
+
 	products_pb "orders-service/proto/products"
-	inventory_pb "orders-service/proto/inventory"
 )
 
 type Handler_Orders_GRPC struct {
 	pb.UnimplementedOrderServiceServer
-	controller        *orders_controller.Controller_Orders
-	productsClient    products_pb.ProductServiceClient
-	inventoryClient   inventory_pb.InventoryServiceClient
+	controller     *orders_controller.Controller_Orders
+	productsClient products_pb.ProductServiceClient
 }
 
-func NewGRPC(controller *orders_controller.Controller_Orders, productsAddr, inventoryAddr string) (*Handler_Orders_GRPC, error) {
+func NewGRPC(controller *orders_controller.Controller_Orders, productsAddr string) (*Handler_Orders_GRPC, error) {
 	// Connect to Products service
 	productsConn, err := grpc.NewClient(productsAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -34,46 +35,196 @@ func NewGRPC(controller *orders_controller.Controller_Orders, productsAddr, inve
 	}
 	productsClient := products_pb.NewProductServiceClient(productsConn)
 
-	// Connect to Inventory service
-	inventoryConn, err := grpc.NewClient(inventoryAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, err
-	}
-	inventoryClient := inventory_pb.NewInventoryServiceClient(inventoryConn)
-
 	return &Handler_Orders_GRPC{
-		controller:      controller,
-		productsClient:  productsClient,
-		inventoryClient: inventoryClient,
+		controller:     controller,
+		productsClient: productsClient,
 	}, nil
 }
 
-func (h *Handler_Orders_GRPC) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
-	order, err := h.controller.Get_ByOrderID(ctx, int(req.Id))
-	if err != nil {
-		if err == internal.ErrItemNotFound {
-			return nil, status.Errorf(codes.NotFound, "order not found")
+// idempotency metadata keys (gRPC equivalents of the Idempotency-Key / Idempotent-Replayed headers)
+const (
+	idempotencyKeyMetadata     = "idempotency-key"
+	idempotentReplayedMetadata = "idempotent-replayed"
+)
+
+// returns the idempotency key sent in the request metadata, if any
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(idempotencyKeyMetadata); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// maps idempotency key errors to gRPC statuses, returns nil for any other error
+func idempotencyStatus(err error) error {
+	switch err {
+	case internal.ErrInvalidIdempotencyKey:
+		return status.Errorf(codes.InvalidArgument, "invalid idempotency key")
+	case internal.ErrIdempotencyKeyInProgress:
+		return status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress")
+	case internal.ErrIdempotencyKeyReused:
+		return status.Errorf(codes.InvalidArgument, "idempotency key was already used for a different request")
+	}
+	return nil
+}
+
+// gRPC metadata key identifying who performs a request (equivalent of the X-Actor header)
+const actorMetadata = "x-actor"
+
+// ActorInterceptor stores the caller named in the request metadata in the context
+func ActorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	actor := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(actorMetadata); len(values) > 0 {
+			actor = values[0]
 		}
-		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
+	return handler(internal.WithActor(ctx, actor), req)
+}
+
+// maps order lifecycle errors to gRPC statuses, returns nil for any other error
+func orderStatusError(err error) error {
+	switch {
+	case errors.Is(err, internal.ErrInvalidStatus):
+		return status.Errorf(codes.InvalidArgument, "invalid order status")
+	case errors.Is(err, internal.ErrStatusRequiresOperation), errors.Is(err, internal.ErrInvalidStatusTransition):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return nil
+}
 
+// converts a domain order into its protobuf representation
+func toPBOrder(order *orders_dmodel.Order) *pb.Order {
 	pbItems := make([]*pb.OrderItem, len(order.Items))
 	for i, item := range order.Items {
 		pbItems[i] = &pb.OrderItem{
+			ProductId:         int32(item.ProductID),
+			Quantity:          int32(item.Quantity),
+			FulfilledQuantity: int32(item.FulfilledQuantity),
+			ReturnedQuantity:  int32(item.ReturnedQuantity),
+			UnitPrice:         item.Price.Float64(),
+			LineTotal:         item.LineTotal.Float64(),
+			UnitPriceMoney:    toPBMoney(item.Price),
+			LineTotalMoney:    toPBMoney(item.LineTotal),
+			SerialNumbers:     item.SerialNumbers,
+		}
+		for _, lot := range item.Lots {
+			pbLot := &pb.OrderItemLot{LotNumber: lot.LotNumber, Quantity: int32(lot.Quantity)}
+			if lot.ExpiresAt != nil {
+				pbLot.ExpiresAt = lot.ExpiresAt.Format(time.RFC3339)
+			}
+			pbItems[i].Lots = append(pbItems[i].Lots, pbLot)
+		}
+	}
+
+	return &pb.Order{
+		Id:                 int32(order.ID),
+		CustomerId:         int32(order.CustomerID),
+		Items:              pbItems,
+		Status:             order.Status,
+		TotalAmount:        order.TotalAmount.Float64(),
+		TotalAmountMoney:   toPBMoney(order.TotalAmount),
+		CreatedAt:          order.CreatedAt.Format(time.RFC3339),
+		CancellationReason: order.CancellationReason,
+	}
+}
+
+func toPBMoney(m money.Money) *pb.Money {
+	return &pb.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
+
+// exact price of a product; products services that predate price_money only send a float
+func productPrice(product *products_pb.Product) money.Money {
+	if product.PriceMoney != nil {
+		return money.New(product.PriceMoney.Amount, product.PriceMoney.Currency)
+	}
+	return money.FromFloat(product.Price, money.DefaultCurrency)
+}
+
+// converts a domain return into its protobuf representation
+func toPBReturn(ret *orders_dmodel.Return) *pb.Return {
+	pbItems := make([]*pb.ReturnItem, len(ret.Items))
+	for i, item := range ret.Items {
+		pbItems[i] = &pb.ReturnItem{
 			ProductId: int32(item.ProductID),
 			Quantity:  int32(item.Quantity),
+			Restocked: int32(item.Restocked),
+			Damaged:   int32(item.Damaged),
 		}
 	}
 
+	pbReturn := &pb.Return{
+		Id:        int32(ret.ID),
+		OrderId:   int32(ret.OrderID),
+		Status:    ret.Status,
+		Reason:    ret.Reason,
+		Items:     pbItems,
+		CreatedAt: ret.CreatedAt.Format(time.RFC3339),
+	}
+	if ret.ReceivedAt != nil {
+		pbReturn.ReceivedAt = ret.ReceivedAt.Format(time.RFC3339)
+	}
+
+	return pbReturn
+}
+
+// converts protobuf order items into return lines
+func toReturnLines(items []*pb.OrderItem) []orders_dmodel.ReturnLine {
+	lines := make([]orders_dmodel.ReturnLine, len(items))
+	for i, item := range items {
+		lines[i] = orders_dmodel.ReturnLine{
+			ProductID: int(item.ProductId),
+			Quantity:  int(item.Quantity),
+		}
+	}
+	return lines
+}
+
+func toReturnLots(pbLots []*pb.ReturnLot) []orders_dmodel.ReturnLot {
+	lots := make([]orders_dmodel.ReturnLot, len(pbLots))
+	for i, lot := range pbLots {
+		lots[i] = orders_dmodel.ReturnLot{
+			ProductID: int(lot.ProductId),
+			LotNumber: lot.LotNumber,
+			Quantity:  int(lot.Quantity),
+		}
+	}
+	return lots
+}
+
+// maps return errors to gRPC statuses, returns nil for any other error
+func returnStatusError(err error) error {
+	switch {
+	case err == internal.ErrItemNotFound:
+		return status.Errorf(codes.NotFound, "order not found")
+	case err == internal.ErrReturnNotFound:
+		return status.Errorf(codes.NotFound, "return not found")
+	case errors.Is(err, internal.ErrInvalidReturn):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err == internal.ErrReturnNotAuthorized:
+		return status.Errorf(codes.FailedPrecondition, "return is not awaiting receipt")
+	}
+	return nil
+}
+
+func (h *Handler_Orders_GRPC) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	order, err := h.controller.Get_ByOrderID(ctx, int(req.Id))
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
 	return &pb.GetOrderResponse{
-		Order: &pb.Order{
-			Id:          int32(order.ID),
-			CustomerId:  int32(order.CustomerID),
-			Items:       pbItems,
-			Status:      order.Status,
-			TotalAmount: order.TotalAmount,
-			CreatedAt:   order.CreatedAt.Format(time.RFC3339),
-		},
+		Order: toPBOrder(order),
 	}, nil
 }
 
@@ -85,22 +236,7 @@ func (h *Handler_Orders_GRPC) ListOrders(ctx context.Context, req *pb.ListOrders
 
 	pbOrders := make([]*pb.Order, len(orders))
 	for i, order := range orders {
-		pbItems := make([]*pb.OrderItem, len(order.Items))
-		for j, item := range order.Items {
-			pbItems[j] = &pb.OrderItem{
-				ProductId: int32(item.ProductID),
-				Quantity:  int32(item.Quantity),
-			}
-		}
-
-		pbOrders[i] = &pb.Order{
-			Id:          int32(order.ID),
-			CustomerId:  int32(order.CustomerID),
-			Items:       pbItems,
-			Status:      order.Status,
-			TotalAmount: order.TotalAmount,
-			CreatedAt:   order.CreatedAt.Format(time.RFC3339),
-		}
+		pbOrders[i] = toPBOrder(order)
 	}
 
 	return &pb.ListOrdersResponse{
@@ -114,120 +250,289 @@ func (h *Handler_Orders_GRPC) CreateOrder(ctx context.Context, req *pb.CreateOrd
 		return nil, status.Errorf(codes.InvalidArgument, "order must contain at least one item")
 	}
 
-	// Calculate total amount and validate products
-	var totalAmount float64
-	items := make([]orders_dmodel.OrderItem, len(req.Items))
+	// Validate products and snapshot their current price on each line
+	// every product is looked up in a single call to the Products service
+	productIDs := make([]int, len(req.Items))
+	pbProductIDs := make([]int32, len(req.Items))
 	for i, item := range req.Items {
-		// Get product details from Products service via gRPC
-		productResp, err := h.productsClient.GetProduct(ctx, &products_pb.GetProductRequest{
-			Id: item.ProductId,
-		})
-		if err != nil {
-			if status.Code(err) == codes.NotFound {
-				return nil, status.Errorf(codes.InvalidArgument, "product %d not found", item.ProductId)
-			}
-			return nil, status.Errorf(codes.Internal, "failed to get product: %v", err)
-		}
-
-		totalAmount += productResp.Product.Price * float64(item.Quantity)
+		productIDs[i] = int(item.ProductId)
+		pbProductIDs[i] = item.ProductId
+	}
+	productsResp, err := h.productsClient.BatchGetProducts(ctx, &products_pb.BatchGetProductsRequest{
+		Ids: pbProductIDs,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get products: %v", err)
+	}
 
-		// Reserve inventory via gRPC
-		_, err = h.inventoryClient.ReserveStock(ctx, &inventory_pb.ReserveStockRequest{
-			ProductId: item.ProductId,
-			Stock:     item.Quantity,
-		})
-		if err != nil {
-			log.Printf("Failed to reserve inventory for product %d: %v", item.ProductId, err)
-			return nil, status.Errorf(codes.FailedPrecondition, "failed to reserve inventory for product %d: %v", item.ProductId, err)
-		}
+	products := make(map[int]*products_pb.Product, len(productsResp.Products))
+	for _, product := range productsResp.Products {
+		products[int(product.Id)] = product
+	}
+	if missing := missingProducts(productIDs, products); len(missing) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "products %v not found", missing)
+	}
 
+	items := make([]orders_dmodel.OrderItem, len(req.Items))
+	for i, item := range req.Items {
 		items[i] = orders_dmodel.OrderItem{
 			ProductID: int(item.ProductId),
 			Quantity:  int(item.Quantity),
+			Price:     productPrice(products[int(item.ProductId)]),
 		}
 	}
 
 	order := &orders_dmodel.Order{
-		CustomerID:  int(req.CustomerId),
-		Items:       items,
-		TotalAmount: totalAmount,
+		CustomerID: int(req.CustomerId),
+		Items:      items,
 	}
 
-	createdOrder, err := h.controller.Create_Order(ctx, order)
+	// the controller reserves inventory and releases it again if the order cannot be created
+	createdOrder, replayed, err := h.controller.Create_OrderIdempotent(ctx, idempotencyKeyFromContext(ctx), order)
 	if err != nil {
+		if errors.Is(err, internal.ErrReservationFailed) {
+			log.Printf("Failed to create order: %v", err)
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if errors.Is(err, internal.ErrMixedCurrencies) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err := idempotencyStatus(err); err != nil {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
-
-	pbItems := make([]*pb.OrderItem, len(createdOrder.Items))
-	for i, item := range createdOrder.Items {
-		pbItems[i] = &pb.OrderItem{
-			ProductId: int32(item.ProductID),
-			Quantity:  int32(item.Quantity),
-		}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
 	}
 
 	return &pb.CreateOrderResponse{
-		Order: &pb.Order{
-			Id:          int32(createdOrder.ID),
-			CustomerId:  int32(createdOrder.CustomerID),
-			Items:       pbItems,
-			Status:      createdOrder.Status,
-			TotalAmount: createdOrder.TotalAmount,
-			CreatedAt:   createdOrder.CreatedAt.Format(time.RFC3339),
-		},
+		Order: toPBOrder(createdOrder),
 	}, nil
 }
 
 func (h *Handler_Orders_GRPC) FulfillOrder(ctx context.Context, req *pb.FulfillOrderRequest) (*pb.FulfillOrderResponse, error) {
-	order, err := h.controller.Get_ByOrderID(ctx, int(req.Id))
+	lines := make([]orders_dmodel.FulfillmentLine, len(req.Items))
+	for i, item := range req.Items {
+		lines[i] = orders_dmodel.FulfillmentLine{
+			ProductID:     int(item.ProductId),
+			Quantity:      int(item.Quantity),
+			SerialNumbers: item.SerialNumbers,
+		}
+	}
+
+	updatedOrder, err := h.controller.Fulfill_Order(ctx, int(req.Id), lines)
 	if err != nil {
 		if err == internal.ErrItemNotFound {
 			return nil, status.Errorf(codes.NotFound, "order not found")
 		}
+		if errors.Is(err, internal.ErrInvalidFulfillment) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if errors.Is(err, internal.ErrFulfillmentConflict) {
+			return nil, status.Errorf(codes.Aborted, "%v", err)
+		}
+		if err := orderStatusError(err); err != nil {
+			return nil, err
+		}
+		if errors.Is(err, internal.ErrInventoryRejected) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		log.Printf("Error fulfilling order %d: %v", req.Id, err)
+		if errors.Is(err, internal.ErrFulfillmentFailed) {
+			return nil, status.Errorf(codes.Internal, "failed to fulfill inventory: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
-	if order.Status != "pending" {
-		return nil, status.Errorf(codes.FailedPrecondition, "order is not in pending status")
+	return &pb.FulfillOrderResponse{
+		Order: toPBOrder(updatedOrder),
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	cancelledOrder, warnings, err := h.controller.Cancel_Order(ctx, int(req.Id), req.Reason)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		if err := orderStatusError(err); err != nil {
+			return nil, err
+		}
+		log.Printf("Error cancelling order %d: %v", req.Id, err)
+		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
-	// Fulfill inventory for each item via gRPC
-	for _, item := range order.Items {
-		_, err := h.inventoryClient.FulfillReservation(ctx, &inventory_pb.FulfillReservationRequest{
-			ProductId: int32(item.ProductID),
-			Stock:     int32(item.Quantity),
-		})
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to fulfill inventory: %v", err)
+	return &pb.CancelOrderResponse{
+		Order:    toPBOrder(cancelledOrder),
+		Warnings: warnings,
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	order, err := h.controller.Set_OrderStatus(ctx, int(req.Id), req.Status, req.Reason)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		if err := orderStatusError(err); err != nil {
+			return nil, err
+		}
+		log.Printf("Error updating status of order %d: %v", req.Id, err)
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.UpdateOrderStatusResponse{
+		Order: toPBOrder(order),
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	history, err := h.controller.Get_OrderHistory(ctx, int(req.Id))
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
 		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
-	// Update order status
-	if err := h.controller.Update_OrderStatus(ctx, int(req.Id), "fulfilled"); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update order status")
+	pbHistory := make([]*pb.OrderStatusChange, len(history))
+	for i, change := range history {
+		pbHistory[i] = &pb.OrderStatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+			ChangedAt:  change.ChangedAt.Format(time.RFC3339),
+		}
 	}
 
-	updatedOrder, err := h.controller.Get_ByOrderID(ctx, int(req.Id))
+	return &pb.GetOrderHistoryResponse{
+		History: pbHistory,
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error) {
+	ret, err := h.controller.Create_Return(ctx, int(req.OrderId), toReturnLines(req.Items), req.Reason)
 	if err != nil {
+		if err := returnStatusError(err); err != nil {
+			return nil, err
+		}
+		log.Printf("Error creating return for order %d: %v", req.OrderId, err)
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
-	pbItems := make([]*pb.OrderItem, len(updatedOrder.Items))
-	for i, item := range updatedOrder.Items {
-		pbItems[i] = &pb.OrderItem{
-			ProductId: int32(item.ProductID),
-			Quantity:  int32(item.Quantity),
+	return &pb.CreateReturnResponse{
+		Return: toPBReturn(ret),
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	returns, err := h.controller.Get_Returns(ctx, int(req.OrderId))
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
 		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
-	return &pb.FulfillOrderResponse{
-		Order: &pb.Order{
-			Id:          int32(updatedOrder.ID),
-			CustomerId:  int32(updatedOrder.CustomerID),
-			Items:       pbItems,
-			Status:      updatedOrder.Status,
-			TotalAmount: updatedOrder.TotalAmount,
-			CreatedAt:   updatedOrder.CreatedAt.Format(time.RFC3339),
-		},
+	pbReturns := make([]*pb.Return, len(returns))
+	for i, ret := range returns {
+		pbReturns[i] = toPBReturn(ret)
+	}
+
+	return &pb.ListReturnsResponse{
+		Returns: pbReturns,
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	ret, err := h.controller.Receive_Return(ctx, int(req.OrderId), int(req.ReturnId), toReturnLines(req.Damaged), req.SerialNumbers, req.DamagedSerialNumbers, toReturnLots(req.Lots))
+	if err != nil {
+		if err := returnStatusError(err); err != nil {
+			return nil, err
+		}
+		log.Printf("Error receiving return %d of order %d: %v", req.ReturnId, req.OrderId, err)
+		if errors.Is(err, internal.ErrRestockFailed) {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.ReceiveReturnResponse{
+		Return: toPBReturn(ret),
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) ListLotShipments(ctx context.Context, req *pb.ListLotShipmentsRequest) (*pb.ListLotShipmentsResponse, error) {
+	shipments, err := h.controller.Get_LotShipments(ctx, req.LotNumber, int(req.ProductId))
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidLot) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbShipments := make([]*pb.LotShipment, len(shipments))
+	for i, s := range shipments {
+		pbShipments[i] = &pb.LotShipment{
+			OrderId:    int32(s.OrderID),
+			CustomerId: int32(s.CustomerID),
+			Status:     s.Status,
+			ProductId:  int32(s.ProductID),
+			LotNumber:  s.LotNumber,
+			Quantity:   int32(s.Quantity),
+		}
+	}
+
+	return &pb.ListLotShipmentsResponse{
+		Shipments: pbShipments,
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) ListOrderSerials(ctx context.Context, req *pb.ListOrderSerialsRequest) (*pb.ListOrderSerialsResponse, error) {
+	serials, err := h.controller.Get_OrderSerials(ctx, int(req.OrderId))
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbSerials := make([]*pb.OrderSerial, len(serials))
+	for i, s := range serials {
+		pbSerials[i] = &pb.OrderSerial{
+			ProductId:    int32(s.ProductID),
+			SerialNumber: s.SerialNumber,
+		}
+	}
+
+	return &pb.ListOrderSerialsResponse{
+		Serials: pbSerials,
+	}, nil
+}
+
+func (h *Handler_Orders_GRPC) ListSerialShipments(ctx context.Context, req *pb.ListSerialShipmentsRequest) (*pb.ListSerialShipmentsResponse, error) {
+	shipments, err := h.controller.Get_SerialShipments(ctx, req.SerialNumber, int(req.ProductId))
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidSerial) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbShipments := make([]*pb.SerialShipment, len(shipments))
+	for i, s := range shipments {
+		pbShipments[i] = &pb.SerialShipment{
+			OrderId:      int32(s.OrderID),
+			CustomerId:   int32(s.CustomerID),
+			Status:       s.Status,
+			ProductId:    int32(s.ProductID),
+			SerialNumber: s.SerialNumber,
+		}
+	}
+
+	return &pb.ListSerialShipmentsResponse{
+		Shipments: pbShipments,
 	}, nil
 }
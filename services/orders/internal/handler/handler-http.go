@@ -1,13 +1,16 @@
 package orders_handler_http
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -15,98 +18,88 @@ import (
 	orders_controller "orders-service/internal/controller"
 	orders_dmodel "orders-service/pkg"
 	products_dmodel "orders-service/pkg/products"
+	"shared/money"
 )
 
-func (h *Handler_Orders) getProduct(productID int) (*products_dmodel.Product, error) {
+// looks up every product of an order in a single request, keyed by product ID
+func (h *Handler_Orders) getProducts(productIDs []int) (map[int]*products_dmodel.Product, error) {
 	// Use Kubernetes service discovery (environment variable or default)
 	host := os.Getenv("PRODUCTS_HOST")
 	if host == "" {
 		host = "products-service:8001"
 	}
 
-	resp, err := http.Get(fmt.Sprintf("http://%s/products/%d", host, productID))
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/products?ids=%s", host, strings.Join(ids, ",")))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("product not found")
+		return nil, fmt.Errorf("products service returned %s", resp.Status)
 	}
 
-	var product products_dmodel.Product
-	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+	var products []*products_dmodel.Product
+	if err := json.NewDecoder(resp.Body).Decode(&products); err != nil {
 		return nil, err
 	}
 
-	return &product, nil
-}
-
-func (h *Handler_Orders) reserveInventory(productID, quantity int) error {
-	// Use Kubernetes service discovery (environment variable or default)
-	host := os.Getenv("INVENTORY_HOST")
-	if host == "" {
-		host = "inventory-service:8002"
-	}
-
-	reqBody, _ := json.Marshal(map[string]int{"stock": quantity})
-
-	resp, err := http.Post(
-		fmt.Sprintf("http://%s/inventory/%d/reserve", host, productID),
-		"application/json",
-		bytes.NewBuffer(reqBody),
-	)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		// Try to read backend error message for better debugging
-		var backendMsg string
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(resp.Body)
-		backendMsg = buf.String()
-		if backendMsg == "" {
-			backendMsg = resp.Status
+	res := make(map[int]*products_dmodel.Product, len(products))
+	for _, product := range products {
+		// products services that predate currencies only price in the default one
+		if product.Currency == "" {
+			product.Currency = money.DefaultCurrency
+			product.Price.Currency = money.DefaultCurrency
 		}
-		return fmt.Errorf("failed to reserve inventory: %s", backendMsg)
+		res[product.ID] = product
 	}
 
-	return nil
+	return res, nil
 }
 
-func (h *Handler_Orders) fulfillInventory(productID, quantity int) error {
-	// Use Kubernetes service discovery (environment variable or default)
-	host := os.Getenv("INVENTORY_HOST")
-	if host == "" {
-		host = "inventory-service:8002"
-	}
-
-	reqBody, _ := json.Marshal(map[string]int{"stock": quantity})
-
-	resp, err := http.Post(
-		fmt.Sprintf("http://%s/inventory/%d/fulfill", host, productID),
-		"application/json",
-		bytes.NewBuffer(reqBody),
-	)
-	if err != nil {
-		return err
+// product IDs of an order that were not found, each reported once
+func missingProducts[T any](productIDs []int, found map[int]T) []int {
+	var missing []int
+	for _, id := range productIDs {
+		if _, ok := found[id]; !ok && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
 	}
-	defer resp.Body.Close()
+	return missing
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fulfill inventory")
-	}
+// idempotency headers
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
 
-	return nil
+// writes the HTTP error for idempotency key errors, returns false for any other error
+func writeIdempotencyError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case internal.ErrInvalidIdempotencyKey:
+		http.Error(w, "Invalid Idempotency-Key header", http.StatusBadRequest)
+	case internal.ErrIdempotencyKeyInProgress:
+		http.Error(w, "A request with this Idempotency-Key is still in progress", http.StatusConflict)
+	case internal.ErrIdempotencyKeyReused:
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+	default:
+		return false
+	}
+	return true
 }
 
 func AddCORSHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key, X-Actor")
+		w.Header().Set("Access-Control-Expose-Headers", "Idempotent-Replayed")
 
 		// CORS preflight request (OPTIONS) handling
 		if r.Method == http.MethodOptions {
@@ -118,6 +111,46 @@ func AddCORSHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// header identifying who performs a request, recorded in the order status history
+const actorHeader = "X-Actor"
+
+// AddActor stores the caller named in the X-Actor header in the request context
+func AddActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(internal.WithActor(r.Context(), r.Header.Get(actorHeader))))
+	})
+}
+
+// writes the HTTP error for order lifecycle errors, returns false for any other error
+func writeStatusError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, internal.ErrInvalidStatus):
+		http.Error(w, "Invalid order status", http.StatusBadRequest)
+	case errors.Is(err, internal.ErrStatusRequiresOperation), errors.Is(err, internal.ErrInvalidStatusTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
+
+// writes the HTTP error for return errors, returns false for any other error
+func writeReturnError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == internal.ErrItemNotFound:
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case err == internal.ErrReturnNotFound:
+		http.Error(w, "Return not found", http.StatusNotFound)
+	case errors.Is(err, internal.ErrInvalidReturn):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err == internal.ErrReturnNotAuthorized:
+		http.Error(w, "Return is not awaiting receipt", http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
+
 type Handler_Orders struct {
 	controller *orders_controller.Controller_Orders
 }
@@ -201,37 +234,53 @@ func (h *Handler_Orders) Create_Order(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Calculate total amount and validate products
-	var totalAmount float64
-	for _, item := range template_req.Items {
-		product, err := h.getProduct(item.ProductID)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Product %d not found", item.ProductID), http.StatusBadRequest)
-			return
-		}
-
-		totalAmount += product.Price * float64(item.Quantity)
+	// Validate products and snapshot their current price on each line
+	productIDs := make([]int, len(template_req.Items))
+	for i, item := range template_req.Items {
+		productIDs[i] = item.ProductID
+	}
+	products, err := h.getProducts(productIDs)
+	if err != nil {
+		log.Printf("Error getting products: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if missing := missingProducts(productIDs, products); len(missing) > 0 {
+		http.Error(w, fmt.Sprintf("Products %v not found", missing), http.StatusBadRequest)
+		return
+	}
 
-		// Try to reserve inventory
-		if err := h.reserveInventory(item.ProductID, item.Quantity); err != nil {
-			http.Error(w, fmt.Sprintf("Failed to reserve inventory for product %d: %v", item.ProductID, err), http.StatusBadRequest)
-			return
+	items := make([]orders_dmodel.OrderItem, len(template_req.Items))
+	for i, item := range template_req.Items {
+		items[i] = orders_dmodel.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     products[item.ProductID].Price,
 		}
 	}
 
 	order := &orders_dmodel.Order{
-		CustomerID:  template_req.CustomerID,
-		Items:       template_req.Items,
-		TotalAmount: totalAmount,
+		CustomerID: template_req.CustomerID,
+		Items:      items,
 	}
 
-	createdOrder, err := h.controller.Create_Order(ctx, order)
+	// the controller reserves inventory and releases it again if the order cannot be created
+	createdOrder, replayed, err := h.controller.Create_OrderIdempotent(ctx, r.Header.Get(idempotencyKeyHeader), order)
 	if err != nil {
-		log.Printf("Error creating order: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		if errors.Is(err, internal.ErrReservationFailed) || errors.Is(err, internal.ErrMixedCurrencies) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else if writeIdempotencyError(w, err) {
+			return
+		} else {
+			log.Printf("Error creating order: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(createdOrder)
 }
@@ -250,6 +299,7 @@ func (h *Handler_Orders) Update_OrderStatus(w http.ResponseWriter, r *http.Reque
 
 	var statusUpdate struct {
 		Status string `json:"status"`
+		Reason string `json:"reason"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&statusUpdate); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
@@ -257,16 +307,165 @@ func (h *Handler_Orders) Update_OrderStatus(w http.ResponseWriter, r *http.Reque
 	}
 
 	// getting the controller's response
-	err = h.controller.Update_OrderStatus(ctx, orderID, statusUpdate.Status)
+	order, err := h.controller.Set_OrderStatus(ctx, orderID, statusUpdate.Status, statusUpdate.Reason)
 	if err != nil {
-		log.Printf("Error updating order status: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+		} else if writeStatusError(w, err) {
+			return
+		} else {
+			log.Printf("Error updating order status: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	json.NewEncoder(w).Encode(order)
 	// logging
-	log.Printf("Order ID %d status updated to %s", orderID, statusUpdate.Status)
+	log.Printf("Order ID %d status updated to %s by %s", orderID, statusUpdate.Status, internal.ActorFromContext(ctx))
+}
+
+func (h *Handler_Orders) Get_OrderHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	orderID, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	history, err := h.controller.Get_OrderHistory(ctx, orderID)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+		} else {
+			log.Printf("Error getting order history: Repository error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(history)
+	if err != nil {
+		log.Printf("Error encoding order history to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Get_LotShipments lists the orders a lot went out with, to trace a recall; the
+// product_id query parameter narrows the lot down to one product
+func (h *Handler_Orders) Get_LotShipments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID := 0
+	if value := r.URL.Query().Get("product_id"); value != "" {
+		var err error
+		if productID, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid product ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// getting the controller's response
+	shipments, err := h.controller.Get_LotShipments(ctx, r_params["lotNumber"], productID)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidLot) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			log.Printf("Error getting lot shipments: Repository error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(shipments)
+	if err != nil {
+		log.Printf("Error encoding lot shipments to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Get_OrderSerials lists the serialized units shipped with an order
+func (h *Handler_Orders) Get_OrderSerials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	orderID, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	serials, err := h.controller.Get_OrderSerials(ctx, orderID)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+		} else {
+			log.Printf("Error getting order serial numbers: Repository error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(serials)
+	if err != nil {
+		log.Printf("Error encoding order serial numbers to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Get_SerialShipments lists the orders a serialized unit went out with; the product_id
+// query parameter narrows the serial number down to one product
+func (h *Handler_Orders) Get_SerialShipments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	productID := 0
+	if value := r.URL.Query().Get("product_id"); value != "" {
+		var err error
+		if productID, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid product ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// getting the controller's response
+	shipments, err := h.controller.Get_SerialShipments(ctx, r_params["serialNumber"], productID)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidSerial) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			log.Printf("Error getting serial shipments: Repository error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(shipments)
+	if err != nil {
+		log.Printf("Error encoding serial shipments to JSON: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 }
 
 func (h *Handler_Orders) Fulfill_Order(w http.ResponseWriter, r *http.Request) {
@@ -281,42 +480,197 @@ func (h *Handler_Orders) Fulfill_Order(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	order, err := h.controller.Get_ByOrderID(ctx, id)
+	// the body is optional, without items every unfulfilled quantity is fulfilled
+	var template_req struct {
+		Items []orders_dmodel.FulfillmentLine `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	updatedOrder, err := h.controller.Fulfill_Order(ctx, id, template_req.Items)
 	if err != nil {
 		if err == internal.ErrItemNotFound {
 			http.Error(w, "Order not found", http.StatusNotFound)
+		} else if errors.Is(err, internal.ErrInvalidFulfillment) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else if errors.Is(err, internal.ErrFulfillmentConflict) {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else if writeStatusError(w, err) {
+			return
+		} else if errors.Is(err, internal.ErrInventoryRejected) {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else if errors.Is(err, internal.ErrFulfillmentFailed) {
+			log.Printf("Error fulfilling order: %v", err)
+			http.Error(w, fmt.Sprintf("Failed to fulfill inventory: %v", err), http.StatusInternalServerError)
 		} else {
-			log.Printf("Error getting order by ID: Repository error: %v", err)
+			log.Printf("Error fulfilling order: %v", err)
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if order.Status != "pending" {
-		http.Error(w, "Order is not in pending status", http.StatusBadRequest)
+	json.NewEncoder(w).Encode(updatedOrder)
+}
+
+func (h *Handler_Orders) Cancel_Order(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	id, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// the body (and the reason) is optional
+	var template_req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
 
-	// Fulfill inventory for each item
-	for _, item := range order.Items {
-		if err := h.fulfillInventory(item.ProductID, item.Quantity); err != nil {
-			http.Error(w, fmt.Sprintf("Failed to fulfill inventory: %v", err), http.StatusInternalServerError)
+	cancelledOrder, warnings, err := h.controller.Cancel_Order(ctx, id, template_req.Reason)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+		} else if writeStatusError(w, err) {
 			return
+		} else {
+			log.Printf("Error cancelling order: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
 		}
+		return
 	}
 
-	// Update order status
-	if err := h.controller.Update_OrderStatus(ctx, id, "fulfilled"); err != nil {
-		http.Error(w, "Failed to update order status", http.StatusInternalServerError)
+	// the order is cancelled even when some of its stock could not be released yet
+	for _, warning := range warnings {
+		w.Header().Add("Warning", fmt.Sprintf("199 - %q", warning))
+	}
+	json.NewEncoder(w).Encode(cancelledOrder)
+	// logging
+	log.Printf("Order ID %d cancelled (reason: %q)", id, template_req.Reason)
+}
+
+func (h *Handler_Orders) Create_Return(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	orderID, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
 		return
 	}
 
-	updatedOrder, err := h.controller.Get_ByOrderID(ctx, id)
+	// the body is optional, without items every returnable quantity is returned
+	var template_req struct {
+		Items  []orders_dmodel.ReturnLine `json:"items"`
+		Reason string                     `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	ret, err := h.controller.Create_Return(ctx, orderID, template_req.Items, template_req.Reason)
 	if err != nil {
-		log.Printf("Error getting updated order by ID: Repository error: %v", err)
+		if !writeReturnError(w, err) {
+			log.Printf("Error creating return: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ret)
+	// logging
+	log.Printf("Return ID %d authorized for order ID %d", ret.ID, orderID)
+}
+
+func (h *Handler_Orders) Get_Returns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	orderID, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// getting the controller's response
+	returns, err := h.controller.Get_Returns(ctx, orderID)
+	if err != nil {
+		if err == internal.ErrItemNotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+		} else {
+			log.Printf("Error getting order returns: Repository error: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(returns)
+	if err != nil {
+		log.Printf("Error encoding returns to JSON: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+}
 
-	json.NewEncoder(w).Encode(updatedOrder)
+func (h *Handler_Orders) Receive_Return(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	r_params := mux.Vars(r)
+	orderID, err := strconv.Atoi(r_params["orderId"])
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+	returnID, err := strconv.Atoi(r_params["returnId"])
+	if err != nil {
+		http.Error(w, "Invalid return ID", http.StatusBadRequest)
+		return
+	}
+
+	// the body is optional, without damaged items everything is restocked
+	var template_req struct {
+		Damaged              []orders_dmodel.ReturnLine `json:"damaged"`
+		SerialNumbers        []string                   `json:"serial_numbers"`
+		DamagedSerialNumbers []string                   `json:"damaged_serial_numbers"`
+		Lots                 []orders_dmodel.ReturnLot  `json:"lots"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&template_req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	ret, err := h.controller.Receive_Return(ctx, orderID, returnID, template_req.Damaged, template_req.SerialNumbers, template_req.DamagedSerialNumbers, template_req.Lots)
+	if err != nil {
+		if writeReturnError(w, err) {
+			return
+		}
+		log.Printf("Error receiving return: %v", err)
+		if errors.Is(err, internal.ErrRestockFailed) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		} else {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(ret)
+	// logging
+	log.Printf("Return ID %d of order ID %d received", returnID, orderID)
 }
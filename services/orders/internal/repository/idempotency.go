@@ -0,0 +1,105 @@
+package orders_repository
+
+import (
+	"context"
+	"database/sql"
+	internal "orders-service/internal"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// idempotency keys
+// -------------------------------------------------------------------
+
+// claim an idempotency key for a request, holding it for lease
+// returns (nil, nil) when the caller owns the key and must execute the request,
+// or the stored response when the request was already executed
+// a key whose request is still in progress past its lease (its caller crashed) is taken
+// over by a request with the same fingerprint
+func (dr *DataRepo_Orders) Begin_IdempotentRequest(ctx context.Context, scope, key, requestHash string, expiredBefore time.Time, lease time.Duration) ([]byte, error) {
+	// a key past the retention window may be reused
+	deleteQuery := `DELETE FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2 AND created_at < $3`
+	if _, err := dr.db.ExecContext(ctx, deleteQuery, scope, key, expiredBefore); err != nil {
+		return nil, err
+	}
+
+	insertQuery := `
+		INSERT INTO idempotency_keys (scope, idempotency_key, request_hash, locked_until)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))
+		ON CONFLICT (scope, idempotency_key) DO UPDATE SET locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.response IS NULL AND idempotency_keys.locked_until < CURRENT_TIMESTAMP
+			AND idempotency_keys.request_hash = EXCLUDED.request_hash`
+	result, err := dr.db.ExecContext(ctx, insertQuery, scope, key, requestHash, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 1 {
+		return nil, nil
+	}
+
+	// the key already exists
+	var storedHash string
+	var response []byte
+	query := `SELECT request_hash, response FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2`
+	err = dr.db.QueryRowContext(ctx, query, scope, key).Scan(&storedHash, &response)
+	if err == sql.ErrNoRows {
+		// deleted in the meantime (failed request or purge), let the client retry
+		return nil, internal.ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if storedHash != requestHash {
+		return nil, internal.ErrIdempotencyKeyReused
+	}
+	if response == nil {
+		return nil, internal.ErrIdempotencyKeyInProgress
+	}
+
+	return response, nil
+}
+
+// store the response of a request executed under an idempotency key
+func (dr *DataRepo_Orders) Complete_IdempotentRequest(ctx context.Context, scope, key string, response []byte) error {
+	query := `UPDATE idempotency_keys SET response = $1, completed_at = CURRENT_TIMESTAMP WHERE scope = $2 AND idempotency_key = $3`
+	result, err := dr.db.ExecContext(ctx, query, string(response), scope, key)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return internal.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// forget an idempotency key, e.g. after the request failed
+func (dr *DataRepo_Orders) Delete_IdempotentRequest(ctx context.Context, scope, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE scope = $1 AND idempotency_key = $2`
+	_, err := dr.db.ExecContext(ctx, query, scope, key)
+	return err
+}
+
+// delete every idempotency key created before createdBefore
+func (dr *DataRepo_Orders) Purge_IdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at < $1 AND scope LIKE 'orders.%'`
+	result, err := dr.db.ExecContext(ctx, query, createdBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// -------------------------------------------------------------------
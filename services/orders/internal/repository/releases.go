@@ -0,0 +1,110 @@
+package orders_repository
+
+import (
+	"context"
+	"database/sql"
+	internal "orders-service/internal"
+	dmodel "orders-service/pkg"
+	"time"
+)
+
+// -------------------------------------------------------------------
+// reservation releases
+// -------------------------------------------------------------------
+
+// the stock still reserved by the items of an order is recorded as pending releases in the
+// transaction that cancels or expires the order, so a release that fails, or is interrupted by a
+// crash, is never lost: it stays pending until the inventory has released it
+
+const releaseColumns = `id, order_id, order_item_id, product_id, COALESCE(reservation_id, 0), quantity, status, attempts`
+
+func scanReleases(rows *sql.Rows) ([]*dmodel.Release, error) {
+	defer rows.Close()
+
+	var releases []*dmodel.Release
+	for rows.Next() {
+		var r dmodel.Release
+		if err := rows.Scan(&r.ID, &r.OrderID, &r.OrderItemID, &r.ProductID, &r.ReservationID, &r.Quantity, &r.Status, &r.Attempts); err != nil {
+			return nil, err
+		}
+		releases = append(releases, &r)
+	}
+
+	return releases, rows.Err()
+}
+
+// record a pending release of the unfulfilled quantity of every item of an order holding a
+// reservation; the items without one (orders placed before reservations were recorded) are
+// recorded as needing manual attention
+func insertReleases(ctx context.Context, tx *sql.Tx, orderID int) error {
+	query := `
+		INSERT INTO order_releases (order_id, order_item_id, product_id, reservation_id, quantity, status)
+		SELECT order_id, id, product_id, reservation_id, quantity - fulfilled_quantity,
+			CASE WHEN reservation_id IS NULL THEN $2 ELSE $3 END
+		FROM order_items
+		WHERE order_id = $1 AND quantity > fulfilled_quantity
+		ON CONFLICT (order_item_id) DO NOTHING`
+	_, err := tx.ExecContext(ctx, query, orderID, dmodel.ReleaseManual, dmodel.ReleasePending)
+	return err
+}
+
+// the releases of an order still pending, in order item order
+func (dr *DataRepo_Orders) Get_PendingReleases(ctx context.Context, orderID int) ([]*dmodel.Release, error) {
+	query := `SELECT ` + releaseColumns + ` FROM order_releases WHERE order_id = $1 AND status = $2 ORDER BY order_item_id`
+	rows, err := dr.db.QueryContext(ctx, query, orderID, dmodel.ReleasePending)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReleases(rows)
+}
+
+// claim pending releases that have not been attempted since staleBefore
+// claiming bumps updated_at, so concurrent replicas never retry the same release
+func (dr *DataRepo_Orders) Claim_PendingReleases(ctx context.Context, staleBefore time.Time, limit int) ([]*dmodel.Release, error) {
+	query := `
+		UPDATE order_releases SET updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM order_releases
+			WHERE status = $1 AND updated_at < $2
+			ORDER BY updated_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + releaseColumns
+	rows, err := dr.db.QueryContext(ctx, query, dmodel.ReleasePending, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReleases(rows)
+}
+
+// record the outcome of an attempt to release: released when cause is nil, otherwise left
+// pending with the cause of the failure
+func (dr *DataRepo_Orders) Update_ReleaseAttempt(ctx context.Context, releaseID int, cause error) error {
+	status, lastError := dmodel.ReleaseReleased, ""
+	if cause != nil {
+		status, lastError = dmodel.ReleasePending, cause.Error()
+	}
+
+	query := `
+		UPDATE order_releases SET status = $1, attempts = attempts + 1, last_error = NULLIF($2, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3`
+	result, err := dr.db.ExecContext(ctx, query, status, lastError, releaseID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return internal.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
@@ -3,8 +3,10 @@ package orders_repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	internal "orders-service/internal"
 	dmodel "orders-service/pkg"
+	"slices"
 	"time"
 )
 
@@ -31,7 +33,7 @@ func New(db *sql.DB) *DataRepo_Orders {
 // -------------------------------------------------------------------
 
 func (dr *DataRepo_Orders) Get_All(ctx context.Context) ([]*dmodel.Order, error) {
-	query := `SELECT id, customer_id, status, total_amount, created_at FROM orders ORDER BY created_at DESC`
+	query := `SELECT id, customer_id, status, total_amount, currency, created_at, COALESCE(cancellation_reason, '') FROM orders ORDER BY created_at DESC`
 	rows, err := dr.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -41,7 +43,7 @@ func (dr *DataRepo_Orders) Get_All(ctx context.Context) ([]*dmodel.Order, error)
 	var orders []*dmodel.Order
 	for rows.Next() {
 		var o dmodel.Order
-		if err := rows.Scan(&o.ID, &o.CustomerID, &o.Status, &o.TotalAmount, &o.CreatedAt); err != nil {
+		if err := rows.Scan(&o.ID, &o.CustomerID, &o.Status, &o.TotalAmount, &o.Currency, &o.CreatedAt, &o.CancellationReason); err != nil {
 			return nil, err
 		}
 
@@ -51,6 +53,7 @@ func (dr *DataRepo_Orders) Get_All(ctx context.Context) ([]*dmodel.Order, error)
 			return nil, err
 		}
 		o.Items = items
+		o.SetCurrency(o.Currency)
 
 		orders = append(orders, &o)
 	}
@@ -59,10 +62,10 @@ func (dr *DataRepo_Orders) Get_All(ctx context.Context) ([]*dmodel.Order, error)
 }
 
 func (dr *DataRepo_Orders) Get_ByOrderID(ctx context.Context, id int) (*dmodel.Order, error) {
-	query := `SELECT id, customer_id, status, total_amount, created_at FROM orders WHERE id = $1`
+	query := `SELECT id, customer_id, status, total_amount, currency, created_at, COALESCE(cancellation_reason, '') FROM orders WHERE id = $1`
 	var o dmodel.Order
 
-	err := dr.db.QueryRowContext(ctx, query, id).Scan(&o.ID, &o.CustomerID, &o.Status, &o.TotalAmount, &o.CreatedAt)
+	err := dr.db.QueryRowContext(ctx, query, id).Scan(&o.ID, &o.CustomerID, &o.Status, &o.TotalAmount, &o.Currency, &o.CreatedAt, &o.CancellationReason)
 	if err == sql.ErrNoRows {
 		return nil, internal.ErrItemNotFound
 	}
@@ -76,6 +79,7 @@ func (dr *DataRepo_Orders) Get_ByOrderID(ctx context.Context, id int) (*dmodel.O
 		return nil, err
 	}
 	o.Items = items
+	o.SetCurrency(o.Currency)
 
 	return &o, nil
 }
@@ -83,7 +87,7 @@ func (dr *DataRepo_Orders) Get_ByOrderID(ctx context.Context, id int) (*dmodel.O
 // -------------------------------------------------------------------
 
 func (dr *DataRepo_Orders) getOrderItems(ctx context.Context, orderID int) ([]dmodel.OrderItem, error) {
-	query := `SELECT product_id, quantity, price_at_order FROM order_items WHERE order_id = $1`
+	query := `SELECT id, product_id, quantity, fulfilled_quantity, returned_quantity, price_at_order, COALESCE(reservation_id, 0) FROM order_items WHERE order_id = $1 ORDER BY id`
 	rows, err := dr.db.QueryContext(ctx, query, orderID)
 	if err != nil {
 		return nil, err
@@ -93,54 +97,473 @@ func (dr *DataRepo_Orders) getOrderItems(ctx context.Context, orderID int) ([]dm
 	var items []dmodel.OrderItem
 	for rows.Next() {
 		var item dmodel.OrderItem
-		if err := rows.Scan(&item.ProductID, &item.Quantity, &item.Price); err != nil {
+		if err := rows.Scan(&item.ID, &item.ProductID, &item.Quantity, &item.FulfilledQuantity, &item.ReturnedQuantity, &item.Price, &item.ReservationID); err != nil {
 			return nil, err
 		}
+		item.LineTotal = item.Price.Mul(item.Quantity)
 		items = append(items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := dr.loadItemLots(ctx, orderID, items); err != nil {
+		return nil, err
+	}
 
-	return items, rows.Err()
+	return items, dr.loadItemSerials(ctx, orderID, items)
 }
 
-// -------------------------------------------------------------------
+// fill in the lots the items of an order were fulfilled from
+func (dr *DataRepo_Orders) loadItemLots(ctx context.Context, orderID int, items []dmodel.OrderItem) error {
+	query := `
+		SELECT l.order_item_id, l.lot_number, l.expires_at, l.quantity
+		FROM order_item_lots l JOIN order_items i ON i.id = l.order_item_id
+		WHERE i.order_id = $1 ORDER BY l.order_item_id, l.expires_at NULLS LAST, l.lot_number`
+	rows, err := dr.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
-func (dr *DataRepo_Orders) Create_Order(ctx context.Context, order *dmodel.Order) (*dmodel.Order, error) {
-	tx, err := dr.db.BeginTx(ctx, nil)
+	for rows.Next() {
+		var itemID int
+		var lot dmodel.OrderItemLot
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&itemID, &lot.LotNumber, &expiresAt, &lot.Quantity); err != nil {
+			return err
+		}
+		if expiresAt.Valid {
+			lot.ExpiresAt = &expiresAt.Time
+		}
+		for i := range items {
+			if items[i].ID == itemID {
+				items[i].Lots = append(items[i].Lots, lot)
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// fill in the serialized units shipped for the items of an order
+func (dr *DataRepo_Orders) loadItemSerials(ctx context.Context, orderID int, items []dmodel.OrderItem) error {
+	query := `
+		SELECT s.order_item_id, s.serial_number
+		FROM order_item_serials s JOIN order_items i ON i.id = s.order_item_id
+		WHERE i.order_id = $1 ORDER BY s.order_item_id, s.serial_number`
+	rows, err := dr.db.QueryContext(ctx, query, orderID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int
+		var serial string
+		if err := rows.Scan(&itemID, &serial); err != nil {
+			return err
+		}
+		for i := range items {
+			if items[i].ID == itemID {
+				items[i].SerialNumbers = append(items[i].SerialNumbers, serial)
+			}
+		}
 	}
-	defer tx.Rollback()
 
+	return rows.Err()
+}
+
+// -------------------------------------------------------------------
+
+// insert an order and its items inside the given transaction
+func (dr *DataRepo_Orders) insertOrder(ctx context.Context, tx *sql.Tx, order *dmodel.Order, actor string) error {
 	order.CreatedAt = time.Now()
-	order.Status = "pending"
+	order.Status = dmodel.OrderStatusPending
 
-	query := `INSERT INTO orders (customer_id, status, total_amount, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
-	err = tx.QueryRowContext(ctx, query, order.CustomerID, order.Status, order.TotalAmount, order.CreatedAt).Scan(&order.ID)
+	query := `INSERT INTO orders (customer_id, status, total_amount, currency, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, order.CustomerID, order.Status, order.TotalAmount, order.Currency, order.CreatedAt).Scan(&order.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Insert order items
-	itemQuery := `INSERT INTO order_items (order_id, product_id, quantity, price_at_order) VALUES ($1, $2, $3, $4)`
+	itemQuery := `INSERT INTO order_items (order_id, product_id, quantity, price_at_order, reservation_id) VALUES ($1, $2, $3, $4, NULLIF($5, 0))`
 	for _, item := range order.Items {
-		_, err = tx.ExecContext(ctx, itemQuery, order.ID, item.ProductID, item.Quantity, item.Price)
+		_, err = tx.ExecContext(ctx, itemQuery, order.ID, item.ProductID, item.Quantity, item.Price, item.ReservationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return dr.insertStatusChange(ctx, tx, order.ID, "", order.Status, actor, "")
+}
+
+// -------------------------------------------------------------------
+
+// move an order to status if its current status is one of from, recording the change
+// the row is locked while it is checked, so concurrent transitions cannot both succeed
+func (dr *DataRepo_Orders) Update_OrderStatus(ctx context.Context, id int, from []string, status, actor, reason string) error {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = dr.transitionOrder(ctx, tx, id, from, status, actor, reason); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// cancel an order whose current status is one of from, keeping the reason on the order and
+// recording the release of the stock its items still reserve
+func (dr *DataRepo_Orders) Cancel_Order(ctx context.Context, id int, from []string, actor, reason string) error {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = dr.transitionOrder(ctx, tx, id, from, dmodel.OrderStatusCancelled, actor, reason); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE orders SET cancellation_reason = $1 WHERE id = $2`, reason, id)
+	if err != nil {
+		return err
+	}
+
+	// quantities fulfilled up to the cancellation are not released
+	if err = insertReleases(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// record the lines fulfilled by a fulfillment wave, with the lots they were taken from and
+// the serialized units shipped, and move the order to status
+// each line is only updated if its fulfilled quantity is still the one the wave was
+// planned from, so two concurrent waves cannot both count the same quantity
+func (dr *DataRepo_Orders) Record_Fulfillment(ctx context.Context, id int, from []string, status, actor, reason string, lines []dmodel.OrderItemFulfillment) error {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = dr.transitionOrder(ctx, tx, id, from, status, actor, reason); err != nil {
+		return err
+	}
+
+	query := `UPDATE order_items SET fulfilled_quantity = fulfilled_quantity + $1 WHERE id = $2 AND order_id = $3 AND fulfilled_quantity = $4`
+	for _, line := range lines {
+		result, err := tx.ExecContext(ctx, query, line.Quantity, line.ItemID, id, line.Fulfilled)
 		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return internal.ErrFulfillmentConflict
+		}
+
+		lotQuery := `
+			INSERT INTO order_item_lots (order_item_id, lot_number, expires_at, quantity) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (order_item_id, lot_number) DO UPDATE SET quantity = order_item_lots.quantity + EXCLUDED.quantity`
+		for _, lot := range line.Lots {
+			if _, err := tx.ExecContext(ctx, lotQuery, line.ItemID, lot.LotNumber, lot.ExpiresAt, lot.Quantity); err != nil {
+				return err
+			}
+		}
+
+		serialQuery := `INSERT INTO order_item_serials (order_item_id, serial_number) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		for _, serial := range line.SerialNumbers {
+			if _, err := tx.ExecContext(ctx, serialQuery, line.ItemID, serial); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// retrieving the orders a lot went out with (of a product, or of any product holding a lot
+// with this number when productID is 0), the latest first
+func (dr *DataRepo_Orders) Get_LotShipments(ctx context.Context, lotNumber string, productID int) ([]*dmodel.LotShipment, error) {
+	query := `
+		SELECT o.id, o.customer_id, o.status, i.product_id, l.lot_number, l.quantity
+		FROM order_item_lots l
+		JOIN order_items i ON i.id = l.order_item_id
+		JOIN orders o ON o.id = i.order_id
+		WHERE l.lot_number = $1 AND ($2 = 0 OR i.product_id = $2)
+		ORDER BY o.created_at DESC, o.id DESC, i.product_id`
+	rows, err := dr.db.QueryContext(ctx, query, lotNumber, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments := []*dmodel.LotShipment{}
+	for rows.Next() {
+		var s dmodel.LotShipment
+		if err := rows.Scan(&s.OrderID, &s.CustomerID, &s.Status, &s.ProductID, &s.LotNumber, &s.Quantity); err != nil {
 			return nil, err
 		}
+		shipments = append(shipments, &s)
 	}
 
-	if err = tx.Commit(); err != nil {
+	return shipments, rows.Err()
+}
+
+// retrieving the serialized units shipped with an order
+func (dr *DataRepo_Orders) Get_OrderSerials(ctx context.Context, orderID int) ([]*dmodel.OrderSerial, error) {
+	var exists bool
+	if err := dr.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)`, orderID).Scan(&exists); err != nil {
 		return nil, err
 	}
+	if !exists {
+		return nil, internal.ErrItemNotFound
+	}
 
-	return order, nil
+	query := `
+		SELECT i.product_id, s.serial_number
+		FROM order_item_serials s JOIN order_items i ON i.id = s.order_item_id
+		WHERE i.order_id = $1
+		ORDER BY i.product_id, s.serial_number`
+	rows, err := dr.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	serials := []*dmodel.OrderSerial{}
+	for rows.Next() {
+		var s dmodel.OrderSerial
+		if err := rows.Scan(&s.ProductID, &s.SerialNumber); err != nil {
+			return nil, err
+		}
+		serials = append(serials, &s)
+	}
+
+	return serials, rows.Err()
+}
+
+// retrieving the orders a serialized unit went out with (of a product, or of any product
+// with a unit of this serial number when productID is 0), the latest first; a unit that
+// was returned and shipped again went out with several orders
+func (dr *DataRepo_Orders) Get_SerialShipments(ctx context.Context, serialNumber string, productID int) ([]*dmodel.SerialShipment, error) {
+	query := `
+		SELECT o.id, o.customer_id, o.status, i.product_id, s.serial_number
+		FROM order_item_serials s
+		JOIN order_items i ON i.id = s.order_item_id
+		JOIN orders o ON o.id = i.order_id
+		WHERE s.serial_number = $1 AND ($2 = 0 OR i.product_id = $2)
+		ORDER BY o.created_at DESC, o.id DESC, i.product_id`
+	rows, err := dr.db.QueryContext(ctx, query, serialNumber, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments := []*dmodel.SerialShipment{}
+	for rows.Next() {
+		var s dmodel.SerialShipment
+		if err := rows.Scan(&s.OrderID, &s.CustomerID, &s.Status, &s.ProductID, &s.SerialNumber); err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, &s)
+	}
+
+	return shipments, rows.Err()
+}
+
+func (dr *DataRepo_Orders) transitionOrder(ctx context.Context, tx *sql.Tx, id int, from []string, status, actor, reason string) error {
+	var current string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err == sql.ErrNoRows {
+		return internal.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(from, current) {
+		return fmt.Errorf("%w: order %d is %s, cannot become %s", internal.ErrInvalidStatusTransition, id, current, status)
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE orders SET status = $1 WHERE id = $2`, status, id); err != nil {
+		return err
+	}
+
+	return dr.insertStatusChange(ctx, tx, id, current, status, actor, reason)
+}
+
+func (dr *DataRepo_Orders) insertStatusChange(ctx context.Context, tx *sql.Tx, id int, from, to, actor, reason string) error {
+	query := `INSERT INTO order_status_history (order_id, from_status, to_status, actor, reason) VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''))`
+	_, err := tx.ExecContext(ctx, query, id, from, to, actor, reason)
+	return err
+}
+
+func (dr *DataRepo_Orders) Get_OrderHistory(ctx context.Context, id int) ([]dmodel.OrderStatusChange, error) {
+	var exists bool
+	if err := dr.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, internal.ErrItemNotFound
+	}
+
+	query := `
+		SELECT COALESCE(from_status, ''), to_status, actor, COALESCE(reason, ''), changed_at
+		FROM order_status_history WHERE order_id = $1 ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []dmodel.OrderStatusChange{}
+	for rows.Next() {
+		var c dmodel.OrderStatusChange
+		if err := rows.Scan(&c.FromStatus, &c.ToStatus, &c.Actor, &c.Reason, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+
+	return history, rows.Err()
 }
 
 // -------------------------------------------------------------------
 
-func (dr *DataRepo_Orders) Update_OrderStatus(ctx context.Context, id int, status string) error {
-	query := `UPDATE orders SET status = $1 WHERE id = $2`
-	result, err := dr.db.ExecContext(ctx, query, status, id)
+// mark pending orders created before createdBefore as expired, recording the release of the
+// stock their items reserve in the same statement (like insertReleases), and return them
+// rows locked by another replica are skipped, so every order is expired exactly once
+func (dr *DataRepo_Orders) Expire_PendingOrders(ctx context.Context, createdBefore time.Time, limit int, actor string) ([]*dmodel.Order, error) {
+	query := `
+		WITH expired AS (
+			UPDATE orders SET status = 'expired'
+			WHERE id IN (
+				SELECT id FROM orders
+				WHERE status = 'pending' AND created_at < $1
+				ORDER BY created_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, customer_id, status, total_amount, currency, created_at
+		), history AS (
+			INSERT INTO order_status_history (order_id, from_status, to_status, actor, reason)
+			SELECT id, 'pending', 'expired', $3, 'reservation expired' FROM expired
+		), releases AS (
+			INSERT INTO order_releases (order_id, order_item_id, product_id, reservation_id, quantity, status)
+			SELECT i.order_id, i.id, i.product_id, i.reservation_id, i.quantity - i.fulfilled_quantity,
+				CASE WHEN i.reservation_id IS NULL THEN $4 ELSE $5 END
+			FROM order_items i JOIN expired e ON e.id = i.order_id
+			WHERE i.quantity > i.fulfilled_quantity
+			ON CONFLICT (order_item_id) DO NOTHING
+		)
+		SELECT id, customer_id, status, total_amount, currency, created_at FROM expired`
+	rows, err := dr.db.QueryContext(ctx, query, createdBefore, limit, actor, dmodel.ReleaseManual, dmodel.ReleasePending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*dmodel.Order
+	for rows.Next() {
+		var o dmodel.Order
+		if err := rows.Scan(&o.ID, &o.CustomerID, &o.Status, &o.TotalAmount, &o.Currency, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		orders = append(orders, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load order items
+	for _, o := range orders {
+		items, err := dr.getOrderItems(ctx, o.ID)
+		if err != nil {
+			return nil, err
+		}
+		o.Items = items
+		o.SetCurrency(o.Currency)
+	}
+
+	return orders, nil
+}
+
+// -------------------------------------------------------------------
+
+// -------------------------------------------------------------------
+// create order saga state
+// -------------------------------------------------------------------
+
+// start a new saga for a customer's order
+func (dr *DataRepo_Orders) Create_Saga(ctx context.Context, customerID int) (int, error) {
+	query := `INSERT INTO order_sagas (customer_id, status) VALUES ($1, $2) RETURNING id`
+	var id int
+	err := dr.db.QueryRowContext(ctx, query, customerID, dmodel.SagaStatusStarted).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// record the intent to reserve inventory, before the reservation is attempted
+func (dr *DataRepo_Orders) Create_SagaStep(ctx context.Context, sagaID, productID, quantity int) (int, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := `INSERT INTO order_saga_steps (saga_id, product_id, quantity, status) VALUES ($1, $2, $3, $4) RETURNING id`
+	err = tx.QueryRowContext(ctx, query, sagaID, productID, quantity, dmodel.SagaStepReserving).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	// keep the saga's lease fresh so recovery does not pick up an in-flight saga
+	_, err = tx.ExecContext(ctx, `UPDATE order_sagas SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, sagaID)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func (dr *DataRepo_Orders) Update_SagaStepStatus(ctx context.Context, stepID int, status string) error {
+	query := `UPDATE order_saga_steps SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	result, err := dr.db.ExecContext(ctx, query, status, stepID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return internal.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// record a step as reserved, together with the inventory reservation holding its stock
+func (dr *DataRepo_Orders) Update_SagaStepReserved(ctx context.Context, stepID, reservationID int) error {
+	query := `UPDATE order_saga_steps SET status = $1, reservation_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+	result, err := dr.db.ExecContext(ctx, query, dmodel.SagaStepReserved, reservationID, stepID)
 	if err != nil {
 		return err
 	}
@@ -156,4 +579,108 @@ func (dr *DataRepo_Orders) Update_OrderStatus(ctx context.Context, id int, statu
 	return nil
 }
 
+func (dr *DataRepo_Orders) Update_SagaStatus(ctx context.Context, sagaID int, status, reason string) error {
+	query := `UPDATE order_sagas SET status = $1, failure_reason = COALESCE(NULLIF($2, ''), failure_reason), updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+	result, err := dr.db.ExecContext(ctx, query, status, reason, sagaID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return internal.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (dr *DataRepo_Orders) Get_SagaSteps(ctx context.Context, sagaID int) ([]dmodel.SagaStep, error) {
+	query := `SELECT id, saga_id, product_id, quantity, status, COALESCE(reservation_id, 0) FROM order_saga_steps WHERE saga_id = $1 ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, sagaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var steps []dmodel.SagaStep
+	for rows.Next() {
+		var step dmodel.SagaStep
+		if err := rows.Scan(&step.ID, &step.SagaID, &step.ProductID, &step.Quantity, &step.Status, &step.ReservationID); err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, rows.Err()
+}
+
+// create the order and mark the saga as completed in a single transaction,
+// so a crash can never leave an order without a completed saga (or vice versa)
+func (dr *DataRepo_Orders) Complete_Saga(ctx context.Context, sagaID int, order *dmodel.Order, actor string) (*dmodel.Order, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = dr.insertOrder(ctx, tx, order, actor); err != nil {
+		return nil, err
+	}
+
+	// only a saga that is still in progress may complete (recovery may have taken it over)
+	query := `UPDATE order_sagas SET status = $1, order_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND status = $4`
+	result, err := tx.ExecContext(ctx, query, dmodel.SagaStatusCompleted, order.ID, sagaID, dmodel.SagaStatusStarted)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("saga %d is no longer in progress", sagaID)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// claim unfinished sagas that have not been touched since staleBefore
+// claiming bumps updated_at, so concurrent replicas never process the same saga
+func (dr *DataRepo_Orders) Claim_StaleSagas(ctx context.Context, staleBefore time.Time, limit int) ([]*dmodel.Saga, error) {
+	query := `
+		UPDATE order_sagas SET updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM order_sagas
+			WHERE status IN ($1, $2) AND updated_at < $3
+			ORDER BY updated_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, customer_id, COALESCE(order_id, 0), status, updated_at`
+	rows, err := dr.db.QueryContext(ctx, query, dmodel.SagaStatusStarted, dmodel.SagaStatusCompensating, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []*dmodel.Saga
+	for rows.Next() {
+		var s dmodel.Saga
+		if err := rows.Scan(&s.ID, &s.CustomerID, &s.OrderID, &s.Status, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, &s)
+	}
+
+	return sagas, rows.Err()
+}
+
 // -------------------------------------------------------------------
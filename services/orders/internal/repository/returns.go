@@ -0,0 +1,221 @@
+package orders_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	internal "orders-service/internal"
+	dmodel "orders-service/pkg"
+	"slices"
+)
+
+// -------------------------------------------------------------------
+// returns
+// -------------------------------------------------------------------
+
+// authorize the return of the given order lines, if the order status is one of from
+// each line's returned quantity is only increased while it stays within its fulfilled
+// quantity, so concurrent authorizations can never return more than was fulfilled
+func (dr *DataRepo_Orders) Create_Return(ctx context.Context, orderID int, from []string, reason string, items []dmodel.ReturnItem) (*dmodel.Return, error) {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(from, status) {
+		return nil, fmt.Errorf("%w: order %d is %s", internal.ErrInvalidReturn, orderID, status)
+	}
+
+	ret := &dmodel.Return{
+		OrderID: orderID,
+		Status:  dmodel.ReturnStatusAuthorized,
+		Reason:  reason,
+	}
+	query := `INSERT INTO order_returns (order_id, status, reason) VALUES ($1, $2, NULLIF($3, '')) RETURNING id, created_at`
+	if err = tx.QueryRowContext(ctx, query, orderID, ret.Status, reason).Scan(&ret.ID, &ret.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	updateQuery := `UPDATE order_items SET returned_quantity = returned_quantity + $1 WHERE id = $2 AND order_id = $3 AND returned_quantity + $1 <= fulfilled_quantity`
+	itemQuery := `INSERT INTO order_return_items (return_id, order_item_id, product_id, quantity) VALUES ($1, $2, $3, $4) RETURNING id`
+	for _, item := range items {
+		result, err := tx.ExecContext(ctx, updateQuery, item.Quantity, item.OrderItemID, orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rows == 0 {
+			return nil, fmt.Errorf("%w: product %d was already returned", internal.ErrInvalidReturn, item.ProductID)
+		}
+
+		if err = tx.QueryRowContext(ctx, itemQuery, ret.ID, item.OrderItemID, item.ProductID, item.Quantity).Scan(&item.ID); err != nil {
+			return nil, err
+		}
+		ret.Items = append(ret.Items, item)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (dr *DataRepo_Orders) Get_Returns(ctx context.Context, orderID int) ([]*dmodel.Return, error) {
+	var exists bool
+	if err := dr.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)`, orderID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, internal.ErrItemNotFound
+	}
+
+	query := `SELECT id, order_id, status, COALESCE(reason, ''), created_at, received_at FROM order_returns WHERE order_id = $1 ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	returns := []*dmodel.Return{}
+	for rows.Next() {
+		var r dmodel.Return
+		if err := rows.Scan(&r.ID, &r.OrderID, &r.Status, &r.Reason, &r.CreatedAt, &r.ReceivedAt); err != nil {
+			return nil, err
+		}
+		returns = append(returns, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Load return items
+	for _, r := range returns {
+		items, err := dr.getReturnItems(ctx, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		r.Items = items
+	}
+
+	return returns, nil
+}
+
+func (dr *DataRepo_Orders) Get_Return(ctx context.Context, orderID, returnID int) (*dmodel.Return, error) {
+	query := `SELECT id, order_id, status, COALESCE(reason, ''), created_at, received_at FROM order_returns WHERE id = $1 AND order_id = $2`
+	var r dmodel.Return
+
+	err := dr.db.QueryRowContext(ctx, query, returnID, orderID).Scan(&r.ID, &r.OrderID, &r.Status, &r.Reason, &r.CreatedAt, &r.ReceivedAt)
+	if err == sql.ErrNoRows {
+		return nil, internal.ErrReturnNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Load return items
+	items, err := dr.getReturnItems(ctx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	r.Items = items
+
+	return &r, nil
+}
+
+func (dr *DataRepo_Orders) getReturnItems(ctx context.Context, returnID int) ([]dmodel.ReturnItem, error) {
+	query := `SELECT id, order_item_id, product_id, quantity, restocked, damaged FROM order_return_items WHERE return_id = $1 ORDER BY id`
+	rows, err := dr.db.QueryContext(ctx, query, returnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []dmodel.ReturnItem
+	for rows.Next() {
+		var item dmodel.ReturnItem
+		if err := rows.Scan(&item.ID, &item.OrderItemID, &item.ProductID, &item.Quantity, &item.Restocked, &item.Damaged); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// mark an authorized return as received with the restocked and damaged quantity of
+// each item; once every unit of the order came back and no return is still awaited,
+// the order moves to returned
+func (dr *DataRepo_Orders) Receive_Return(ctx context.Context, orderID, returnID int, items []dmodel.ReturnItem, actor string) error {
+	tx, err := dr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// lock the order first, as every other status change does
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return internal.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var returnStatus string
+	query := `SELECT status FROM order_returns WHERE id = $1 AND order_id = $2 FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, returnID, orderID).Scan(&returnStatus)
+	if err == sql.ErrNoRows {
+		return internal.ErrReturnNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if returnStatus != dmodel.ReturnStatusAuthorized {
+		return internal.ErrReturnNotAuthorized
+	}
+
+	itemQuery := `UPDATE order_return_items SET restocked = $1, damaged = $2 WHERE id = $3 AND return_id = $4`
+	for _, item := range items {
+		if _, err = tx.ExecContext(ctx, itemQuery, item.Restocked, item.Damaged, item.ID, returnID); err != nil {
+			return err
+		}
+	}
+
+	query = `UPDATE order_returns SET status = $1, received_at = CURRENT_TIMESTAMP WHERE id = $2`
+	if _, err = tx.ExecContext(ctx, query, dmodel.ReturnStatusReceived, returnID); err != nil {
+		return err
+	}
+
+	var fullyReturned bool
+	query = `
+		SELECT NOT EXISTS (SELECT 1 FROM order_items WHERE order_id = $1 AND returned_quantity < quantity)
+		   AND NOT EXISTS (SELECT 1 FROM order_returns WHERE order_id = $1 AND status = $2)`
+	if err = tx.QueryRowContext(ctx, query, orderID, dmodel.ReturnStatusAuthorized).Scan(&fullyReturned); err != nil {
+		return err
+	}
+	if fullyReturned {
+		from := dmodel.TransitionSources(dmodel.OrderStatusReturned)
+		if err = dr.transitionOrder(ctx, tx, orderID, from, dmodel.OrderStatusReturned, actor, "all items returned"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// -------------------------------------------------------------------
@@ -1,18 +1,334 @@
 package orders_dmodel
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"shared/money"
+)
 
 type OrderItem struct {
-	ProductID int     `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price,omitempty"`
+	ID                int            `json:"-"`
+	ProductID         int            `json:"product_id"`
+	Quantity          int            `json:"quantity"`
+	FulfilledQuantity int            `json:"fulfilled_quantity"`
+	ReturnedQuantity  int            `json:"returned_quantity"`
+	Price             money.Money    `json:"price"`                    // unit price of the product when the order was placed
+	LineTotal         money.Money    `json:"line_total"`               // unit price times quantity
+	ReservationID     int            `json:"reservation_id,omitempty"` // inventory reservation holding the line's stock
+	Lots              []OrderItemLot `json:"lots,omitempty"`           // inventory lots the fulfilled units were taken from
+	SerialNumbers     []string       `json:"serial_numbers,omitempty"` // serialized units shipped for the line
+}
+
+// quantity of the line still reserved and waiting to be fulfilled
+func (item OrderItem) Unfulfilled() int {
+	return item.Quantity - item.FulfilledQuantity
 }
 
 type Order struct {
-	ID          int         `json:"id"`
-	CustomerID  int         `json:"customer_id"`
-	Items       []OrderItem `json:"items"`
-	Status      string      `json:"status"`
-	TotalAmount float64     `json:"total_amount"`
-	CreatedAt   time.Time   `json:"created_at"`
+	ID                 int         `json:"id"`
+	CustomerID         int         `json:"customer_id"`
+	Items              []OrderItem `json:"items"`
+	Status             string      `json:"status"`
+	TotalAmount        money.Money `json:"total_amount"`
+	Currency           string      `json:"currency"` // currency of every amount of the order
+	CreatedAt          time.Time   `json:"created_at"`
+	CancellationReason string      `json:"cancellation_reason,omitempty"`
+}
+
+// computes every line total from the line's unit price, and the order total from the lines
+// the order takes the currency of its lines, which must all be priced in the same one
+func (o *Order) CalculateTotals() error {
+	total := money.Money{}
+	for i := range o.Items {
+		o.Items[i].LineTotal = o.Items[i].Price.Mul(o.Items[i].Quantity)
+
+		var err error
+		if total, err = total.Add(o.Items[i].LineTotal); err != nil {
+			return err
+		}
+	}
+	if total.Currency == "" {
+		total.Currency = money.DefaultCurrency
+	}
+
+	o.TotalAmount = total
+	o.SetCurrency(total.Currency)
+	return nil
+}
+
+// SetCurrency sets the currency of the order and of every amount it holds
+func (o *Order) SetCurrency(currency string) {
+	o.Currency = currency
+	o.TotalAmount.Currency = currency
+	for i := range o.Items {
+		o.Items[i].Price.Currency = currency
+		o.Items[i].LineTotal.Currency = currency
+	}
+}
+
+// amounts are plain numbers in JSON, their currency is read from the currency field
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	if err := json.Unmarshal(data, (*order)(o)); err != nil {
+		return err
+	}
+	o.SetCurrency(o.Currency)
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+// order lifecycle
+// -------------------------------------------------------------------
+
+// order statuses
+const (
+	OrderStatusPending            = "pending"
+	OrderStatusConfirmed          = "confirmed"
+	OrderStatusPartiallyFulfilled = "partially_fulfilled"
+	OrderStatusFulfilled          = "fulfilled"
+	OrderStatusShipped            = "shipped"
+	OrderStatusDelivered          = "delivered"
+	OrderStatusCancelled          = "cancelled"
+	OrderStatusExpired            = "expired"
+	OrderStatusReturned           = "returned"
+)
+
+// allowed transitions between order statuses (cancelled, expired and returned are final)
+// a partially fulfilled order stays so through every fulfillment wave but the last
+var orderTransitions = map[string][]string{
+	OrderStatusPending:            {OrderStatusConfirmed, OrderStatusPartiallyFulfilled, OrderStatusFulfilled, OrderStatusCancelled, OrderStatusExpired},
+	OrderStatusConfirmed:          {OrderStatusPartiallyFulfilled, OrderStatusFulfilled, OrderStatusCancelled},
+	OrderStatusPartiallyFulfilled: {OrderStatusPartiallyFulfilled, OrderStatusFulfilled, OrderStatusCancelled},
+	OrderStatusFulfilled:          {OrderStatusShipped, OrderStatusReturned},
+	OrderStatusShipped:            {OrderStatusDelivered, OrderStatusReturned},
+	OrderStatusDelivered:          {OrderStatusReturned},
+	OrderStatusCancelled:          {},
+	OrderStatusExpired:            {},
+	OrderStatusReturned:           {},
+}
+
+// statuses that can be set directly; the others have side effects on the inventory
+// and are reached through their own operation (fulfill, cancel, expiry, returns)
+var manualOrderStatuses = []string{OrderStatusConfirmed, OrderStatusShipped, OrderStatusDelivered}
+
+func IsOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+func IsManualOrderStatus(status string) bool {
+	return slices.Contains(manualOrderStatuses, status)
+}
+
+func CanTransition(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+// statuses from which an order may move to the given status
+func TransitionSources(to string) []string {
+	var sources []string
+	for from, targets := range orderTransitions {
+		if slices.Contains(targets, to) {
+			sources = append(sources, from)
+		}
+	}
+	slices.Sort(sources)
+	return sources
+}
+
+// OrderStatusChange
+// one entry of an order's status history
+type OrderStatusChange struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// -------------------------------------------------------------------
+// fulfillment
+// -------------------------------------------------------------------
+
+// FulfillmentLine
+// quantity of a product to fulfill, as requested by a client, with the serial numbers of
+// the units shipped when the product is serial-tracked (one per unit)
+type FulfillmentLine struct {
+	ProductID     int      `json:"product_id"`
+	Quantity      int      `json:"quantity"`
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+// OrderItemFulfillment
+// quantity of one order line fulfilled by a fulfillment wave
+type OrderItemFulfillment struct {
+	ItemID        int
+	ProductID     int
+	ReservationID int
+	Fulfilled     int // fulfilled quantity of the line before the wave
+	Quantity      int
+	Lots          []OrderItemLot // lots the inventory took the wave's units from
+	SerialNumbers []string       // serialized units to ship, then the ones the inventory shipped
+}
+
+// OrderItemLot
+// units of an inventory lot an order line was fulfilled from
+type OrderItemLot struct {
+	LotNumber string     `json:"lot_number"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Quantity  int        `json:"quantity"`
+}
+
+// LotShipment
+// units of a lot of a product that went out with an order, to trace a recall back to
+// the customers that received the lot
+type LotShipment struct {
+	OrderID    int    `json:"order_id"`
+	CustomerID int    `json:"customer_id"`
+	Status     string `json:"status"`
+	ProductID  int    `json:"product_id"`
+	LotNumber  string `json:"lot_number"`
+	Quantity   int    `json:"quantity"`
+}
+
+// OrderSerial
+// a serialized unit shipped with an order
+type OrderSerial struct {
+	ProductID    int    `json:"product_id"`
+	SerialNumber string `json:"serial_number"`
+}
+
+// SerialShipment
+// the order a serialized unit went out with, and its customer
+type SerialShipment struct {
+	OrderID      int    `json:"order_id"`
+	CustomerID   int    `json:"customer_id"`
+	Status       string `json:"status"`
+	ProductID    int    `json:"product_id"`
+	SerialNumber string `json:"serial_number"`
+}
+
+// -------------------------------------------------------------------
+// returns
+// -------------------------------------------------------------------
+
+// return authorization statuses
+const (
+	ReturnStatusAuthorized = "authorized"
+	ReturnStatusReceived   = "received"
+)
+
+// ReturnLine
+// quantity of a product, as given by a client when returning an order
+type ReturnLine struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+// ReturnLot
+// restocked units of a product that came from an inventory lot, as given by a client when
+// receiving a return
+type ReturnLot struct {
+	ProductID int    `json:"product_id"`
+	LotNumber string `json:"lot_number"`
+	Quantity  int    `json:"quantity"`
+}
+
+// ReturnItem
+// quantity of one order line covered by a return authorization
+type ReturnItem struct {
+	ID          int `json:"-"`
+	OrderItemID int `json:"-"`
+	ProductID   int `json:"product_id"`
+	Quantity    int `json:"quantity"`
+	Restocked   int `json:"restocked"`
+	Damaged     int `json:"damaged"`
+	// serialized units of the line that came back, restocked and damaged, when named on
+	// receipt
+	SerialNumbers        []string `json:"-"`
+	DamagedSerialNumbers []string `json:"-"`
+	// lots the restocked units go back to, on receipt
+	Lots []OrderItemLot `json:"-"`
+}
+
+// Return
+// return authorization (RMA) against the fulfilled lines of an order
+type Return struct {
+	ID         int          `json:"id"`
+	OrderID    int          `json:"order_id"`
+	Status     string       `json:"status"`
+	Reason     string       `json:"reason,omitempty"`
+	Items      []ReturnItem `json:"items"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ReceivedAt *time.Time   `json:"received_at,omitempty"`
+}
+
+// -------------------------------------------------------------------
+// create order saga
+// -------------------------------------------------------------------
+
+// saga statuses
+const (
+	SagaStatusStarted      = "started"
+	SagaStatusCompleted    = "completed"
+	SagaStatusCompensating = "compensating"
+	SagaStatusCompensated  = "compensated"
+	SagaStatusFailed       = "failed"
+)
+
+// saga step (inventory reservation) statuses
+const (
+	SagaStepReserving = "reserving"
+	SagaStepReserved  = "reserved"
+	SagaStepFailed    = "failed"
+	SagaStepReleased  = "released"
+)
+
+// SagaStep
+// one inventory reservation attempted by a saga
+type SagaStep struct {
+	ID            int    `json:"id"`
+	SagaID        int    `json:"saga_id"`
+	ProductID     int    `json:"product_id"`
+	Quantity      int    `json:"quantity"`
+	Status        string `json:"status"`
+	ReservationID int    `json:"reservation_id,omitempty"` // set once reserved
+}
+
+// Saga
+// durable state of a create order saga
+type Saga struct {
+	ID         int       `json:"id"`
+	CustomerID int       `json:"customer_id"`
+	OrderID    int       `json:"order_id,omitempty"`
+	Status     string    `json:"status"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// -------------------------------------------------------------------
+
+// release statuses; the stock of an item without a recorded reservation (an order placed
+// before reservations were recorded) cannot be released by the release worker, and needs
+// manual attention
+const (
+	ReleasePending  = "pending"
+	ReleaseReleased = "released"
+	ReleaseManual   = "manual"
+)
+
+// Release
+// stock still reserved by an item of a cancelled or expired order, recorded with the status
+// change and returned to the inventory after it
+type Release struct {
+	ID            int    `json:"id"`
+	OrderID       int    `json:"order_id"`
+	OrderItemID   int    `json:"order_item_id"`
+	ProductID     int    `json:"product_id"`
+	ReservationID int    `json:"reservation_id"`
+	Quantity      int    `json:"quantity"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
 }
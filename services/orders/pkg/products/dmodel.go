@@ -1,9 +1,28 @@
 package products_dmodel
 
+import (
+	"encoding/json"
+
+	"shared/money"
+)
+
 type Product struct {
-	ID          int     `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Category    string  `json:"category"`
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Currency    string      `json:"currency"`
+	Category    string      `json:"category"`
+	Version     int         `json:"version"` // incremented by every update of the product
+}
+
+// the price is a plain number in JSON, its currency is read from the currency field
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type product Product
+	if err := json.Unmarshal(data, (*product)(p)); err != nil {
+		return err
+	}
+	p.Price.Currency = p.Currency
+
+	return nil
 }
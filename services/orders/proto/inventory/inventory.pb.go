@@ -21,18 +21,89 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type InventoryItem struct {
+// exact amount of money, in minor units (e.g. cents) of an ISO 4217 currency; the costs of
+// a product are kept in the currency its stock was first costed in
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
-	Reserved      int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// stock of a product at a location, or the totals of every location holding the product
+// (location_id 0, with the stock of each location in locations)
+type InventoryItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Stock     int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	Reserved  int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Damaged   int32                  `protobuf:"varint,4,opt,name=damaged,proto3" json:"damaged,omitempty"`
+	// incremented by every change to the item (the sum of them in totals)
+	Version    int32            `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	LocationId int32            `protobuf:"varint,6,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Locations  []*InventoryItem `protobuf:"bytes,7,rep,name=locations,proto3" json:"locations,omitempty"`
+	// units shipped to the location by transfers, not received yet
+	InTransit int32 `protobuf:"varint,8,opt,name=in_transit,json=inTransit,proto3" json:"in_transit,omitempty"`
+	// low on stock below this many available units (never when 0), and the units to
+	// order then (the sums of them in totals)
+	ReorderPoint    int32 `protobuf:"varint,9,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"`
+	ReorderQuantity int32 `protobuf:"varint,10,opt,name=reorder_quantity,json=reorderQuantity,proto3" json:"reorder_quantity,omitempty"`
+	// unreserved units of expired lots, which cannot be reserved
+	Expired int32 `protobuf:"varint,11,opt,name=expired,proto3" json:"expired,omitempty"`
+	// every unit is registered by serial number
+	SerialTracked bool `protobuf:"varint,12,opt,name=serial_tracked,json=serialTracked,proto3" json:"serial_tracked,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InventoryItem) Reset() {
 	*x = InventoryItem{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[0]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +115,7 @@ func (x *InventoryItem) String() string {
 func (*InventoryItem) ProtoMessage() {}
 
 func (x *InventoryItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[0]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +128,7 @@ func (x *InventoryItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventoryItem.ProtoReflect.Descriptor instead.
 func (*InventoryItem) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{0}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *InventoryItem) GetProductId() int32 {
@@ -81,16 +152,83 @@ func (x *InventoryItem) GetReserved() int32 {
 	return 0
 }
 
+func (x *InventoryItem) GetDamaged() int32 {
+	if x != nil {
+		return x.Damaged
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetLocations() []*InventoryItem {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetInTransit() int32 {
+	if x != nil {
+		return x.InTransit
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetReorderPoint() int32 {
+	if x != nil {
+		return x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetReorderQuantity() int32 {
+	if x != nil {
+		return x.ReorderQuantity
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetExpired() int32 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetSerialTracked() bool {
+	if x != nil {
+		return x.SerialTracked
+	}
+	return false
+}
+
 type GetInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// the item at this location; the totals of every location when 0
+	LocationId int32 `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// the balances as of an RFC 3339 time, rebuilt from the movement ledger; current when empty
+	AsOf          string `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetInventoryRequest) Reset() {
 	*x = GetInventoryRequest{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[1]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -102,7 +240,7 @@ func (x *GetInventoryRequest) String() string {
 func (*GetInventoryRequest) ProtoMessage() {}
 
 func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[1]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +253,7 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
 func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{1}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetInventoryRequest) GetProductId() int32 {
@@ -125,6 +263,20 @@ func (x *GetInventoryRequest) GetProductId() int32 {
 	return 0
 }
 
+func (x *GetInventoryRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *GetInventoryRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
 type GetInventoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -134,7 +286,7 @@ type GetInventoryResponse struct {
 
 func (x *GetInventoryResponse) Reset() {
 	*x = GetInventoryResponse{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[2]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +298,7 @@ func (x *GetInventoryResponse) String() string {
 func (*GetInventoryResponse) ProtoMessage() {}
 
 func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[2]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +311,7 @@ func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
 func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{2}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetInventoryResponse) GetItem() *InventoryItem {
@@ -170,14 +322,18 @@ func (x *GetInventoryResponse) GetItem() *InventoryItem {
 }
 
 type ListInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the items at this location; the totals of every product when 0
+	LocationId int32 `protobuf:"varint,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// the balances as of an RFC 3339 time, rebuilt from the movement ledger; current when empty
+	AsOf          string `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListInventoryRequest) Reset() {
 	*x = ListInventoryRequest{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[3]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -189,7 +345,7 @@ func (x *ListInventoryRequest) String() string {
 func (*ListInventoryRequest) ProtoMessage() {}
 
 func (x *ListInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[3]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -202,7 +358,21 @@ func (x *ListInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInventoryRequest.ProtoReflect.Descriptor instead.
 func (*ListInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{3}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListInventoryRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ListInventoryRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
 }
 
 type ListInventoryResponse struct {
@@ -214,7 +384,7 @@ type ListInventoryResponse struct {
 
 func (x *ListInventoryResponse) Reset() {
 	*x = ListInventoryResponse{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[4]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -226,7 +396,7 @@ func (x *ListInventoryResponse) String() string {
 func (*ListInventoryResponse) ProtoMessage() {}
 
 func (x *ListInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[4]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +409,7 @@ func (x *ListInventoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInventoryResponse.ProtoReflect.Descriptor instead.
 func (*ListInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{4}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListInventoryResponse) GetItems() []*InventoryItem {
@@ -250,16 +420,22 @@ func (x *ListInventoryResponse) GetItems() []*InventoryItem {
 }
 
 type UpdateStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// recorded with the stock movement (optional)
+	Reference string `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	// when set, the update fails with ABORTED unless the item is still at this version
+	ExpectedVersion int32 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// the product's preferred location when 0, checking expected_version against its totals
+	LocationId    int32 `protobuf:"varint,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateStockRequest) Reset() {
 	*x = UpdateStockRequest{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[5]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +447,7 @@ func (x *UpdateStockRequest) String() string {
 func (*UpdateStockRequest) ProtoMessage() {}
 
 func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[5]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +460,7 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
 func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{5}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateStockRequest) GetProductId() int32 {
@@ -301,6 +477,27 @@ func (x *UpdateStockRequest) GetQuantity() int32 {
 	return 0
 }
 
+func (x *UpdateStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
 type UpdateStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -310,7 +507,7 @@ type UpdateStockResponse struct {
 
 func (x *UpdateStockResponse) Reset() {
 	*x = UpdateStockResponse{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[6]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +519,7 @@ func (x *UpdateStockResponse) String() string {
 func (*UpdateStockResponse) ProtoMessage() {}
 
 func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[6]
+	mi := &file_proto_inventory_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +532,7 @@ func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
 func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{6}
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateStockResponse) GetItem() *InventoryItem {
@@ -345,29 +542,43 @@ func (x *UpdateStockResponse) GetItem() *InventoryItem {
 	return nil
 }
 
-type ReserveStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+// adds delta units to the stock (removes them when negative); fails with
+// FAILED_PRECONDITION when the stock would drop below zero or the reserved quantity
+type AdjustStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Delta     int32                  `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	// cycle_count, damage, shrinkage, found or correction
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// recorded with the stock movement (optional)
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// the product's preferred location when 0
+	LocationId int32 `protobuf:"varint,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// cost of each unit added (optional); the product's current unit cost when unset
+	UnitCost *Money `protobuf:"bytes,6,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	// the lot the units are added to, or removed from (optional; untracked when empty)
+	LotNumber string `protobuf:"bytes,7,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	// expiry date (RFC 3339) of a lot units are added to, when it is new (optional)
+	ExpiresAt     string `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveStockRequest) Reset() {
-	*x = ReserveStockRequest{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[7]
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveStockRequest) String() string {
+func (x *AdjustStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveStockRequest) ProtoMessage() {}
+func (*AdjustStockRequest) ProtoMessage() {}
 
-func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[7]
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,91 +589,88 @@ func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
-func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ReserveStockRequest) GetProductId() int32 {
+func (x *AdjustStockRequest) GetProductId() int32 {
 	if x != nil {
 		return x.ProductId
 	}
 	return 0
 }
 
-func (x *ReserveStockRequest) GetStock() int32 {
+func (x *AdjustStockRequest) GetDelta() int32 {
 	if x != nil {
-		return x.Stock
+		return x.Delta
 	}
 	return 0
 }
 
-type ReserveStockResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AdjustStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *ReserveStockResponse) Reset() {
-	*x = ReserveStockResponse{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AdjustStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
 }
 
-func (x *ReserveStockResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AdjustStockRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
 }
 
-func (*ReserveStockResponse) ProtoMessage() {}
-
-func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[8]
+func (x *AdjustStockRequest) GetUnitCost() *Money {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.UnitCost
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
-func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{8}
+func (x *AdjustStockRequest) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
 }
 
-func (x *ReserveStockResponse) GetItem() *InventoryItem {
+func (x *AdjustStockRequest) GetExpiresAt() string {
 	if x != nil {
-		return x.Item
+		return x.ExpiresAt
 	}
-	return nil
+	return ""
 }
 
-type FulfillReservationRequest struct {
+type AdjustStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FulfillReservationRequest) Reset() {
-	*x = FulfillReservationRequest{}
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
 	mi := &file_proto_inventory_inventory_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FulfillReservationRequest) String() string {
+func (x *AdjustStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FulfillReservationRequest) ProtoMessage() {}
+func (*AdjustStockResponse) ProtoMessage() {}
 
-func (x *FulfillReservationRequest) ProtoReflect() protoreflect.Message {
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_inventory_inventory_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,46 +682,43 @@ func (x *FulfillReservationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FulfillReservationRequest.ProtoReflect.Descriptor instead.
-func (*FulfillReservationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
 	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *FulfillReservationRequest) GetProductId() int32 {
-	if x != nil {
-		return x.ProductId
-	}
-	return 0
-}
-
-func (x *FulfillReservationRequest) GetStock() int32 {
+func (x *AdjustStockResponse) GetItem() *InventoryItem {
 	if x != nil {
-		return x.Stock
+		return x.Item
 	}
-	return 0
+	return nil
 }
 
-type FulfillReservationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+type SetReorderPointRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ReorderPoint    int32                  `protobuf:"varint,2,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"`
+	ReorderQuantity int32                  `protobuf:"varint,3,opt,name=reorder_quantity,json=reorderQuantity,proto3" json:"reorder_quantity,omitempty"`
+	// the product's preferred location when 0
+	LocationId    int32 `protobuf:"varint,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FulfillReservationResponse) Reset() {
-	*x = FulfillReservationResponse{}
+func (x *SetReorderPointRequest) Reset() {
+	*x = SetReorderPointRequest{}
 	mi := &file_proto_inventory_inventory_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FulfillReservationResponse) String() string {
+func (x *SetReorderPointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FulfillReservationResponse) ProtoMessage() {}
+func (*SetReorderPointRequest) ProtoMessage() {}
 
-func (x *FulfillReservationResponse) ProtoReflect() protoreflect.Message {
+func (x *SetReorderPointRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_inventory_inventory_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -525,41 +730,7077 @@ func (x *FulfillReservationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FulfillReservationResponse.ProtoReflect.Descriptor instead.
-func (*FulfillReservationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetReorderPointRequest.ProtoReflect.Descriptor instead.
+func (*SetReorderPointRequest) Descriptor() ([]byte, []int) {
 	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *FulfillReservationResponse) GetItem() *InventoryItem {
+func (x *SetReorderPointRequest) GetProductId() int32 {
 	if x != nil {
-		return x.Item
+		return x.ProductId
 	}
-	return nil
+	return 0
+}
+
+func (x *SetReorderPointRequest) GetReorderPoint() int32 {
+	if x != nil {
+		return x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *SetReorderPointRequest) GetReorderQuantity() int32 {
+	if x != nil {
+		return x.ReorderQuantity
+	}
+	return 0
+}
+
+func (x *SetReorderPointRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type SetReorderPointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderPointResponse) Reset() {
+	*x = SetReorderPointResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderPointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderPointResponse) ProtoMessage() {}
+
+func (x *SetReorderPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderPointResponse.ProtoReflect.Descriptor instead.
+func (*SetReorderPointResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetReorderPointResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// lists the items with fewer available units than their reorder point
+type ListLowStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the items at this location; at every location when 0
+	LocationId    int32 `protobuf:"varint,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockRequest) Reset() {
+	*x = ListLowStockRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockRequest) ProtoMessage() {}
+
+func (x *ListLowStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockRequest.ProtoReflect.Descriptor instead.
+func (*ListLowStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListLowStockRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type ListLowStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockResponse) Reset() {
+	*x = ListLowStockResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockResponse) ProtoMessage() {}
+
+func (x *ListLowStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowStockResponse.ProtoReflect.Descriptor instead.
+func (*ListLowStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListLowStockResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// units of a product held for an owner; state is active, fulfilled, released or expired
+type Reservation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId         int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity          int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FulfilledQuantity int32                  `protobuf:"varint,4,opt,name=fulfilled_quantity,json=fulfilledQuantity,proto3" json:"fulfilled_quantity,omitempty"`
+	ReleasedQuantity  int32                  `protobuf:"varint,5,opt,name=released_quantity,json=releasedQuantity,proto3" json:"released_quantity,omitempty"`
+	Owner             string                 `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	State             string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// empty when the reservation never expires
+	ExpiresAt  string `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LocationId int32  `protobuf:"varint,10,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// cost of the units fulfilled so far
+	CostOfGoodsSold *Money `protobuf:"bytes,11,opt,name=cost_of_goods_sold,json=costOfGoodsSold,proto3" json:"cost_of_goods_sold,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Reservation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reservation) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetFulfilledQuantity() int32 {
+	if x != nil {
+		return x.FulfilledQuantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetReleasedQuantity() int32 {
+	if x != nil {
+		return x.ReleasedQuantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Reservation) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Reservation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Reservation) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Reservation) GetCostOfGoodsSold() *Money {
+	if x != nil {
+		return x.CostOfGoodsSold
+	}
+	return nil
+}
+
+// a point on the map, in decimal degrees
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Coordinates) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Stock     int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	// who holds the reservation, e.g. "order-saga-12"
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// the reservation is released after ttl_seconds; never when 0
+	TtlSeconds int32 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// reserve at this location; when 0 the location is picked by the allocation policy
+	LocationId int32 `protobuf:"varint,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// priority, most_stock or nearest; the service's configured policy when empty
+	Policy string `protobuf:"bytes,6,opt,name=policy,proto3" json:"policy,omitempty"`
+	// where the units are going, used by the nearest policy
+	Destination   *Coordinates `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReserveStockRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetDestination() *Coordinates {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReserveStockResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ReserveStockResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type FulfillReservationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// units to fulfill; every remaining unit when 0
+	Stock         int32 `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	ReservationId int32 `protobuf:"varint,3,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	// the units shipped of a serial-tracked product, one per unit; the units picked for
+	// the reservation when empty
+	SerialNumbers []string `protobuf:"bytes,4,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillReservationRequest) Reset() {
+	*x = FulfillReservationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillReservationRequest) ProtoMessage() {}
+
+func (x *FulfillReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillReservationRequest.ProtoReflect.Descriptor instead.
+func (*FulfillReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FulfillReservationRequest) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *FulfillReservationRequest) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *FulfillReservationRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type FulfillReservationResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Item        *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Reservation *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// the lots the fulfilled units were taken from
+	Lots []*LotConsumption `protobuf:"bytes,3,rep,name=lots,proto3" json:"lots,omitempty"`
+	// the serialized units shipped
+	Serials []*SerialConsumption `protobuf:"bytes,4,rep,name=serials,proto3" json:"serials,omitempty"`
+	// cost of the fulfilled units, valued by their product's costing method
+	CostOfGoodsSold *Money `protobuf:"bytes,5,opt,name=cost_of_goods_sold,json=costOfGoodsSold,proto3" json:"cost_of_goods_sold,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FulfillReservationResponse) Reset() {
+	*x = FulfillReservationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillReservationResponse) ProtoMessage() {}
+
+func (x *FulfillReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillReservationResponse.ProtoReflect.Descriptor instead.
+func (*FulfillReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FulfillReservationResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *FulfillReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *FulfillReservationResponse) GetLots() []*LotConsumption {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *FulfillReservationResponse) GetSerials() []*SerialConsumption {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *FulfillReservationResponse) GetCostOfGoodsSold() *Money {
+	if x != nil {
+		return x.CostOfGoodsSold
+	}
+	return nil
 }
 
 type ReleaseReservationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// units to release; every remaining unit when 0
+	Stock         int32 `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	ReservationId int32 `protobuf:"varint,3,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReleaseReservationRequest) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReleaseReservationResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ReleaseReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type GetReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReservationRequest) Reset() {
+	*x = GetReservationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReservationRequest) ProtoMessage() {}
+
+func (x *GetReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReservationRequest.ProtoReflect.Descriptor instead.
+func (*GetReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetReservationRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReservationResponse) Reset() {
+	*x = GetReservationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReservationResponse) ProtoMessage() {}
+
+func (x *GetReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReservationResponse.ProtoReflect.Descriptor instead.
+func (*GetReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// active reservations of a product and/or an owner (any when 0 or empty)
+type ListReservationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsRequest) Reset() {
+	*x = ListReservationsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReservationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsRequest) ProtoMessage() {}
+
+func (x *ListReservationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsRequest.ProtoReflect.Descriptor instead.
+func (*ListReservationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListReservationsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ListReservationsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type ListReservationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservations  []*Reservation         `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsResponse) Reset() {
+	*x = ListReservationsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReservationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsResponse) ProtoMessage() {}
+
+func (x *ListReservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsResponse.ProtoReflect.Descriptor instead.
+func (*ListReservationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListReservationsResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+type ReceiveReturnRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// returned units put back into stock
+	Restock int32 `protobuf:"varint,2,opt,name=restock,proto3" json:"restock,omitempty"`
+	// returned units that cannot be sold again
+	Damaged int32 `protobuf:"varint,3,opt,name=damaged,proto3" json:"damaged,omitempty"`
+	// recorded with the stock movement, e.g. the return authorization (optional)
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// the product's preferred location when 0
+	LocationId int32 `protobuf:"varint,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// the shipped serialized units that came back and are restocked, one for each restocked
+	// unit of a serial-tracked product
+	SerialNumbers []string `protobuf:"bytes,6,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	// the shipped serialized units that came back damaged, one for each damaged unit of a
+	// serial-tracked product
+	DamagedSerialNumbers []string `protobuf:"bytes,7,rep,name=damaged_serial_numbers,json=damagedSerialNumbers,proto3" json:"damaged_serial_numbers,omitempty"`
+	// the lots the restocked units are added back to (optional; untracked when not covered)
+	Lots          []*LotQuantity `protobuf:"bytes,8,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnRequest) Reset() {
+	*x = ReceiveReturnRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnRequest) ProtoMessage() {}
+
+func (x *ReceiveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReceiveReturnRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetRestock() int32 {
+	if x != nil {
+		return x.Restock
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetDamaged() int32 {
+	if x != nil {
+		return x.Damaged
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReceiveReturnRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiveReturnRequest) GetDamagedSerialNumbers() []string {
+	if x != nil {
+		return x.DamagedSerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiveReturnRequest) GetLots() []*LotQuantity {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type ReceiveReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnResponse) Reset() {
+	*x = ReceiveReturnResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnResponse) ProtoMessage() {}
+
+func (x *ReceiveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReceiveReturnResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// a quantity of a product to reserve, or of a reservation to fulfill or release
+// (every remaining unit when 0)
+type StockLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReservationId int32                  `protobuf:"varint,3,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	// location to reserve at; picked by the allocation policy when 0
+	LocationId int32 `protobuf:"varint,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// the serialized units fulfilled, as in FulfillReservationRequest
+	SerialNumbers []string `protobuf:"bytes,5,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLine) Reset() {
+	*x = StockLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLine) ProtoMessage() {}
+
+func (x *StockLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLine.ProtoReflect.Descriptor instead.
+func (*StockLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StockLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockLine) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *StockLine) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *StockLine) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type LineFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReservationId int32                  `protobuf:"varint,4,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	LocationId    int32                  `protobuf:"varint,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineFailure) Reset() {
+	*x = LineFailure{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineFailure) ProtoMessage() {}
+
+func (x *LineFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineFailure.ProtoReflect.Descriptor instead.
+func (*LineFailure) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LineFailure) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *LineFailure) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LineFailure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LineFailure) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *LineFailure) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+// error detail of a rejected batch: every line that could not be applied
+type BatchFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Failures      []*LineFailure         `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchFailure) Reset() {
+	*x = BatchFailure{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchFailure) ProtoMessage() {}
+
+func (x *BatchFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchFailure.ProtoReflect.Descriptor instead.
+func (*BatchFailure) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BatchFailure) GetFailures() []*LineFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// one reservation is made per product and location of the lines
+type ReserveStockBatchRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Lines      []*StockLine           `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Owner      string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	TtlSeconds int32                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// allocation of the lines without a location, as in ReserveStockRequest
+	Policy        string       `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+	Destination   *Coordinates `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockBatchRequest) Reset() {
+	*x = ReserveStockBatchRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockBatchRequest) ProtoMessage() {}
+
+func (x *ReserveStockBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockBatchRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReserveStockBatchRequest) GetLines() []*StockLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReserveStockBatchRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ReserveStockBatchRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveStockBatchRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *ReserveStockBatchRequest) GetDestination() *Coordinates {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+type ReserveStockBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,2,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockBatchResponse) Reset() {
+	*x = ReserveStockBatchResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockBatchResponse) ProtoMessage() {}
+
+func (x *ReserveStockBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockBatchResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReserveStockBatchResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockBatchResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+// lines name reservations by reservation_id
+type FulfillReservationBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*StockLine           `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillReservationBatchRequest) Reset() {
+	*x = FulfillReservationBatchRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillReservationBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillReservationBatchRequest) ProtoMessage() {}
+
+func (x *FulfillReservationBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillReservationBatchRequest.ProtoReflect.Descriptor instead.
+func (*FulfillReservationBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FulfillReservationBatchRequest) GetLines() []*StockLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type FulfillReservationBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,2,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	Lots          []*LotConsumption      `protobuf:"bytes,3,rep,name=lots,proto3" json:"lots,omitempty"`
+	Serials       []*SerialConsumption   `protobuf:"bytes,4,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillReservationBatchResponse) Reset() {
+	*x = FulfillReservationBatchResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillReservationBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillReservationBatchResponse) ProtoMessage() {}
+
+func (x *FulfillReservationBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillReservationBatchResponse.ProtoReflect.Descriptor instead.
+func (*FulfillReservationBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FulfillReservationBatchResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *FulfillReservationBatchResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+func (x *FulfillReservationBatchResponse) GetLots() []*LotConsumption {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *FulfillReservationBatchResponse) GetSerials() []*SerialConsumption {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+// lines name reservations by reservation_id
+type ReleaseReservationBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*StockLine           `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationBatchRequest) Reset() {
+	*x = ReleaseReservationBatchRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationBatchRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationBatchRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReleaseReservationBatchRequest) GetLines() []*StockLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type ReleaseReservationBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*InventoryItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,2,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationBatchResponse) Reset() {
+	*x = ReleaseReservationBatchResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationBatchResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationBatchResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReleaseReservationBatchResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReleaseReservationBatchResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+// one entry of the stock ledger; stock, reserved and damaged are the balances after it
+type Movement struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId       int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	StockDelta      int32                  `protobuf:"varint,4,opt,name=stock_delta,json=stockDelta,proto3" json:"stock_delta,omitempty"`
+	ReservedDelta   int32                  `protobuf:"varint,5,opt,name=reserved_delta,json=reservedDelta,proto3" json:"reserved_delta,omitempty"`
+	DamagedDelta    int32                  `protobuf:"varint,6,opt,name=damaged_delta,json=damagedDelta,proto3" json:"damaged_delta,omitempty"`
+	Stock           int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
+	Reserved        int32                  `protobuf:"varint,8,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Damaged         int32                  `protobuf:"varint,9,opt,name=damaged,proto3" json:"damaged,omitempty"`
+	ReservationId   int32                  `protobuf:"varint,10,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ReferenceId     string                 `protobuf:"bytes,11,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Actor           string                 `protobuf:"bytes,12,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LocationId      int32                  `protobuf:"varint,14,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	TransferId      int32                  `protobuf:"varint,15,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	PurchaseOrderId int32                  `protobuf:"varint,16,opt,name=purchase_order_id,json=purchaseOrderId,proto3" json:"purchase_order_id,omitempty"`
+	StocktakeId     int32                  `protobuf:"varint,17,opt,name=stocktake_id,json=stocktakeId,proto3" json:"stocktake_id,omitempty"`
+	// cost of each unit that entered or left the stock (unset when the stock did not
+	// change), and the value the movement added to the stock (negative when units left it)
+	UnitCost      *Money `protobuf:"bytes,18,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	Value         *Money `protobuf:"bytes,19,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Movement) Reset() {
+	*x = Movement{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movement) ProtoMessage() {}
+
+func (x *Movement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movement.ProtoReflect.Descriptor instead.
+func (*Movement) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Movement) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Movement) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Movement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Movement) GetStockDelta() int32 {
+	if x != nil {
+		return x.StockDelta
+	}
+	return 0
+}
+
+func (x *Movement) GetReservedDelta() int32 {
+	if x != nil {
+		return x.ReservedDelta
+	}
+	return 0
+}
+
+func (x *Movement) GetDamagedDelta() int32 {
+	if x != nil {
+		return x.DamagedDelta
+	}
+	return 0
+}
+
+func (x *Movement) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Movement) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Movement) GetDamaged() int32 {
+	if x != nil {
+		return x.Damaged
+	}
+	return 0
+}
+
+func (x *Movement) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *Movement) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *Movement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Movement) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Movement) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Movement) GetTransferId() int32 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *Movement) GetPurchaseOrderId() int32 {
+	if x != nil {
+		return x.PurchaseOrderId
+	}
+	return 0
+}
+
+func (x *Movement) GetStocktakeId() int32 {
+	if x != nil {
+		return x.StocktakeId
+	}
+	return 0
+}
+
+func (x *Movement) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+func (x *Movement) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ListMovementsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// RFC 3339 time range [from, to); unbounded when empty
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// movements with a greater id, for paging
+	AfterId int64 `protobuf:"varint,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	// 100 when 0, at most 1000
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// movements at this location; every location when 0
+	LocationId    int32 `protobuf:"varint,6,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsRequest) Reset() {
+	*x = ListMovementsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsRequest) ProtoMessage() {}
+
+func (x *ListMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListMovementsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type ListMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*Movement            `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsResponse) Reset() {
+	*x = ListMovementsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsResponse) ProtoMessage() {}
+
+func (x *ListMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListMovementsResponse) GetMovements() []*Movement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+// a warehouse holding stock
+type Location struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code      string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude  float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// lower is preferred by the priority allocation policy
+	Priority      int32 `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Location) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Location) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type ListLocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsRequest) Reset() {
+	*x = ListLocationsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsRequest) ProtoMessage() {}
+
+func (x *ListLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{41}
+}
+
+type ListLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsResponse) Reset() {
+	*x = ListLocationsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsResponse) ProtoMessage() {}
+
+func (x *ListLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListLocationsResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type GetLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLocationRequest) Reset() {
+	*x = GetLocationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLocationRequest) ProtoMessage() {}
+
+func (x *GetLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLocationRequest.ProtoReflect.Descriptor instead.
+func (*GetLocationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetLocationRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLocationResponse) Reset() {
+	*x = GetLocationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLocationResponse) ProtoMessage() {}
+
+func (x *GetLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLocationResponse.ProtoReflect.Descriptor instead.
+func (*GetLocationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetLocationResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type CreateLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLocationRequest) Reset() {
+	*x = CreateLocationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLocationRequest) ProtoMessage() {}
+
+func (x *CreateLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLocationRequest.ProtoReflect.Descriptor instead.
+func (*CreateLocationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateLocationRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateLocationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateLocationRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateLocationRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateLocationRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type CreateLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLocationResponse) Reset() {
+	*x = CreateLocationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLocationResponse) ProtoMessage() {}
+
+func (x *CreateLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLocationResponse.ProtoReflect.Descriptor instead.
+func (*CreateLocationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateLocationResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// units moved from one location to another: draft -> in_transit (shipped from the source)
+// -> received (added to the destination's stock)
+type TransferLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the serialized units moved, one for each unit of a serial-tracked product
+	SerialNumbers []string `protobuf:"bytes,3,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	// units of lots moved: the lots named on creation, then every lot the units were taken
+	// from once shipped
+	Lots          []*LotQuantity `protobuf:"bytes,4,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLine) Reset() {
+	*x = TransferLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLine) ProtoMessage() {}
+
+func (x *TransferLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLine.ProtoReflect.Descriptor instead.
+func (*TransferLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TransferLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *TransferLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferLine) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *TransferLine) GetLots() []*LotQuantity {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type Transfer struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceLocationId      int32                  `protobuf:"varint,2,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`
+	DestinationLocationId int32                  `protobuf:"varint,3,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"`
+	State                 string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Lines                 []*TransferLine        `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ShippedAt             string                 `protobuf:"bytes,7,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	ReceivedAt            string                 `protobuf:"bytes,8,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Transfer) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transfer) GetSourceLocationId() int32 {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return 0
+}
+
+func (x *Transfer) GetDestinationLocationId() int32 {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return 0
+}
+
+func (x *Transfer) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Transfer) GetLines() []*TransferLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Transfer) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Transfer) GetShippedAt() string {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return ""
+}
+
+func (x *Transfer) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+// lists the transfers in a state and/or from or to a location (any when empty or 0)
+type ListTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	LocationId    int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersRequest) Reset() {
+	*x = ListTransfersRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersRequest) ProtoMessage() {}
+
+func (x *ListTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListTransfersRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type ListTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*Transfer            `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersResponse) Reset() {
+	*x = ListTransfersResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersResponse) ProtoMessage() {}
+
+func (x *ListTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListTransfersResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type GetTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferRequest) Reset() {
+	*x = GetTransferRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferRequest) ProtoMessage() {}
+
+func (x *GetTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetTransferRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferResponse) Reset() {
+	*x = GetTransferResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferResponse) ProtoMessage() {}
+
+func (x *GetTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+type CreateTransferRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SourceLocationId      int32                  `protobuf:"varint,1,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`
+	DestinationLocationId int32                  `protobuf:"varint,2,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"`
+	Lines                 []*TransferLine        `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateTransferRequest) Reset() {
+	*x = CreateTransferRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferRequest) ProtoMessage() {}
+
+func (x *CreateTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateTransferRequest) GetSourceLocationId() int32 {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return 0
+}
+
+func (x *CreateTransferRequest) GetDestinationLocationId() int32 {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return 0
+}
+
+func (x *CreateTransferRequest) GetLines() []*TransferLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type CreateTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransferResponse) Reset() {
+	*x = CreateTransferResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferResponse) ProtoMessage() {}
+
+func (x *CreateTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferResponse.ProtoReflect.Descriptor instead.
+func (*CreateTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CreateTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+type ShipTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipTransferRequest) Reset() {
+	*x = ShipTransferRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipTransferRequest) ProtoMessage() {}
+
+func (x *ShipTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipTransferRequest.ProtoReflect.Descriptor instead.
+func (*ShipTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ShipTransferRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// the transfer and the items it changed
+type ShipTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Items         []*InventoryItem       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipTransferResponse) Reset() {
+	*x = ShipTransferResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipTransferResponse) ProtoMessage() {}
+
+func (x *ShipTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipTransferResponse.ProtoReflect.Descriptor instead.
+func (*ShipTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ShipTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ShipTransferResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReceiveTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveTransferRequest) Reset() {
+	*x = ReceiveTransferRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveTransferRequest) ProtoMessage() {}
+
+func (x *ReceiveTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReceiveTransferRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ReceiveTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Items         []*InventoryItem       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveTransferResponse) Reset() {
+	*x = ReceiveTransferResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveTransferResponse) ProtoMessage() {}
+
+func (x *ReceiveTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveTransferResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ReceiveTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ReceiveTransferResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// units of a product counted by one counter
+type StocktakeCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counter       string                 `protobuf:"bytes,1,opt,name=counter,proto3" json:"counter,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CountedAt     string                 `protobuf:"bytes,3,opt,name=counted_at,json=countedAt,proto3" json:"counted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StocktakeCount) Reset() {
+	*x = StocktakeCount{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StocktakeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StocktakeCount) ProtoMessage() {}
+
+func (x *StocktakeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StocktakeCount.ProtoReflect.Descriptor instead.
+func (*StocktakeCount) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *StocktakeCount) GetCounter() string {
+	if x != nil {
+		return x.Counter
+	}
+	return ""
+}
+
+func (x *StocktakeCount) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StocktakeCount) GetCountedAt() string {
+	if x != nil {
+		return x.CountedAt
+	}
+	return ""
+}
+
+// expected is the item's stock when the line was last counted (when the stocktake was
+// opened until then), counted the sum of the counts, and variance counted - expected (0 until counted); state is pending, counted,
+// approved or rejected
+type StocktakeLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Expected      int32                  `protobuf:"varint,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	Counted       int32                  `protobuf:"varint,3,opt,name=counted,proto3" json:"counted,omitempty"`
+	Variance      int32                  `protobuf:"varint,4,opt,name=variance,proto3" json:"variance,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Counts        []*StocktakeCount      `protobuf:"bytes,6,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StocktakeLine) Reset() {
+	*x = StocktakeLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StocktakeLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StocktakeLine) ProtoMessage() {}
+
+func (x *StocktakeLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StocktakeLine.ProtoReflect.Descriptor instead.
+func (*StocktakeLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *StocktakeLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StocktakeLine) GetExpected() int32 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *StocktakeLine) GetCounted() int32 {
+	if x != nil {
+		return x.Counted
+	}
+	return 0
+}
+
+func (x *StocktakeLine) GetVariance() int32 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *StocktakeLine) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StocktakeLine) GetCounts() []*StocktakeCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// a physical count of products at a location; state is open, posted or cancelled
+type Stocktake struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocationId int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	State      string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Reference  string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	CreatedBy  string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Lines      []*StocktakeLine       `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt  string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// when it was posted or cancelled
+	ClosedAt      string `protobuf:"bytes,8,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stocktake) Reset() {
+	*x = Stocktake{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stocktake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stocktake) ProtoMessage() {}
+
+func (x *Stocktake) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stocktake.ProtoReflect.Descriptor instead.
+func (*Stocktake) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *Stocktake) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Stocktake) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Stocktake) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Stocktake) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Stocktake) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Stocktake) GetLines() []*StocktakeLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Stocktake) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Stocktake) GetClosedAt() string {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return ""
+}
+
+// lists the stocktakes in a state and/or at a location (any when empty or 0)
+type ListStocktakesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	LocationId    int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocktakesRequest) Reset() {
+	*x = ListStocktakesRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocktakesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocktakesRequest) ProtoMessage() {}
+
+func (x *ListStocktakesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocktakesRequest.ProtoReflect.Descriptor instead.
+func (*ListStocktakesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListStocktakesRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListStocktakesRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type ListStocktakesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktakes    []*Stocktake           `protobuf:"bytes,1,rep,name=stocktakes,proto3" json:"stocktakes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocktakesResponse) Reset() {
+	*x = ListStocktakesResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocktakesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocktakesResponse) ProtoMessage() {}
+
+func (x *ListStocktakesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocktakesResponse.ProtoReflect.Descriptor instead.
+func (*ListStocktakesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListStocktakesResponse) GetStocktakes() []*Stocktake {
+	if x != nil {
+		return x.Stocktakes
+	}
+	return nil
+}
+
+type GetStocktakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStocktakeRequest) Reset() {
+	*x = GetStocktakeRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStocktakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStocktakeRequest) ProtoMessage() {}
+
+func (x *GetStocktakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStocktakeRequest.ProtoReflect.Descriptor instead.
+func (*GetStocktakeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetStocktakeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetStocktakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStocktakeResponse) Reset() {
+	*x = GetStocktakeResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStocktakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStocktakeResponse) ProtoMessage() {}
+
+func (x *GetStocktakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStocktakeResponse.ProtoReflect.Descriptor instead.
+func (*GetStocktakeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetStocktakeResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+// snapshots the stock of the products at the location as their expected quantities
+type CreateStocktakeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LocationId int32                  `protobuf:"varint,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// every product held at the location when empty
+	ProductIds    []int32 `protobuf:"varint,2,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Reference     string  `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStocktakeRequest) Reset() {
+	*x = CreateStocktakeRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStocktakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStocktakeRequest) ProtoMessage() {}
+
+func (x *CreateStocktakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStocktakeRequest.ProtoReflect.Descriptor instead.
+func (*CreateStocktakeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateStocktakeRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *CreateStocktakeRequest) GetProductIds() []int32 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *CreateStocktakeRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type CreateStocktakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStocktakeResponse) Reset() {
+	*x = CreateStocktakeResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStocktakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStocktakeResponse) ProtoMessage() {}
+
+func (x *CreateStocktakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStocktakeResponse.ProtoReflect.Descriptor instead.
+func (*CreateStocktakeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CreateStocktakeResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+type StocktakeCountLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StocktakeCountLine) Reset() {
+	*x = StocktakeCountLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StocktakeCountLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StocktakeCountLine) ProtoMessage() {}
+
+func (x *StocktakeCountLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StocktakeCountLine.ProtoReflect.Descriptor instead.
+func (*StocktakeCountLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *StocktakeCountLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StocktakeCountLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// replaces the counter's earlier count of each product
+type SubmitStocktakeCountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the caller's actor when empty
+	Counter       string                `protobuf:"bytes,2,opt,name=counter,proto3" json:"counter,omitempty"`
+	Lines         []*StocktakeCountLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitStocktakeCountsRequest) Reset() {
+	*x = SubmitStocktakeCountsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitStocktakeCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitStocktakeCountsRequest) ProtoMessage() {}
+
+func (x *SubmitStocktakeCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitStocktakeCountsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitStocktakeCountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SubmitStocktakeCountsRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SubmitStocktakeCountsRequest) GetCounter() string {
+	if x != nil {
+		return x.Counter
+	}
+	return ""
+}
+
+func (x *SubmitStocktakeCountsRequest) GetLines() []*StocktakeCountLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type SubmitStocktakeCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitStocktakeCountsResponse) Reset() {
+	*x = SubmitStocktakeCountsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitStocktakeCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitStocktakeCountsResponse) ProtoMessage() {}
+
+func (x *SubmitStocktakeCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitStocktakeCountsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitStocktakeCountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SubmitStocktakeCountsResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+// approves or rejects the variances of counted products
+type ReviewStocktakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approve       []int32                `protobuf:"varint,2,rep,packed,name=approve,proto3" json:"approve,omitempty"`
+	Reject        []int32                `protobuf:"varint,3,rep,packed,name=reject,proto3" json:"reject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewStocktakeRequest) Reset() {
+	*x = ReviewStocktakeRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewStocktakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewStocktakeRequest) ProtoMessage() {}
+
+func (x *ReviewStocktakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewStocktakeRequest.ProtoReflect.Descriptor instead.
+func (*ReviewStocktakeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReviewStocktakeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReviewStocktakeRequest) GetApprove() []int32 {
+	if x != nil {
+		return x.Approve
+	}
+	return nil
+}
+
+func (x *ReviewStocktakeRequest) GetReject() []int32 {
+	if x != nil {
+		return x.Reject
+	}
+	return nil
+}
+
+type ReviewStocktakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewStocktakeResponse) Reset() {
+	*x = ReviewStocktakeResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewStocktakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewStocktakeResponse) ProtoMessage() {}
+
+func (x *ReviewStocktakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewStocktakeResponse.ProtoReflect.Descriptor instead.
+func (*ReviewStocktakeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReviewStocktakeResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+// adds the approved variances to the stock; every counted product must be reviewed
+type PostStocktakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStocktakeRequest) Reset() {
+	*x = PostStocktakeRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStocktakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStocktakeRequest) ProtoMessage() {}
+
+func (x *PostStocktakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStocktakeRequest.ProtoReflect.Descriptor instead.
+func (*PostStocktakeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *PostStocktakeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// the stocktake and the items it changed
+type PostStocktakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	Items         []*InventoryItem       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStocktakeResponse) Reset() {
+	*x = PostStocktakeResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStocktakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStocktakeResponse) ProtoMessage() {}
+
+func (x *PostStocktakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStocktakeResponse.ProtoReflect.Descriptor instead.
+func (*PostStocktakeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *PostStocktakeResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+func (x *PostStocktakeResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CancelStocktakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStocktakeRequest) Reset() {
+	*x = CancelStocktakeRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStocktakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStocktakeRequest) ProtoMessage() {}
+
+func (x *CancelStocktakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStocktakeRequest.ProtoReflect.Descriptor instead.
+func (*CancelStocktakeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CancelStocktakeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CancelStocktakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocktake     *Stocktake             `protobuf:"bytes,1,opt,name=stocktake,proto3" json:"stocktake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStocktakeResponse) Reset() {
+	*x = CancelStocktakeResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStocktakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStocktakeResponse) ProtoMessage() {}
+
+func (x *CancelStocktakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStocktakeResponse.ProtoReflect.Descriptor instead.
+func (*CancelStocktakeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *CancelStocktakeResponse) GetStocktake() *Stocktake {
+	if x != nil {
+		return x.Stocktake
+	}
+	return nil
+}
+
+// variances of a product posted by stocktakes: shrinkage counts the units found missing,
+// found the units counted in excess, and variance is found - shrinkage
+type ShrinkageLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Stocktakes    int32                  `protobuf:"varint,2,opt,name=stocktakes,proto3" json:"stocktakes,omitempty"`
+	Expected      int32                  `protobuf:"varint,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Counted       int32                  `protobuf:"varint,4,opt,name=counted,proto3" json:"counted,omitempty"`
+	Shrinkage     int32                  `protobuf:"varint,5,opt,name=shrinkage,proto3" json:"shrinkage,omitempty"`
+	Found         int32                  `protobuf:"varint,6,opt,name=found,proto3" json:"found,omitempty"`
+	Variance      int32                  `protobuf:"varint,7,opt,name=variance,proto3" json:"variance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShrinkageLine) Reset() {
+	*x = ShrinkageLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShrinkageLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShrinkageLine) ProtoMessage() {}
+
+func (x *ShrinkageLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShrinkageLine.ProtoReflect.Descriptor instead.
+func (*ShrinkageLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ShrinkageLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetStocktakes() int32 {
+	if x != nil {
+		return x.Stocktakes
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetExpected() int32 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetCounted() int32 {
+	if x != nil {
+		return x.Counted
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetShrinkage() int32 {
+	if x != nil {
+		return x.Shrinkage
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetFound() int32 {
+	if x != nil {
+		return x.Found
+	}
+	return 0
+}
+
+func (x *ShrinkageLine) GetVariance() int32 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+// totals of the stocktakes posted at a location and/or in an RFC 3339 time range
+// [from, to) (any when 0 or empty), or of a single stocktake
+type GetShrinkageReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    int32                  `protobuf:"varint,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	StocktakeId   int32                  `protobuf:"varint,2,opt,name=stocktake_id,json=stocktakeId,proto3" json:"stocktake_id,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShrinkageReportRequest) Reset() {
+	*x = GetShrinkageReportRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShrinkageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShrinkageReportRequest) ProtoMessage() {}
+
+func (x *GetShrinkageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShrinkageReportRequest.ProtoReflect.Descriptor instead.
+func (*GetShrinkageReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetShrinkageReportRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *GetShrinkageReportRequest) GetStocktakeId() int32 {
+	if x != nil {
+		return x.StocktakeId
+	}
+	return 0
+}
+
+func (x *GetShrinkageReportRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetShrinkageReportRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type GetShrinkageReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*ShrinkageLine       `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShrinkageReportResponse) Reset() {
+	*x = GetShrinkageReportResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShrinkageReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShrinkageReportResponse) ProtoMessage() {}
+
+func (x *GetShrinkageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShrinkageReportResponse.ProtoReflect.Descriptor instead.
+func (*GetShrinkageReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetShrinkageReportResponse) GetLines() []*ShrinkageLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// sets the stock of a product at a location (the product's preferred location when 0)
+type ImportRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LocationId    int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Stock         int32                  `protobuf:"varint,3,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRow) Reset() {
+	*x = ImportRow{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRow) ProtoMessage() {}
+
+func (x *ImportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRow.ProtoReflect.Descriptor instead.
+func (*ImportRow) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ImportRow) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ImportRow) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ImportRow) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+// rows are numbered by their position in the stream, starting at 1
+type ImportInventoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rows  []*ImportRow           `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	// all_or_nothing (the default) or best_effort
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// validate every row and report what would change, without saving anything
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// recorded with the stock movements (optional)
+	Reference     string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportInventoryRequest) Reset() {
+	*x = ImportInventoryRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportInventoryRequest) ProtoMessage() {}
+
+func (x *ImportInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportInventoryRequest.ProtoReflect.Descriptor instead.
+func (*ImportInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ImportInventoryRequest) GetRows() []*ImportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *ImportInventoryRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ImportInventoryRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportInventoryRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	ProductId     int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LocationId    int32                  `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ImportError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ImportError) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ImportError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// applied counts the rows changing an item's stock (or that would, on a dry run),
+// unchanged the rows matching it; committed tells whether the changes were saved
+type ImportInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Committed     bool                   `protobuf:"varint,3,opt,name=committed,proto3" json:"committed,omitempty"`
+	Rows          int32                  `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	Applied       int32                  `protobuf:"varint,5,opt,name=applied,proto3" json:"applied,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,6,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Failed        int32                  `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportInventoryResponse) Reset() {
+	*x = ImportInventoryResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportInventoryResponse) ProtoMessage() {}
+
+func (x *ImportInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportInventoryResponse.ProtoReflect.Descriptor instead.
+func (*ImportInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ImportInventoryResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ImportInventoryResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportInventoryResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *ImportInventoryResponse) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportInventoryResponse) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *ImportInventoryResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportInventoryResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportInventoryResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// units of a product received at one unit cost, remaining of them still in stock and worth
+// value; a product costed by weighted_average keeps a single layer, at the average cost
+type CostLayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Remaining     int32                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	UnitCost      *Money                 `protobuf:"bytes,4,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	Value         *Money                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	ReceivedAt    string                 `protobuf:"bytes,6,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostLayer) Reset() {
+	*x = CostLayer{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostLayer) ProtoMessage() {}
+
+func (x *CostLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostLayer.ProtoReflect.Descriptor instead.
+func (*CostLayer) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CostLayer) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CostLayer) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CostLayer) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *CostLayer) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+func (x *CostLayer) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CostLayer) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+// costing method of a product (fifo or weighted_average) and the cost of its stock over
+// every location; unit_cost is the average cost of the units in stock
+type ProductCosting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Value         *Money                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	UnitCost      *Money                 `protobuf:"bytes,5,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	Layers        []*CostLayer           `protobuf:"bytes,6,rep,name=layers,proto3" json:"layers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductCosting) Reset() {
+	*x = ProductCosting{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCosting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCosting) ProtoMessage() {}
+
+func (x *ProductCosting) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCosting.ProtoReflect.Descriptor instead.
+func (*ProductCosting) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ProductCosting) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductCosting) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ProductCosting) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductCosting) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ProductCosting) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+func (x *ProductCosting) GetLayers() []*CostLayer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+type GetCostingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCostingRequest) Reset() {
+	*x = GetCostingRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCostingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCostingRequest) ProtoMessage() {}
+
+func (x *GetCostingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCostingRequest.ProtoReflect.Descriptor instead.
+func (*GetCostingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetCostingRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type GetCostingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Costing       *ProductCosting        `protobuf:"bytes,1,opt,name=costing,proto3" json:"costing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCostingResponse) Reset() {
+	*x = GetCostingResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCostingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCostingResponse) ProtoMessage() {}
+
+func (x *GetCostingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCostingResponse.ProtoReflect.Descriptor instead.
+func (*GetCostingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetCostingResponse) GetCosting() *ProductCosting {
+	if x != nil {
+		return x.Costing
+	}
+	return nil
+}
+
+// switching to weighted_average costs the units in stock at their average cost
+type SetCostingMethodRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCostingMethodRequest) Reset() {
+	*x = SetCostingMethodRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCostingMethodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCostingMethodRequest) ProtoMessage() {}
+
+func (x *SetCostingMethodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCostingMethodRequest.ProtoReflect.Descriptor instead.
+func (*SetCostingMethodRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *SetCostingMethodRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SetCostingMethodRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type SetCostingMethodResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Costing       *ProductCosting        `protobuf:"bytes,1,opt,name=costing,proto3" json:"costing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCostingMethodResponse) Reset() {
+	*x = SetCostingMethodResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCostingMethodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCostingMethodResponse) ProtoMessage() {}
+
+func (x *SetCostingMethodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCostingMethodResponse.ProtoReflect.Descriptor instead.
+func (*SetCostingMethodResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *SetCostingMethodResponse) GetCosting() *ProductCosting {
+	if x != nil {
+		return x.Costing
+	}
+	return nil
+}
+
+// units of a product on hand and their cost; unit_cost is their average cost
+type ValuationLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Value         *Money                 `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	UnitCost      *Money                 `protobuf:"bytes,7,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuationLine) Reset() {
+	*x = ValuationLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuationLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuationLine) ProtoMessage() {}
+
+func (x *ValuationLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuationLine.ProtoReflect.Descriptor instead.
+func (*ValuationLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ValuationLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ValuationLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValuationLine) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ValuationLine) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ValuationLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ValuationLine) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ValuationLine) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+// totals of the products of a category valued in one currency
+type ValuationCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Products      int32                  `protobuf:"varint,2,opt,name=products,proto3" json:"products,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Value         *Money                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValuationCategory) Reset() {
+	*x = ValuationCategory{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValuationCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuationCategory) ProtoMessage() {}
+
+func (x *ValuationCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuationCategory.ProtoReflect.Descriptor instead.
+func (*ValuationCategory) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ValuationCategory) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ValuationCategory) GetProducts() int32 {
+	if x != nil {
+		return x.Products
+	}
+	return 0
+}
+
+func (x *ValuationCategory) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ValuationCategory) GetValue() *Money {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// values the stock on hand as of an RFC 3339 time (now when empty), at a location and/or
+// of a category (any when 0 or empty); units in transit are counted at their destination
+// from the moment they were shipped
+type GetValuationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          string                 `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	LocationId    int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuationRequest) Reset() {
+	*x = GetValuationRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuationRequest) ProtoMessage() {}
+
+func (x *GetValuationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuationRequest.ProtoReflect.Descriptor instead.
+func (*GetValuationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetValuationRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+func (x *GetValuationRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *GetValuationRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type GetValuationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          string                 `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	Products      []*ValuationLine       `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	Categories    []*ValuationCategory   `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuationResponse) Reset() {
+	*x = GetValuationResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuationResponse) ProtoMessage() {}
+
+func (x *GetValuationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuationResponse.ProtoReflect.Descriptor instead.
+func (*GetValuationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetValuationResponse) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+func (x *GetValuationResponse) GetProducts() []*ValuationLine {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetValuationResponse) GetCategories() []*ValuationCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type Supplier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Supplier) Reset() {
+	*x = Supplier{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Supplier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Supplier) ProtoMessage() {}
+
+func (x *Supplier) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Supplier.ProtoReflect.Descriptor instead.
+func (*Supplier) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *Supplier) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Supplier) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Supplier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Supplier) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Supplier) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListSuppliersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersRequest) Reset() {
+	*x = ListSuppliersRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersRequest) ProtoMessage() {}
+
+func (x *ListSuppliersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersRequest.ProtoReflect.Descriptor instead.
+func (*ListSuppliersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{95}
+}
+
+type ListSuppliersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppliers     []*Supplier            `protobuf:"bytes,1,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersResponse) Reset() {
+	*x = ListSuppliersResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersResponse) ProtoMessage() {}
+
+func (x *ListSuppliersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersResponse.ProtoReflect.Descriptor instead.
+func (*ListSuppliersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListSuppliersResponse) GetSuppliers() []*Supplier {
+	if x != nil {
+		return x.Suppliers
+	}
+	return nil
+}
+
+type GetSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupplierRequest) Reset() {
+	*x = GetSupplierRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplierRequest) ProtoMessage() {}
+
+func (x *GetSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupplierRequest.ProtoReflect.Descriptor instead.
+func (*GetSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetSupplierRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupplierResponse) Reset() {
+	*x = GetSupplierResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplierResponse) ProtoMessage() {}
+
+func (x *GetSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupplierResponse.ProtoReflect.Descriptor instead.
+func (*GetSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *GetSupplierResponse) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+type CreateSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSupplierRequest) Reset() {
+	*x = CreateSupplierRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSupplierRequest) ProtoMessage() {}
+
+func (x *CreateSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSupplierRequest.ProtoReflect.Descriptor instead.
+func (*CreateSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *CreateSupplierRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type CreateSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSupplierResponse) Reset() {
+	*x = CreateSupplierResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSupplierResponse) ProtoMessage() {}
+
+func (x *CreateSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSupplierResponse.ProtoReflect.Descriptor instead.
+func (*CreateSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *CreateSupplierResponse) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+// units ordered from a supplier, to be received at a location: open -> partially_received
+// -> received; an open or partially received order can be cancelled
+type PurchaseOrderLine struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReceivedQuantity int32                  `protobuf:"varint,3,opt,name=received_quantity,json=receivedQuantity,proto3" json:"received_quantity,omitempty"`
+	// cost of each unit, in the currency of the product's costs (the currency is ignored on
+	// creation)
+	UnitCost      *Money `protobuf:"bytes,4,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseOrderLine) Reset() {
+	*x = PurchaseOrderLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseOrderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseOrderLine) ProtoMessage() {}
+
+func (x *PurchaseOrderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseOrderLine.ProtoReflect.Descriptor instead.
+func (*PurchaseOrderLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *PurchaseOrderLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *PurchaseOrderLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PurchaseOrderLine) GetReceivedQuantity() int32 {
+	if x != nil {
+		return x.ReceivedQuantity
+	}
+	return 0
+}
+
+func (x *PurchaseOrderLine) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+type PurchaseOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SupplierId    int32                  `protobuf:"varint,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	LocationId    int32                  `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	ExpectedAt    string                 `protobuf:"bytes,6,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	Lines         []*PurchaseOrderLine   `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ClosedAt      string                 `protobuf:"bytes,9,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseOrder) Reset() {
+	*x = PurchaseOrder{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseOrder) ProtoMessage() {}
+
+func (x *PurchaseOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseOrder.ProtoReflect.Descriptor instead.
+func (*PurchaseOrder) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *PurchaseOrder) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PurchaseOrder) GetSupplierId() int32 {
+	if x != nil {
+		return x.SupplierId
+	}
+	return 0
+}
+
+func (x *PurchaseOrder) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *PurchaseOrder) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PurchaseOrder) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *PurchaseOrder) GetExpectedAt() string {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return ""
+}
+
+func (x *PurchaseOrder) GetLines() []*PurchaseOrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *PurchaseOrder) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PurchaseOrder) GetClosedAt() string {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return ""
+}
+
+type ReceiptLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the lot the units belong to, when tracked, and its expiry date (RFC 3339, optional)
+	LotNumber string `protobuf:"bytes,3,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// the serial number of every unit of a serial-tracked product
+	SerialNumbers []string `protobuf:"bytes,5,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	// cost of each unit, when it differs from the purchase order's (optional)
+	UnitCost      *Money `protobuf:"bytes,6,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptLine) Reset() {
+	*x = ReceiptLine{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptLine) ProtoMessage() {}
+
+func (x *ReceiptLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptLine.ProtoReflect.Descriptor instead.
+func (*ReceiptLine) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ReceiptLine) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReceiptLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReceiptLine) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *ReceiptLine) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ReceiptLine) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiptLine) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+// goods received for a line of a purchase order
+type Receipt struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PurchaseOrderId int32                  `protobuf:"varint,2,opt,name=purchase_order_id,json=purchaseOrderId,proto3" json:"purchase_order_id,omitempty"`
+	LocationId      int32                  `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	ProductId       int32                  `protobuf:"varint,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity        int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reference       string                 `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`
+	Actor           string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	ReceivedAt      string                 `protobuf:"bytes,8,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	LotNumber       string                 `protobuf:"bytes,9,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt       string                 `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// cost each unit was valued at
+	UnitCost      *Money `protobuf:"bytes,11,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *Receipt) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Receipt) GetPurchaseOrderId() int32 {
+	if x != nil {
+		return x.PurchaseOrderId
+	}
+	return 0
+}
+
+func (x *Receipt) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Receipt) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Receipt) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Receipt) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Receipt) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Receipt) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+func (x *Receipt) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *Receipt) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Receipt) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+// lists the purchase orders in a state and/or placed with a supplier (any when empty or 0)
+type ListPurchaseOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	SupplierId    int32                  `protobuf:"varint,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurchaseOrdersRequest) Reset() {
+	*x = ListPurchaseOrdersRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurchaseOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurchaseOrdersRequest) ProtoMessage() {}
+
+func (x *ListPurchaseOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurchaseOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListPurchaseOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ListPurchaseOrdersRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListPurchaseOrdersRequest) GetSupplierId() int32 {
+	if x != nil {
+		return x.SupplierId
+	}
+	return 0
+}
+
+type ListPurchaseOrdersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseOrders []*PurchaseOrder       `protobuf:"bytes,1,rep,name=purchase_orders,json=purchaseOrders,proto3" json:"purchase_orders,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPurchaseOrdersResponse) Reset() {
+	*x = ListPurchaseOrdersResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurchaseOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurchaseOrdersResponse) ProtoMessage() {}
+
+func (x *ListPurchaseOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurchaseOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListPurchaseOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ListPurchaseOrdersResponse) GetPurchaseOrders() []*PurchaseOrder {
+	if x != nil {
+		return x.PurchaseOrders
+	}
+	return nil
+}
+
+type GetPurchaseOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPurchaseOrderRequest) Reset() {
+	*x = GetPurchaseOrderRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPurchaseOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPurchaseOrderRequest) ProtoMessage() {}
+
+func (x *GetPurchaseOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPurchaseOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetPurchaseOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *GetPurchaseOrderRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// the purchase order and the goods received for it
+type GetPurchaseOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseOrder *PurchaseOrder         `protobuf:"bytes,1,opt,name=purchase_order,json=purchaseOrder,proto3" json:"purchase_order,omitempty"`
+	Receipts      []*Receipt             `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPurchaseOrderResponse) Reset() {
+	*x = GetPurchaseOrderResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPurchaseOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPurchaseOrderResponse) ProtoMessage() {}
+
+func (x *GetPurchaseOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPurchaseOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetPurchaseOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *GetPurchaseOrderResponse) GetPurchaseOrder() *PurchaseOrder {
+	if x != nil {
+		return x.PurchaseOrder
+	}
+	return nil
+}
+
+func (x *GetPurchaseOrderResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type CreatePurchaseOrderRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SupplierId int32                  `protobuf:"varint,1,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	LocationId int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	// received_quantity is ignored
+	Lines []*PurchaseOrderLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	// e.g. the supplier's order confirmation (optional)
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// RFC 3339 (optional)
+	ExpectedAt    string `protobuf:"bytes,5,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurchaseOrderRequest) Reset() {
+	*x = CreatePurchaseOrderRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurchaseOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurchaseOrderRequest) ProtoMessage() {}
+
+func (x *CreatePurchaseOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurchaseOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreatePurchaseOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *CreatePurchaseOrderRequest) GetSupplierId() int32 {
+	if x != nil {
+		return x.SupplierId
+	}
+	return 0
+}
+
+func (x *CreatePurchaseOrderRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *CreatePurchaseOrderRequest) GetLines() []*PurchaseOrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CreatePurchaseOrderRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CreatePurchaseOrderRequest) GetExpectedAt() string {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return ""
+}
+
+type CreatePurchaseOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseOrder *PurchaseOrder         `protobuf:"bytes,1,opt,name=purchase_order,json=purchaseOrder,proto3" json:"purchase_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurchaseOrderResponse) Reset() {
+	*x = CreatePurchaseOrderResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurchaseOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurchaseOrderResponse) ProtoMessage() {}
+
+func (x *CreatePurchaseOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurchaseOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreatePurchaseOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *CreatePurchaseOrderResponse) GetPurchaseOrder() *PurchaseOrder {
+	if x != nil {
+		return x.PurchaseOrder
+	}
+	return nil
+}
+
+// adds the units that arrived to the stock of the purchase order's location; more units
+// than are outstanding may be received
+type ReceivePurchaseOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Lines []*ReceiptLine         `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	// recorded with the receipts and stock movements, e.g. the delivery note (optional)
+	Reference     string `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceivePurchaseOrderRequest) Reset() {
+	*x = ReceivePurchaseOrderRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceivePurchaseOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceivePurchaseOrderRequest) ProtoMessage() {}
+
+func (x *ReceivePurchaseOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceivePurchaseOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReceivePurchaseOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ReceivePurchaseOrderRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReceivePurchaseOrderRequest) GetLines() []*ReceiptLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReceivePurchaseOrderRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+// the purchase order, the receipts just recorded and the items they changed
+type ReceivePurchaseOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseOrder *PurchaseOrder         `protobuf:"bytes,1,opt,name=purchase_order,json=purchaseOrder,proto3" json:"purchase_order,omitempty"`
+	Receipts      []*Receipt             `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	Items         []*InventoryItem       `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceivePurchaseOrderResponse) Reset() {
+	*x = ReceivePurchaseOrderResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceivePurchaseOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceivePurchaseOrderResponse) ProtoMessage() {}
+
+func (x *ReceivePurchaseOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceivePurchaseOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReceivePurchaseOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ReceivePurchaseOrderResponse) GetPurchaseOrder() *PurchaseOrder {
+	if x != nil {
+		return x.PurchaseOrder
+	}
+	return nil
+}
+
+func (x *ReceivePurchaseOrderResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+func (x *ReceivePurchaseOrderResponse) GetItems() []*InventoryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CancelPurchaseOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPurchaseOrderRequest) Reset() {
+	*x = CancelPurchaseOrderRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPurchaseOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPurchaseOrderRequest) ProtoMessage() {}
+
+func (x *CancelPurchaseOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPurchaseOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelPurchaseOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *CancelPurchaseOrderRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CancelPurchaseOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurchaseOrder *PurchaseOrder         `protobuf:"bytes,1,opt,name=purchase_order,json=purchaseOrder,proto3" json:"purchase_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPurchaseOrderResponse) Reset() {
+	*x = CancelPurchaseOrderResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPurchaseOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPurchaseOrderResponse) ProtoMessage() {}
+
+func (x *CancelPurchaseOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPurchaseOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelPurchaseOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *CancelPurchaseOrderResponse) GetPurchaseOrder() *PurchaseOrder {
+	if x != nil {
+		return x.PurchaseOrder
+	}
+	return nil
+}
+
+// units still expected from open and partially received purchase orders
+type IncomingStock struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductId  int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LocationId int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Quantity   int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the earliest expected arrival, empty when no order has one
+	ExpectedAt    string `protobuf:"bytes,4,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomingStock) Reset() {
+	*x = IncomingStock{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomingStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomingStock) ProtoMessage() {}
+
+func (x *IncomingStock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomingStock.ProtoReflect.Descriptor instead.
+func (*IncomingStock) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *IncomingStock) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *IncomingStock) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *IncomingStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *IncomingStock) GetExpectedAt() string {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return ""
+}
+
+type ListIncomingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the product's incoming units; every product's when 0
+	ProductId     int32 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingRequest) Reset() {
+	*x = ListIncomingRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingRequest) ProtoMessage() {}
+
+func (x *ListIncomingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListIncomingRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type ListIncomingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incoming      []*IncomingStock       `protobuf:"bytes,1,rep,name=incoming,proto3" json:"incoming,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingResponse) Reset() {
+	*x = ListIncomingResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingResponse) ProtoMessage() {}
+
+func (x *ListIncomingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListIncomingResponse) GetIncoming() []*IncomingStock {
+	if x != nil {
+		return x.Incoming
+	}
+	return nil
+}
+
+// units of a product received under a lot number, at a location; expired once expires_at
+// has passed, after which its unreserved units cannot be reserved
+type Lot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocationId    int32                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	ProductId     int32                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,4,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Stock         int32                  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
+	Reserved      int32                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Expired       bool                   `protobuf:"varint,8,opt,name=expired,proto3" json:"expired,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lot) Reset() {
+	*x = Lot{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lot) ProtoMessage() {}
+
+func (x *Lot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lot.ProtoReflect.Descriptor instead.
+func (*Lot) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *Lot) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Lot) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Lot) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Lot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *Lot) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Lot) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Lot) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Lot) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+func (x *Lot) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// units of a product from one lot; expires_at (RFC 3339) is the lot's expiry date, optional
+// when the lot is known
+type LotQuantity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotNumber     string                 `protobuf:"bytes,1,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotQuantity) Reset() {
+	*x = LotQuantity{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotQuantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotQuantity) ProtoMessage() {}
+
+func (x *LotQuantity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotQuantity.ProtoReflect.Descriptor instead.
+func (*LotQuantity) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *LotQuantity) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *LotQuantity) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *LotQuantity) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// units of a lot taken out of stock by fulfilling a reservation
+type LotConsumption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId int32                  `protobuf:"varint,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,2,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotConsumption) Reset() {
+	*x = LotConsumption{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotConsumption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotConsumption) ProtoMessage() {}
+
+func (x *LotConsumption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotConsumption.ProtoReflect.Descriptor instead.
+func (*LotConsumption) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *LotConsumption) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *LotConsumption) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *LotConsumption) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *LotConsumption) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ListLotsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// the lots at this location; at every location when 0
+	LocationId    int32 `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsRequest) Reset() {
+	*x = ListLotsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsRequest) ProtoMessage() {}
+
+func (x *ListLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListLotsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *ListLotsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ListLotsRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+type ListLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lots          []*Lot                 `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsResponse) Reset() {
+	*x = ListLotsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsResponse) ProtoMessage() {}
+
+func (x *ListLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *ListLotsResponse) GetLots() []*Lot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+// a unit of a serial-tracked product; state is available, reserved (picked for
+// reservation_id), shipped (by reservation_id) or returned
+type SerialUnit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId       int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SerialNumber    string                 `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	LocationId      int32                  `protobuf:"varint,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	State           string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	ReservationId   int32                  `protobuf:"varint,6,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	PurchaseOrderId int32                  `protobuf:"varint,7,opt,name=purchase_order_id,json=purchaseOrderId,proto3" json:"purchase_order_id,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SerialUnit) Reset() {
+	*x = SerialUnit{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialUnit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialUnit) ProtoMessage() {}
+
+func (x *SerialUnit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialUnit.ProtoReflect.Descriptor instead.
+func (*SerialUnit) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *SerialUnit) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SerialUnit) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SerialUnit) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *SerialUnit) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *SerialUnit) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SerialUnit) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *SerialUnit) GetPurchaseOrderId() int32 {
+	if x != nil {
+		return x.PurchaseOrderId
+	}
+	return 0
+}
+
+func (x *SerialUnit) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SerialUnit) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// a serialized unit shipped by fulfilling a reservation
+type SerialConsumption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId int32                  `protobuf:"varint,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerialConsumption) Reset() {
+	*x = SerialConsumption{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialConsumption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialConsumption) ProtoMessage() {}
+
+func (x *SerialConsumption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialConsumption.ProtoReflect.Descriptor instead.
+func (*SerialConsumption) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *SerialConsumption) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *SerialConsumption) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+// tracking can only be turned on while the product holds no stock (FAILED_PRECONDITION)
+type SetSerialTrackingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Stock         int32                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseReservationRequest) Reset() {
-	*x = ReleaseReservationRequest{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[11]
+func (x *SetSerialTrackingRequest) Reset() {
+	*x = SetSerialTrackingRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseReservationRequest) String() string {
+func (x *SetSerialTrackingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseReservationRequest) ProtoMessage() {}
+func (*SetSerialTrackingRequest) ProtoMessage() {}
 
-func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[11]
+func (x *SetSerialTrackingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,47 +7811,47 @@ func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SetSerialTrackingRequest.ProtoReflect.Descriptor instead.
+func (*SetSerialTrackingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *ReleaseReservationRequest) GetProductId() int32 {
+func (x *SetSerialTrackingRequest) GetProductId() int32 {
 	if x != nil {
 		return x.ProductId
 	}
 	return 0
 }
 
-func (x *ReleaseReservationRequest) GetStock() int32 {
+func (x *SetSerialTrackingRequest) GetEnabled() bool {
 	if x != nil {
-		return x.Stock
+		return x.Enabled
 	}
-	return 0
+	return false
 }
 
-type ReleaseReservationResponse struct {
+type SetSerialTrackingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseReservationResponse) Reset() {
-	*x = ReleaseReservationResponse{}
-	mi := &file_proto_inventory_inventory_proto_msgTypes[12]
+func (x *SetSerialTrackingResponse) Reset() {
+	*x = SetSerialTrackingResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseReservationResponse) String() string {
+func (x *SetSerialTrackingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseReservationResponse) ProtoMessage() {}
+func (*SetSerialTrackingResponse) ProtoMessage() {}
 
-func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_inventory_proto_msgTypes[12]
+func (x *SetSerialTrackingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,67 +7862,940 @@ func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
-func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SetSerialTrackingResponse.ProtoReflect.Descriptor instead.
+func (*SetSerialTrackingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *ReleaseReservationResponse) GetItem() *InventoryItem {
+func (x *SetSerialTrackingResponse) GetItem() *InventoryItem {
 	if x != nil {
 		return x.Item
 	}
 	return nil
 }
 
+// lists the serialized units matching every field set
+type ListSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	LocationId    int32                  `protobuf:"varint,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	ReservationId int32                  `protobuf:"varint,5,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialsRequest) Reset() {
+	*x = ListSerialsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialsRequest) ProtoMessage() {}
+
+func (x *ListSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ListSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ListSerialsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ListSerialsRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ListSerialsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListSerialsRequest) GetLocationId() int32 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *ListSerialsRequest) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+type ListSerialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serials       []*SerialUnit          `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialsResponse) Reset() {
+	*x = ListSerialsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialsResponse) ProtoMessage() {}
+
+func (x *ListSerialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialsResponse.ProtoReflect.Descriptor instead.
+func (*ListSerialsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *ListSerialsResponse) GetSerials() []*SerialUnit {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+// picks units for an active reservation, which ships them when it is fulfilled
+type ReserveSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId int32                  `protobuf:"varint,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveSerialsRequest) Reset() {
+	*x = ReserveSerialsRequest{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSerialsRequest) ProtoMessage() {}
+
+func (x *ReserveSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *ReserveSerialsRequest) GetReservationId() int32 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+func (x *ReserveSerialsRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type ReserveSerialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serials       []*SerialUnit          `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveSerialsResponse) Reset() {
+	*x = ReserveSerialsResponse{}
+	mi := &file_proto_inventory_inventory_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSerialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSerialsResponse) ProtoMessage() {}
+
+func (x *ReserveSerialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_inventory_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSerialsResponse.ProtoReflect.Descriptor instead.
+func (*ReserveSerialsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_inventory_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *ReserveSerialsResponse) GetSerials() []*SerialUnit {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
 var File_proto_inventory_inventory_proto protoreflect.FileDescriptor
 
 const file_proto_inventory_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x1fproto/inventory/inventory.proto\x12\tinventory\"`\n" +
+	"\x1fproto/inventory/inventory.proto\x12\tinventory\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x9d\x03\n" +
 	"\rInventoryItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x14\n" +
 	"\x05stock\x18\x02 \x01(\x05R\x05stock\x12\x1a\n" +
-	"\breserved\x18\x03 \x01(\x05R\breserved\"4\n" +
+	"\breserved\x18\x03 \x01(\x05R\breserved\x12\x18\n" +
+	"\adamaged\x18\x04 \x01(\x05R\adamaged\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x12\x1f\n" +
+	"\vlocation_id\x18\x06 \x01(\x05R\n" +
+	"locationId\x126\n" +
+	"\tlocations\x18\a \x03(\v2\x18.inventory.InventoryItemR\tlocations\x12\x1d\n" +
+	"\n" +
+	"in_transit\x18\b \x01(\x05R\tinTransit\x12#\n" +
+	"\rreorder_point\x18\t \x01(\x05R\freorderPoint\x12)\n" +
+	"\x10reorder_quantity\x18\n" +
+	" \x01(\x05R\x0freorderQuantity\x12\x18\n" +
+	"\aexpired\x18\v \x01(\x05R\aexpired\x12%\n" +
+	"\x0eserial_tracked\x18\f \x01(\bR\rserialTracked\"j\n" +
 	"\x13GetInventoryRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\x05R\tproductId\"D\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x13\n" +
+	"\x05as_of\x18\x03 \x01(\tR\x04asOf\"D\n" +
 	"\x14GetInventoryResponse\x12,\n" +
-	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\x16\n" +
-	"\x14ListInventoryRequest\"G\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"L\n" +
+	"\x14ListInventoryRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\x05R\n" +
+	"locationId\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\tR\x04asOf\"G\n" +
 	"\x15ListInventoryResponse\x12.\n" +
-	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\"O\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\"\xb9\x01\n" +
 	"\x12UpdateStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"C\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\x05R\x0fexpectedVersion\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\x05R\n" +
+	"locationId\"C\n" +
 	"\x13UpdateStockResponse\x12,\n" +
-	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"J\n" +
-	"\x13ReserveStockRequest\x12\x1d\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\x8d\x02\n" +
+	"\x12AdjustStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x14\n" +
-	"\x05stock\x18\x02 \x01(\x05R\x05stock\"D\n" +
-	"\x14ReserveStockResponse\x12,\n" +
-	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"P\n" +
-	"\x19FulfillReservationRequest\x12\x1d\n" +
+	"\x05delta\x18\x02 \x01(\x05R\x05delta\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\x05R\n" +
+	"locationId\x12-\n" +
+	"\tunit_cost\x18\x06 \x01(\v2\x10.inventory.MoneyR\bunitCost\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\a \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\tR\texpiresAt\"C\n" +
+	"\x13AdjustStockResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xa8\x01\n" +
+	"\x16SetReorderPointRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12#\n" +
+	"\rreorder_point\x18\x02 \x01(\x05R\freorderPoint\x12)\n" +
+	"\x10reorder_quantity\x18\x03 \x01(\x05R\x0freorderQuantity\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\x05R\n" +
+	"locationId\"G\n" +
+	"\x17SetReorderPointResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"6\n" +
+	"\x13ListLowStockRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\x05R\n" +
+	"locationId\"F\n" +
+	"\x14ListLowStockResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\"\xfe\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12-\n" +
+	"\x12fulfilled_quantity\x18\x04 \x01(\x05R\x11fulfilledQuantity\x12+\n" +
+	"\x11released_quantity\x18\x05 \x01(\x05R\x10releasedQuantity\x12\x14\n" +
+	"\x05owner\x18\x06 \x01(\tR\x05owner\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\tR\texpiresAt\x12\x1f\n" +
+	"\vlocation_id\x18\n" +
+	" \x01(\x05R\n" +
+	"locationId\x12=\n" +
+	"\x12cost_of_goods_sold\x18\v \x01(\v2\x10.inventory.MoneyR\x0fcostOfGoodsSold\"G\n" +
+	"\vCoordinates\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xf4\x01\n" +
+	"\x13ReserveStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x14\n" +
-	"\x05stock\x18\x02 \x01(\x05R\x05stock\"J\n" +
+	"\x05stock\x18\x02 \x01(\x05R\x05stock\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\x05R\n" +
+	"locationId\x12\x16\n" +
+	"\x06policy\x18\x06 \x01(\tR\x06policy\x128\n" +
+	"\vdestination\x18\a \x01(\v2\x16.inventory.CoordinatesR\vdestination\"~\n" +
+	"\x14ReserveStockResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\x128\n" +
+	"\vreservation\x18\x02 \x01(\v2\x16.inventory.ReservationR\vreservation\"\x91\x01\n" +
+	"\x19FulfillReservationRequest\x12\x14\n" +
+	"\x05stock\x18\x02 \x01(\x05R\x05stock\x12%\n" +
+	"\x0ereservation_id\x18\x03 \x01(\x05R\rreservationId\x12%\n" +
+	"\x0eserial_numbers\x18\x04 \x03(\tR\rserialNumbersJ\x04\b\x01\x10\x02R\n" +
+	"product_id\"\xaa\x02\n" +
 	"\x1aFulfillReservationResponse\x12,\n" +
-	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"P\n" +
-	"\x19ReleaseReservationRequest\x12\x1d\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\x128\n" +
+	"\vreservation\x18\x02 \x01(\v2\x16.inventory.ReservationR\vreservation\x12-\n" +
+	"\x04lots\x18\x03 \x03(\v2\x19.inventory.LotConsumptionR\x04lots\x126\n" +
+	"\aserials\x18\x04 \x03(\v2\x1c.inventory.SerialConsumptionR\aserials\x12=\n" +
+	"\x12cost_of_goods_sold\x18\x05 \x01(\v2\x10.inventory.MoneyR\x0fcostOfGoodsSold\"j\n" +
+	"\x19ReleaseReservationRequest\x12\x14\n" +
+	"\x05stock\x18\x02 \x01(\x05R\x05stock\x12%\n" +
+	"\x0ereservation_id\x18\x03 \x01(\x05R\rreservationIdJ\x04\b\x01\x10\x02R\n" +
+	"product_id\"\x84\x01\n" +
+	"\x1aReleaseReservationResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\x128\n" +
+	"\vreservation\x18\x02 \x01(\v2\x16.inventory.ReservationR\vreservation\"'\n" +
+	"\x15GetReservationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"R\n" +
+	"\x16GetReservationResponse\x128\n" +
+	"\vreservation\x18\x01 \x01(\v2\x16.inventory.ReservationR\vreservation\"N\n" +
+	"\x17ListReservationsRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x14\n" +
-	"\x05stock\x18\x02 \x01(\x05R\x05stock\"J\n" +
-	"\x1aReleaseReservationResponse\x12,\n" +
-	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item2\x9c\x04\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\"V\n" +
+	"\x18ListReservationsResponse\x12:\n" +
+	"\freservations\x18\x01 \x03(\v2\x16.inventory.ReservationR\freservations\"\xb1\x02\n" +
+	"\x14ReceiveReturnRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x18\n" +
+	"\arestock\x18\x02 \x01(\x05R\arestock\x12\x18\n" +
+	"\adamaged\x18\x03 \x01(\x05R\adamaged\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\x05R\n" +
+	"locationId\x12%\n" +
+	"\x0eserial_numbers\x18\x06 \x03(\tR\rserialNumbers\x124\n" +
+	"\x16damaged_serial_numbers\x18\a \x03(\tR\x14damagedSerialNumbers\x12*\n" +
+	"\x04lots\x18\b \x03(\v2\x16.inventory.LotQuantityR\x04lots\"E\n" +
+	"\x15ReceiveReturnResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xb5\x01\n" +
+	"\tStockLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12%\n" +
+	"\x0ereservation_id\x18\x03 \x01(\x05R\rreservationId\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\x05R\n" +
+	"locationId\x12%\n" +
+	"\x0eserial_numbers\x18\x05 \x03(\tR\rserialNumbers\"\xa8\x01\n" +
+	"\vLineFailure\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12%\n" +
+	"\x0ereservation_id\x18\x04 \x01(\x05R\rreservationId\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\x05R\n" +
+	"locationId\"B\n" +
+	"\fBatchFailure\x122\n" +
+	"\bfailures\x18\x01 \x03(\v2\x16.inventory.LineFailureR\bfailures\"\xcf\x01\n" +
+	"\x18ReserveStockBatchRequest\x12*\n" +
+	"\x05lines\x18\x01 \x03(\v2\x14.inventory.StockLineR\x05lines\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x05R\n" +
+	"ttlSeconds\x12\x16\n" +
+	"\x06policy\x18\x04 \x01(\tR\x06policy\x128\n" +
+	"\vdestination\x18\x05 \x01(\v2\x16.inventory.CoordinatesR\vdestination\"\x87\x01\n" +
+	"\x19ReserveStockBatchResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\x12:\n" +
+	"\freservations\x18\x02 \x03(\v2\x16.inventory.ReservationR\freservations\"L\n" +
+	"\x1eFulfillReservationBatchRequest\x12*\n" +
+	"\x05lines\x18\x01 \x03(\v2\x14.inventory.StockLineR\x05lines\"\xf4\x01\n" +
+	"\x1fFulfillReservationBatchResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\x12:\n" +
+	"\freservations\x18\x02 \x03(\v2\x16.inventory.ReservationR\freservations\x12-\n" +
+	"\x04lots\x18\x03 \x03(\v2\x19.inventory.LotConsumptionR\x04lots\x126\n" +
+	"\aserials\x18\x04 \x03(\v2\x1c.inventory.SerialConsumptionR\aserials\"L\n" +
+	"\x1eReleaseReservationBatchRequest\x12*\n" +
+	"\x05lines\x18\x01 \x03(\v2\x14.inventory.StockLineR\x05lines\"\x8d\x01\n" +
+	"\x1fReleaseReservationBatchResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x05items\x12:\n" +
+	"\freservations\x18\x02 \x03(\v2\x16.inventory.ReservationR\freservations\"\xf1\x04\n" +
+	"\bMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vstock_delta\x18\x04 \x01(\x05R\n" +
+	"stockDelta\x12%\n" +
+	"\x0ereserved_delta\x18\x05 \x01(\x05R\rreservedDelta\x12#\n" +
+	"\rdamaged_delta\x18\x06 \x01(\x05R\fdamagedDelta\x12\x14\n" +
+	"\x05stock\x18\a \x01(\x05R\x05stock\x12\x1a\n" +
+	"\breserved\x18\b \x01(\x05R\breserved\x12\x18\n" +
+	"\adamaged\x18\t \x01(\x05R\adamaged\x12%\n" +
+	"\x0ereservation_id\x18\n" +
+	" \x01(\x05R\rreservationId\x12!\n" +
+	"\freference_id\x18\v \x01(\tR\vreferenceId\x12\x14\n" +
+	"\x05actor\x18\f \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vlocation_id\x18\x0e \x01(\x05R\n" +
+	"locationId\x12\x1f\n" +
+	"\vtransfer_id\x18\x0f \x01(\x05R\n" +
+	"transferId\x12*\n" +
+	"\x11purchase_order_id\x18\x10 \x01(\x05R\x0fpurchaseOrderId\x12!\n" +
+	"\fstocktake_id\x18\x11 \x01(\x05R\vstocktakeId\x12-\n" +
+	"\tunit_cost\x18\x12 \x01(\v2\x10.inventory.MoneyR\bunitCost\x12&\n" +
+	"\x05value\x18\x13 \x01(\v2\x10.inventory.MoneyR\x05value\"\xab\x01\n" +
+	"\x14ListMovementsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x19\n" +
+	"\bafter_id\x18\x04 \x01(\x03R\aafterId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vlocation_id\x18\x06 \x01(\x05R\n" +
+	"locationId\"J\n" +
+	"\x15ListMovementsResponse\x121\n" +
+	"\tmovements\x18\x01 \x03(\v2\x13.inventory.MovementR\tmovements\"\x98\x01\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\"\x16\n" +
+	"\x14ListLocationsRequest\"J\n" +
+	"\x15ListLocationsResponse\x121\n" +
+	"\tlocations\x18\x01 \x03(\v2\x13.inventory.LocationR\tlocations\"$\n" +
+	"\x12GetLocationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"F\n" +
+	"\x13GetLocationResponse\x12/\n" +
+	"\blocation\x18\x01 \x01(\v2\x13.inventory.LocationR\blocation\"\x95\x01\n" +
+	"\x15CreateLocationRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\"I\n" +
+	"\x16CreateLocationResponse\x12/\n" +
+	"\blocation\x18\x01 \x01(\v2\x13.inventory.LocationR\blocation\"\x9c\x01\n" +
+	"\fTransferLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12%\n" +
+	"\x0eserial_numbers\x18\x03 \x03(\tR\rserialNumbers\x12*\n" +
+	"\x04lots\x18\x04 \x03(\v2\x16.inventory.LotQuantityR\x04lots\"\xa4\x02\n" +
+	"\bTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\x12source_location_id\x18\x02 \x01(\x05R\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x03 \x01(\x05R\x15destinationLocationId\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12-\n" +
+	"\x05lines\x18\x05 \x03(\v2\x17.inventory.TransferLineR\x05lines\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"shipped_at\x18\a \x01(\tR\tshippedAt\x12\x1f\n" +
+	"\vreceived_at\x18\b \x01(\tR\n" +
+	"receivedAt\"M\n" +
+	"\x14ListTransfersRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\"J\n" +
+	"\x15ListTransfersResponse\x121\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x13.inventory.TransferR\ttransfers\"$\n" +
+	"\x12GetTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"F\n" +
+	"\x13GetTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.inventory.TransferR\btransfer\"\xac\x01\n" +
+	"\x15CreateTransferRequest\x12,\n" +
+	"\x12source_location_id\x18\x01 \x01(\x05R\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x02 \x01(\x05R\x15destinationLocationId\x12-\n" +
+	"\x05lines\x18\x03 \x03(\v2\x17.inventory.TransferLineR\x05lines\"I\n" +
+	"\x16CreateTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.inventory.TransferR\btransfer\"%\n" +
+	"\x13ShipTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"w\n" +
+	"\x14ShipTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.inventory.TransferR\btransfer\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.inventory.InventoryItemR\x05items\"(\n" +
+	"\x16ReceiveTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"z\n" +
+	"\x17ReceiveTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.inventory.TransferR\btransfer\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.inventory.InventoryItemR\x05items\"e\n" +
+	"\x0eStocktakeCount\x12\x18\n" +
+	"\acounter\x18\x01 \x01(\tR\acounter\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"counted_at\x18\x03 \x01(\tR\tcountedAt\"\xc9\x01\n" +
+	"\rStocktakeLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bexpected\x18\x02 \x01(\x05R\bexpected\x12\x18\n" +
+	"\acounted\x18\x03 \x01(\x05R\acounted\x12\x1a\n" +
+	"\bvariance\x18\x04 \x01(\x05R\bvariance\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x121\n" +
+	"\x06counts\x18\x06 \x03(\v2\x19.inventory.StocktakeCountR\x06counts\"\xfb\x01\n" +
+	"\tStocktake\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12.\n" +
+	"\x05lines\x18\x06 \x03(\v2\x18.inventory.StocktakeLineR\x05lines\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tclosed_at\x18\b \x01(\tR\bclosedAt\"N\n" +
+	"\x15ListStocktakesRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\"N\n" +
+	"\x16ListStocktakesResponse\x124\n" +
+	"\n" +
+	"stocktakes\x18\x01 \x03(\v2\x14.inventory.StocktakeR\n" +
+	"stocktakes\"%\n" +
+	"\x13GetStocktakeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"J\n" +
+	"\x14GetStocktakeResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\"x\n" +
+	"\x16CreateStocktakeRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\x05R\n" +
+	"locationId\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\x05R\n" +
+	"productIds\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"M\n" +
+	"\x17CreateStocktakeResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\"O\n" +
+	"\x12StocktakeCountLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"}\n" +
+	"\x1cSubmitStocktakeCountsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\acounter\x18\x02 \x01(\tR\acounter\x123\n" +
+	"\x05lines\x18\x03 \x03(\v2\x1d.inventory.StocktakeCountLineR\x05lines\"S\n" +
+	"\x1dSubmitStocktakeCountsResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\"Z\n" +
+	"\x16ReviewStocktakeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x03(\x05R\aapprove\x12\x16\n" +
+	"\x06reject\x18\x03 \x03(\x05R\x06reject\"M\n" +
+	"\x17ReviewStocktakeResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\"&\n" +
+	"\x14PostStocktakeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"{\n" +
+	"\x15PostStocktakeResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.inventory.InventoryItemR\x05items\"(\n" +
+	"\x16CancelStocktakeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"M\n" +
+	"\x17CancelStocktakeResponse\x122\n" +
+	"\tstocktake\x18\x01 \x01(\v2\x14.inventory.StocktakeR\tstocktake\"\xd4\x01\n" +
+	"\rShrinkageLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1e\n" +
+	"\n" +
+	"stocktakes\x18\x02 \x01(\x05R\n" +
+	"stocktakes\x12\x1a\n" +
+	"\bexpected\x18\x03 \x01(\x05R\bexpected\x12\x18\n" +
+	"\acounted\x18\x04 \x01(\x05R\acounted\x12\x1c\n" +
+	"\tshrinkage\x18\x05 \x01(\x05R\tshrinkage\x12\x14\n" +
+	"\x05found\x18\x06 \x01(\x05R\x05found\x12\x1a\n" +
+	"\bvariance\x18\a \x01(\x05R\bvariance\"\x83\x01\n" +
+	"\x19GetShrinkageReportRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\x05R\n" +
+	"locationId\x12!\n" +
+	"\fstocktake_id\x18\x02 \x01(\x05R\vstocktakeId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"L\n" +
+	"\x1aGetShrinkageReportResponse\x12.\n" +
+	"\x05lines\x18\x01 \x03(\v2\x18.inventory.ShrinkageLineR\x05lines\"a\n" +
+	"\tImportRow\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x14\n" +
+	"\x05stock\x18\x03 \x01(\x05R\x05stock\"\x8d\x01\n" +
+	"\x16ImportInventoryRequest\x12(\n" +
+	"\x04rows\x18\x01 \x03(\v2\x14.inventory.ImportRowR\x04rows\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\"y\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\x05R\n" +
+	"locationId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xf8\x01\n" +
+	"\x17ImportInventoryResponse\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\tcommitted\x18\x03 \x01(\bR\tcommitted\x12\x12\n" +
+	"\x04rows\x18\x04 \x01(\x05R\x04rows\x12\x18\n" +
+	"\aapplied\x18\x05 \x01(\x05R\aapplied\x12\x1c\n" +
+	"\tunchanged\x18\x06 \x01(\x05R\tunchanged\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x05R\x06failed\x12.\n" +
+	"\x06errors\x18\b \x03(\v2\x16.inventory.ImportErrorR\x06errors\"\xcd\x01\n" +
+	"\tCostLayer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x05R\tremaining\x12-\n" +
+	"\tunit_cost\x18\x04 \x01(\v2\x10.inventory.MoneyR\bunitCost\x12&\n" +
+	"\x05value\x18\x05 \x01(\v2\x10.inventory.MoneyR\x05value\x12\x1f\n" +
+	"\vreceived_at\x18\x06 \x01(\tR\n" +
+	"receivedAt\"\xe8\x01\n" +
+	"\x0eProductCosting\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12&\n" +
+	"\x05value\x18\x04 \x01(\v2\x10.inventory.MoneyR\x05value\x12-\n" +
+	"\tunit_cost\x18\x05 \x01(\v2\x10.inventory.MoneyR\bunitCost\x12,\n" +
+	"\x06layers\x18\x06 \x03(\v2\x14.inventory.CostLayerR\x06layers\"2\n" +
+	"\x11GetCostingRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\"I\n" +
+	"\x12GetCostingResponse\x123\n" +
+	"\acosting\x18\x01 \x01(\v2\x19.inventory.ProductCostingR\acosting\"P\n" +
+	"\x17SetCostingMethodRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\"O\n" +
+	"\x18SetCostingMethodResponse\x123\n" +
+	"\acosting\x18\x01 \x01(\v2\x19.inventory.ProductCostingR\acosting\"\xe9\x01\n" +
+	"\rValuationLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12&\n" +
+	"\x05value\x18\x06 \x01(\v2\x10.inventory.MoneyR\x05value\x12-\n" +
+	"\tunit_cost\x18\a \x01(\v2\x10.inventory.MoneyR\bunitCost\"\x8f\x01\n" +
+	"\x11ValuationCategory\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1a\n" +
+	"\bproducts\x18\x02 \x01(\x05R\bproducts\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12&\n" +
+	"\x05value\x18\x04 \x01(\v2\x10.inventory.MoneyR\x05value\"g\n" +
+	"\x13GetValuationRequest\x12\x13\n" +
+	"\x05as_of\x18\x01 \x01(\tR\x04asOf\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\"\x9f\x01\n" +
+	"\x14GetValuationResponse\x12\x13\n" +
+	"\x05as_of\x18\x01 \x01(\tR\x04asOf\x124\n" +
+	"\bproducts\x18\x02 \x03(\v2\x18.inventory.ValuationLineR\bproducts\x12<\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\v2\x1c.inventory.ValuationCategoryR\n" +
+	"categories\"w\n" +
+	"\bSupplier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x16\n" +
+	"\x14ListSuppliersRequest\"J\n" +
+	"\x15ListSuppliersResponse\x121\n" +
+	"\tsuppliers\x18\x01 \x03(\v2\x13.inventory.SupplierR\tsuppliers\"$\n" +
+	"\x12GetSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"F\n" +
+	"\x13GetSupplierResponse\x12/\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x13.inventory.SupplierR\bsupplier\"U\n" +
+	"\x15CreateSupplierRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"I\n" +
+	"\x16CreateSupplierResponse\x12/\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x13.inventory.SupplierR\bsupplier\"\xaa\x01\n" +
+	"\x11PurchaseOrderLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12+\n" +
+	"\x11received_quantity\x18\x03 \x01(\x05R\x10receivedQuantity\x12-\n" +
+	"\tunit_cost\x18\x04 \x01(\v2\x10.inventory.MoneyR\bunitCost\"\xa6\x02\n" +
+	"\rPurchaseOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\x05R\n" +
+	"supplierId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\x05R\n" +
+	"locationId\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12\x1f\n" +
+	"\vexpected_at\x18\x06 \x01(\tR\n" +
+	"expectedAt\x122\n" +
+	"\x05lines\x18\a \x03(\v2\x1c.inventory.PurchaseOrderLineR\x05lines\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\tclosed_at\x18\t \x01(\tR\bclosedAt\"\xdc\x01\n" +
+	"\vReceiptLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x03 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12%\n" +
+	"\x0eserial_numbers\x18\x05 \x03(\tR\rserialNumbers\x12-\n" +
+	"\tunit_cost\x18\x06 \x01(\v2\x10.inventory.MoneyR\bunitCost\"\xe3\x02\n" +
+	"\aReceipt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12*\n" +
+	"\x11purchase_order_id\x18\x02 \x01(\x05R\x0fpurchaseOrderId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\x05R\n" +
+	"locationId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\treference\x18\x06 \x01(\tR\treference\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x1f\n" +
+	"\vreceived_at\x18\b \x01(\tR\n" +
+	"receivedAt\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\t \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\tR\texpiresAt\x12-\n" +
+	"\tunit_cost\x18\v \x01(\v2\x10.inventory.MoneyR\bunitCost\"R\n" +
+	"\x19ListPurchaseOrdersRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\x05R\n" +
+	"supplierId\"_\n" +
+	"\x1aListPurchaseOrdersResponse\x12A\n" +
+	"\x0fpurchase_orders\x18\x01 \x03(\v2\x18.inventory.PurchaseOrderR\x0epurchaseOrders\")\n" +
+	"\x17GetPurchaseOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x8b\x01\n" +
+	"\x18GetPurchaseOrderResponse\x12?\n" +
+	"\x0epurchase_order\x18\x01 \x01(\v2\x18.inventory.PurchaseOrderR\rpurchaseOrder\x12.\n" +
+	"\breceipts\x18\x02 \x03(\v2\x12.inventory.ReceiptR\breceipts\"\xd1\x01\n" +
+	"\x1aCreatePurchaseOrderRequest\x12\x1f\n" +
+	"\vsupplier_id\x18\x01 \x01(\x05R\n" +
+	"supplierId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x122\n" +
+	"\x05lines\x18\x03 \x03(\v2\x1c.inventory.PurchaseOrderLineR\x05lines\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x1f\n" +
+	"\vexpected_at\x18\x05 \x01(\tR\n" +
+	"expectedAt\"^\n" +
+	"\x1bCreatePurchaseOrderResponse\x12?\n" +
+	"\x0epurchase_order\x18\x01 \x01(\v2\x18.inventory.PurchaseOrderR\rpurchaseOrder\"y\n" +
+	"\x1bReceivePurchaseOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\x05lines\x18\x02 \x03(\v2\x16.inventory.ReceiptLineR\x05lines\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"\xbf\x01\n" +
+	"\x1cReceivePurchaseOrderResponse\x12?\n" +
+	"\x0epurchase_order\x18\x01 \x01(\v2\x18.inventory.PurchaseOrderR\rpurchaseOrder\x12.\n" +
+	"\breceipts\x18\x02 \x03(\v2\x12.inventory.ReceiptR\breceipts\x12.\n" +
+	"\x05items\x18\x03 \x03(\v2\x18.inventory.InventoryItemR\x05items\",\n" +
+	"\x1aCancelPurchaseOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"^\n" +
+	"\x1bCancelPurchaseOrderResponse\x12?\n" +
+	"\x0epurchase_order\x18\x01 \x01(\v2\x18.inventory.PurchaseOrderR\rpurchaseOrder\"\x8c\x01\n" +
+	"\rIncomingStock\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vexpected_at\x18\x04 \x01(\tR\n" +
+	"expectedAt\"4\n" +
+	"\x13ListIncomingRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\"L\n" +
+	"\x14ListIncomingResponse\x124\n" +
+	"\bincoming\x18\x01 \x03(\v2\x18.inventory.IncomingStockR\bincoming\"\xfe\x01\n" +
+	"\x03Lot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x05R\tproductId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x04 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\x12\x14\n" +
+	"\x05stock\x18\x06 \x01(\x05R\x05stock\x12\x1a\n" +
+	"\breserved\x18\a \x01(\x05R\breserved\x12\x18\n" +
+	"\aexpired\x18\b \x01(\bR\aexpired\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"g\n" +
+	"\vLotQuantity\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x01 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\x91\x01\n" +
+	"\x0eLotConsumption\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\x05R\rreservationId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x02 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"Q\n" +
+	"\x0fListLotsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x05R\n" +
+	"locationId\"6\n" +
+	"\x10ListLotsResponse\x12\"\n" +
+	"\x04lots\x18\x01 \x03(\v2\x0e.inventory.LotR\x04lots\"\xa8\x02\n" +
+	"\n" +
+	"SerialUnit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12#\n" +
+	"\rserial_number\x18\x03 \x01(\tR\fserialNumber\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\x05R\n" +
+	"locationId\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12%\n" +
+	"\x0ereservation_id\x18\x06 \x01(\x05R\rreservationId\x12*\n" +
+	"\x11purchase_order_id\x18\a \x01(\x05R\x0fpurchaseOrderId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\"_\n" +
+	"\x11SerialConsumption\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\x05R\rreservationId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\"S\n" +
+	"\x18SetSerialTrackingRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"I\n" +
+	"\x19SetSerialTrackingResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xb6\x01\n" +
+	"\x12ListSerialsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\x05R\n" +
+	"locationId\x12%\n" +
+	"\x0ereservation_id\x18\x05 \x01(\x05R\rreservationId\"F\n" +
+	"\x13ListSerialsResponse\x12/\n" +
+	"\aserials\x18\x01 \x03(\v2\x15.inventory.SerialUnitR\aserials\"e\n" +
+	"\x15ReserveSerialsRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\x05R\rreservationId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\"I\n" +
+	"\x16ReserveSerialsResponse\x12/\n" +
+	"\aserials\x18\x01 \x03(\v2\x15.inventory.SerialUnitR\aserials2\x89\"\n" +
 	"\x10InventoryService\x12O\n" +
 	"\fGetInventory\x12\x1e.inventory.GetInventoryRequest\x1a\x1f.inventory.GetInventoryResponse\x12R\n" +
 	"\rListInventory\x12\x1f.inventory.ListInventoryRequest\x1a .inventory.ListInventoryResponse\x12L\n" +
-	"\vUpdateStock\x12\x1d.inventory.UpdateStockRequest\x1a\x1e.inventory.UpdateStockResponse\x12O\n" +
+	"\vUpdateStock\x12\x1d.inventory.UpdateStockRequest\x1a\x1e.inventory.UpdateStockResponse\x12L\n" +
+	"\vAdjustStock\x12\x1d.inventory.AdjustStockRequest\x1a\x1e.inventory.AdjustStockResponse\x12X\n" +
+	"\x0fSetReorderPoint\x12!.inventory.SetReorderPointRequest\x1a\".inventory.SetReorderPointResponse\x12O\n" +
+	"\fListLowStock\x12\x1e.inventory.ListLowStockRequest\x1a\x1f.inventory.ListLowStockResponse\x12O\n" +
 	"\fReserveStock\x12\x1e.inventory.ReserveStockRequest\x1a\x1f.inventory.ReserveStockResponse\x12a\n" +
 	"\x12FulfillReservation\x12$.inventory.FulfillReservationRequest\x1a%.inventory.FulfillReservationResponse\x12a\n" +
-	"\x12ReleaseReservation\x12$.inventory.ReleaseReservationRequest\x1a%.inventory.ReleaseReservationResponseB#Z!inventory-service/proto/inventoryb\x06proto3"
+	"\x12ReleaseReservation\x12$.inventory.ReleaseReservationRequest\x1a%.inventory.ReleaseReservationResponse\x12R\n" +
+	"\rReceiveReturn\x12\x1f.inventory.ReceiveReturnRequest\x1a .inventory.ReceiveReturnResponse\x12U\n" +
+	"\x0eGetReservation\x12 .inventory.GetReservationRequest\x1a!.inventory.GetReservationResponse\x12[\n" +
+	"\x10ListReservations\x12\".inventory.ListReservationsRequest\x1a#.inventory.ListReservationsResponse\x12R\n" +
+	"\rListMovements\x12\x1f.inventory.ListMovementsRequest\x1a .inventory.ListMovementsResponse\x12R\n" +
+	"\rListLocations\x12\x1f.inventory.ListLocationsRequest\x1a .inventory.ListLocationsResponse\x12L\n" +
+	"\vGetLocation\x12\x1d.inventory.GetLocationRequest\x1a\x1e.inventory.GetLocationResponse\x12U\n" +
+	"\x0eCreateLocation\x12 .inventory.CreateLocationRequest\x1a!.inventory.CreateLocationResponse\x12R\n" +
+	"\rListTransfers\x12\x1f.inventory.ListTransfersRequest\x1a .inventory.ListTransfersResponse\x12L\n" +
+	"\vGetTransfer\x12\x1d.inventory.GetTransferRequest\x1a\x1e.inventory.GetTransferResponse\x12U\n" +
+	"\x0eCreateTransfer\x12 .inventory.CreateTransferRequest\x1a!.inventory.CreateTransferResponse\x12O\n" +
+	"\fShipTransfer\x12\x1e.inventory.ShipTransferRequest\x1a\x1f.inventory.ShipTransferResponse\x12X\n" +
+	"\x0fReceiveTransfer\x12!.inventory.ReceiveTransferRequest\x1a\".inventory.ReceiveTransferResponse\x12U\n" +
+	"\x0eListStocktakes\x12 .inventory.ListStocktakesRequest\x1a!.inventory.ListStocktakesResponse\x12O\n" +
+	"\fGetStocktake\x12\x1e.inventory.GetStocktakeRequest\x1a\x1f.inventory.GetStocktakeResponse\x12X\n" +
+	"\x0fCreateStocktake\x12!.inventory.CreateStocktakeRequest\x1a\".inventory.CreateStocktakeResponse\x12j\n" +
+	"\x15SubmitStocktakeCounts\x12'.inventory.SubmitStocktakeCountsRequest\x1a(.inventory.SubmitStocktakeCountsResponse\x12X\n" +
+	"\x0fReviewStocktake\x12!.inventory.ReviewStocktakeRequest\x1a\".inventory.ReviewStocktakeResponse\x12R\n" +
+	"\rPostStocktake\x12\x1f.inventory.PostStocktakeRequest\x1a .inventory.PostStocktakeResponse\x12X\n" +
+	"\x0fCancelStocktake\x12!.inventory.CancelStocktakeRequest\x1a\".inventory.CancelStocktakeResponse\x12a\n" +
+	"\x12GetShrinkageReport\x12$.inventory.GetShrinkageReportRequest\x1a%.inventory.GetShrinkageReportResponse\x12R\n" +
+	"\rListSuppliers\x12\x1f.inventory.ListSuppliersRequest\x1a .inventory.ListSuppliersResponse\x12L\n" +
+	"\vGetSupplier\x12\x1d.inventory.GetSupplierRequest\x1a\x1e.inventory.GetSupplierResponse\x12U\n" +
+	"\x0eCreateSupplier\x12 .inventory.CreateSupplierRequest\x1a!.inventory.CreateSupplierResponse\x12a\n" +
+	"\x12ListPurchaseOrders\x12$.inventory.ListPurchaseOrdersRequest\x1a%.inventory.ListPurchaseOrdersResponse\x12[\n" +
+	"\x10GetPurchaseOrder\x12\".inventory.GetPurchaseOrderRequest\x1a#.inventory.GetPurchaseOrderResponse\x12d\n" +
+	"\x13CreatePurchaseOrder\x12%.inventory.CreatePurchaseOrderRequest\x1a&.inventory.CreatePurchaseOrderResponse\x12g\n" +
+	"\x14ReceivePurchaseOrder\x12&.inventory.ReceivePurchaseOrderRequest\x1a'.inventory.ReceivePurchaseOrderResponse\x12d\n" +
+	"\x13CancelPurchaseOrder\x12%.inventory.CancelPurchaseOrderRequest\x1a&.inventory.CancelPurchaseOrderResponse\x12O\n" +
+	"\fListIncoming\x12\x1e.inventory.ListIncomingRequest\x1a\x1f.inventory.ListIncomingResponse\x12C\n" +
+	"\bListLots\x12\x1a.inventory.ListLotsRequest\x1a\x1b.inventory.ListLotsResponse\x12^\n" +
+	"\x11SetSerialTracking\x12#.inventory.SetSerialTrackingRequest\x1a$.inventory.SetSerialTrackingResponse\x12L\n" +
+	"\vListSerials\x12\x1d.inventory.ListSerialsRequest\x1a\x1e.inventory.ListSerialsResponse\x12U\n" +
+	"\x0eReserveSerials\x12 .inventory.ReserveSerialsRequest\x1a!.inventory.ReserveSerialsResponse\x12Z\n" +
+	"\x0fImportInventory\x12!.inventory.ImportInventoryRequest\x1a\".inventory.ImportInventoryResponse(\x01\x12I\n" +
+	"\n" +
+	"GetCosting\x12\x1c.inventory.GetCostingRequest\x1a\x1d.inventory.GetCostingResponse\x12[\n" +
+	"\x10SetCostingMethod\x12\".inventory.SetCostingMethodRequest\x1a#.inventory.SetCostingMethodResponse\x12O\n" +
+	"\fGetValuation\x12\x1e.inventory.GetValuationRequest\x1a\x1f.inventory.GetValuationResponse\x12^\n" +
+	"\x11ReserveStockBatch\x12#.inventory.ReserveStockBatchRequest\x1a$.inventory.ReserveStockBatchResponse\x12p\n" +
+	"\x17FulfillReservationBatch\x12).inventory.FulfillReservationBatchRequest\x1a*.inventory.FulfillReservationBatchResponse\x12p\n" +
+	"\x17ReleaseReservationBatch\x12).inventory.ReleaseReservationBatchRequest\x1a*.inventory.ReleaseReservationBatchResponseB#Z!inventory-service/proto/inventoryb\x06proto3"
 
 var (
 	file_proto_inventory_inventory_proto_rawDescOnce sync.Once
@@ -695,46 +8809,344 @@ func file_proto_inventory_inventory_proto_rawDescGZIP() []byte {
 	return file_proto_inventory_inventory_proto_rawDescData
 }
 
-var file_proto_inventory_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_inventory_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 131)
 var file_proto_inventory_inventory_proto_goTypes = []any{
-	(*InventoryItem)(nil),              // 0: inventory.InventoryItem
-	(*GetInventoryRequest)(nil),        // 1: inventory.GetInventoryRequest
-	(*GetInventoryResponse)(nil),       // 2: inventory.GetInventoryResponse
-	(*ListInventoryRequest)(nil),       // 3: inventory.ListInventoryRequest
-	(*ListInventoryResponse)(nil),      // 4: inventory.ListInventoryResponse
-	(*UpdateStockRequest)(nil),         // 5: inventory.UpdateStockRequest
-	(*UpdateStockResponse)(nil),        // 6: inventory.UpdateStockResponse
-	(*ReserveStockRequest)(nil),        // 7: inventory.ReserveStockRequest
-	(*ReserveStockResponse)(nil),       // 8: inventory.ReserveStockResponse
-	(*FulfillReservationRequest)(nil),  // 9: inventory.FulfillReservationRequest
-	(*FulfillReservationResponse)(nil), // 10: inventory.FulfillReservationResponse
-	(*ReleaseReservationRequest)(nil),  // 11: inventory.ReleaseReservationRequest
-	(*ReleaseReservationResponse)(nil), // 12: inventory.ReleaseReservationResponse
+	(*Money)(nil),                           // 0: inventory.Money
+	(*InventoryItem)(nil),                   // 1: inventory.InventoryItem
+	(*GetInventoryRequest)(nil),             // 2: inventory.GetInventoryRequest
+	(*GetInventoryResponse)(nil),            // 3: inventory.GetInventoryResponse
+	(*ListInventoryRequest)(nil),            // 4: inventory.ListInventoryRequest
+	(*ListInventoryResponse)(nil),           // 5: inventory.ListInventoryResponse
+	(*UpdateStockRequest)(nil),              // 6: inventory.UpdateStockRequest
+	(*UpdateStockResponse)(nil),             // 7: inventory.UpdateStockResponse
+	(*AdjustStockRequest)(nil),              // 8: inventory.AdjustStockRequest
+	(*AdjustStockResponse)(nil),             // 9: inventory.AdjustStockResponse
+	(*SetReorderPointRequest)(nil),          // 10: inventory.SetReorderPointRequest
+	(*SetReorderPointResponse)(nil),         // 11: inventory.SetReorderPointResponse
+	(*ListLowStockRequest)(nil),             // 12: inventory.ListLowStockRequest
+	(*ListLowStockResponse)(nil),            // 13: inventory.ListLowStockResponse
+	(*Reservation)(nil),                     // 14: inventory.Reservation
+	(*Coordinates)(nil),                     // 15: inventory.Coordinates
+	(*ReserveStockRequest)(nil),             // 16: inventory.ReserveStockRequest
+	(*ReserveStockResponse)(nil),            // 17: inventory.ReserveStockResponse
+	(*FulfillReservationRequest)(nil),       // 18: inventory.FulfillReservationRequest
+	(*FulfillReservationResponse)(nil),      // 19: inventory.FulfillReservationResponse
+	(*ReleaseReservationRequest)(nil),       // 20: inventory.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),      // 21: inventory.ReleaseReservationResponse
+	(*GetReservationRequest)(nil),           // 22: inventory.GetReservationRequest
+	(*GetReservationResponse)(nil),          // 23: inventory.GetReservationResponse
+	(*ListReservationsRequest)(nil),         // 24: inventory.ListReservationsRequest
+	(*ListReservationsResponse)(nil),        // 25: inventory.ListReservationsResponse
+	(*ReceiveReturnRequest)(nil),            // 26: inventory.ReceiveReturnRequest
+	(*ReceiveReturnResponse)(nil),           // 27: inventory.ReceiveReturnResponse
+	(*StockLine)(nil),                       // 28: inventory.StockLine
+	(*LineFailure)(nil),                     // 29: inventory.LineFailure
+	(*BatchFailure)(nil),                    // 30: inventory.BatchFailure
+	(*ReserveStockBatchRequest)(nil),        // 31: inventory.ReserveStockBatchRequest
+	(*ReserveStockBatchResponse)(nil),       // 32: inventory.ReserveStockBatchResponse
+	(*FulfillReservationBatchRequest)(nil),  // 33: inventory.FulfillReservationBatchRequest
+	(*FulfillReservationBatchResponse)(nil), // 34: inventory.FulfillReservationBatchResponse
+	(*ReleaseReservationBatchRequest)(nil),  // 35: inventory.ReleaseReservationBatchRequest
+	(*ReleaseReservationBatchResponse)(nil), // 36: inventory.ReleaseReservationBatchResponse
+	(*Movement)(nil),                        // 37: inventory.Movement
+	(*ListMovementsRequest)(nil),            // 38: inventory.ListMovementsRequest
+	(*ListMovementsResponse)(nil),           // 39: inventory.ListMovementsResponse
+	(*Location)(nil),                        // 40: inventory.Location
+	(*ListLocationsRequest)(nil),            // 41: inventory.ListLocationsRequest
+	(*ListLocationsResponse)(nil),           // 42: inventory.ListLocationsResponse
+	(*GetLocationRequest)(nil),              // 43: inventory.GetLocationRequest
+	(*GetLocationResponse)(nil),             // 44: inventory.GetLocationResponse
+	(*CreateLocationRequest)(nil),           // 45: inventory.CreateLocationRequest
+	(*CreateLocationResponse)(nil),          // 46: inventory.CreateLocationResponse
+	(*TransferLine)(nil),                    // 47: inventory.TransferLine
+	(*Transfer)(nil),                        // 48: inventory.Transfer
+	(*ListTransfersRequest)(nil),            // 49: inventory.ListTransfersRequest
+	(*ListTransfersResponse)(nil),           // 50: inventory.ListTransfersResponse
+	(*GetTransferRequest)(nil),              // 51: inventory.GetTransferRequest
+	(*GetTransferResponse)(nil),             // 52: inventory.GetTransferResponse
+	(*CreateTransferRequest)(nil),           // 53: inventory.CreateTransferRequest
+	(*CreateTransferResponse)(nil),          // 54: inventory.CreateTransferResponse
+	(*ShipTransferRequest)(nil),             // 55: inventory.ShipTransferRequest
+	(*ShipTransferResponse)(nil),            // 56: inventory.ShipTransferResponse
+	(*ReceiveTransferRequest)(nil),          // 57: inventory.ReceiveTransferRequest
+	(*ReceiveTransferResponse)(nil),         // 58: inventory.ReceiveTransferResponse
+	(*StocktakeCount)(nil),                  // 59: inventory.StocktakeCount
+	(*StocktakeLine)(nil),                   // 60: inventory.StocktakeLine
+	(*Stocktake)(nil),                       // 61: inventory.Stocktake
+	(*ListStocktakesRequest)(nil),           // 62: inventory.ListStocktakesRequest
+	(*ListStocktakesResponse)(nil),          // 63: inventory.ListStocktakesResponse
+	(*GetStocktakeRequest)(nil),             // 64: inventory.GetStocktakeRequest
+	(*GetStocktakeResponse)(nil),            // 65: inventory.GetStocktakeResponse
+	(*CreateStocktakeRequest)(nil),          // 66: inventory.CreateStocktakeRequest
+	(*CreateStocktakeResponse)(nil),         // 67: inventory.CreateStocktakeResponse
+	(*StocktakeCountLine)(nil),              // 68: inventory.StocktakeCountLine
+	(*SubmitStocktakeCountsRequest)(nil),    // 69: inventory.SubmitStocktakeCountsRequest
+	(*SubmitStocktakeCountsResponse)(nil),   // 70: inventory.SubmitStocktakeCountsResponse
+	(*ReviewStocktakeRequest)(nil),          // 71: inventory.ReviewStocktakeRequest
+	(*ReviewStocktakeResponse)(nil),         // 72: inventory.ReviewStocktakeResponse
+	(*PostStocktakeRequest)(nil),            // 73: inventory.PostStocktakeRequest
+	(*PostStocktakeResponse)(nil),           // 74: inventory.PostStocktakeResponse
+	(*CancelStocktakeRequest)(nil),          // 75: inventory.CancelStocktakeRequest
+	(*CancelStocktakeResponse)(nil),         // 76: inventory.CancelStocktakeResponse
+	(*ShrinkageLine)(nil),                   // 77: inventory.ShrinkageLine
+	(*GetShrinkageReportRequest)(nil),       // 78: inventory.GetShrinkageReportRequest
+	(*GetShrinkageReportResponse)(nil),      // 79: inventory.GetShrinkageReportResponse
+	(*ImportRow)(nil),                       // 80: inventory.ImportRow
+	(*ImportInventoryRequest)(nil),          // 81: inventory.ImportInventoryRequest
+	(*ImportError)(nil),                     // 82: inventory.ImportError
+	(*ImportInventoryResponse)(nil),         // 83: inventory.ImportInventoryResponse
+	(*CostLayer)(nil),                       // 84: inventory.CostLayer
+	(*ProductCosting)(nil),                  // 85: inventory.ProductCosting
+	(*GetCostingRequest)(nil),               // 86: inventory.GetCostingRequest
+	(*GetCostingResponse)(nil),              // 87: inventory.GetCostingResponse
+	(*SetCostingMethodRequest)(nil),         // 88: inventory.SetCostingMethodRequest
+	(*SetCostingMethodResponse)(nil),        // 89: inventory.SetCostingMethodResponse
+	(*ValuationLine)(nil),                   // 90: inventory.ValuationLine
+	(*ValuationCategory)(nil),               // 91: inventory.ValuationCategory
+	(*GetValuationRequest)(nil),             // 92: inventory.GetValuationRequest
+	(*GetValuationResponse)(nil),            // 93: inventory.GetValuationResponse
+	(*Supplier)(nil),                        // 94: inventory.Supplier
+	(*ListSuppliersRequest)(nil),            // 95: inventory.ListSuppliersRequest
+	(*ListSuppliersResponse)(nil),           // 96: inventory.ListSuppliersResponse
+	(*GetSupplierRequest)(nil),              // 97: inventory.GetSupplierRequest
+	(*GetSupplierResponse)(nil),             // 98: inventory.GetSupplierResponse
+	(*CreateSupplierRequest)(nil),           // 99: inventory.CreateSupplierRequest
+	(*CreateSupplierResponse)(nil),          // 100: inventory.CreateSupplierResponse
+	(*PurchaseOrderLine)(nil),               // 101: inventory.PurchaseOrderLine
+	(*PurchaseOrder)(nil),                   // 102: inventory.PurchaseOrder
+	(*ReceiptLine)(nil),                     // 103: inventory.ReceiptLine
+	(*Receipt)(nil),                         // 104: inventory.Receipt
+	(*ListPurchaseOrdersRequest)(nil),       // 105: inventory.ListPurchaseOrdersRequest
+	(*ListPurchaseOrdersResponse)(nil),      // 106: inventory.ListPurchaseOrdersResponse
+	(*GetPurchaseOrderRequest)(nil),         // 107: inventory.GetPurchaseOrderRequest
+	(*GetPurchaseOrderResponse)(nil),        // 108: inventory.GetPurchaseOrderResponse
+	(*CreatePurchaseOrderRequest)(nil),      // 109: inventory.CreatePurchaseOrderRequest
+	(*CreatePurchaseOrderResponse)(nil),     // 110: inventory.CreatePurchaseOrderResponse
+	(*ReceivePurchaseOrderRequest)(nil),     // 111: inventory.ReceivePurchaseOrderRequest
+	(*ReceivePurchaseOrderResponse)(nil),    // 112: inventory.ReceivePurchaseOrderResponse
+	(*CancelPurchaseOrderRequest)(nil),      // 113: inventory.CancelPurchaseOrderRequest
+	(*CancelPurchaseOrderResponse)(nil),     // 114: inventory.CancelPurchaseOrderResponse
+	(*IncomingStock)(nil),                   // 115: inventory.IncomingStock
+	(*ListIncomingRequest)(nil),             // 116: inventory.ListIncomingRequest
+	(*ListIncomingResponse)(nil),            // 117: inventory.ListIncomingResponse
+	(*Lot)(nil),                             // 118: inventory.Lot
+	(*LotQuantity)(nil),                     // 119: inventory.LotQuantity
+	(*LotConsumption)(nil),                  // 120: inventory.LotConsumption
+	(*ListLotsRequest)(nil),                 // 121: inventory.ListLotsRequest
+	(*ListLotsResponse)(nil),                // 122: inventory.ListLotsResponse
+	(*SerialUnit)(nil),                      // 123: inventory.SerialUnit
+	(*SerialConsumption)(nil),               // 124: inventory.SerialConsumption
+	(*SetSerialTrackingRequest)(nil),        // 125: inventory.SetSerialTrackingRequest
+	(*SetSerialTrackingResponse)(nil),       // 126: inventory.SetSerialTrackingResponse
+	(*ListSerialsRequest)(nil),              // 127: inventory.ListSerialsRequest
+	(*ListSerialsResponse)(nil),             // 128: inventory.ListSerialsResponse
+	(*ReserveSerialsRequest)(nil),           // 129: inventory.ReserveSerialsRequest
+	(*ReserveSerialsResponse)(nil),          // 130: inventory.ReserveSerialsResponse
 }
 var file_proto_inventory_inventory_proto_depIdxs = []int32{
-	0,  // 0: inventory.GetInventoryResponse.item:type_name -> inventory.InventoryItem
-	0,  // 1: inventory.ListInventoryResponse.items:type_name -> inventory.InventoryItem
-	0,  // 2: inventory.UpdateStockResponse.item:type_name -> inventory.InventoryItem
-	0,  // 3: inventory.ReserveStockResponse.item:type_name -> inventory.InventoryItem
-	0,  // 4: inventory.FulfillReservationResponse.item:type_name -> inventory.InventoryItem
-	0,  // 5: inventory.ReleaseReservationResponse.item:type_name -> inventory.InventoryItem
-	1,  // 6: inventory.InventoryService.GetInventory:input_type -> inventory.GetInventoryRequest
-	3,  // 7: inventory.InventoryService.ListInventory:input_type -> inventory.ListInventoryRequest
-	5,  // 8: inventory.InventoryService.UpdateStock:input_type -> inventory.UpdateStockRequest
-	7,  // 9: inventory.InventoryService.ReserveStock:input_type -> inventory.ReserveStockRequest
-	9,  // 10: inventory.InventoryService.FulfillReservation:input_type -> inventory.FulfillReservationRequest
-	11, // 11: inventory.InventoryService.ReleaseReservation:input_type -> inventory.ReleaseReservationRequest
-	2,  // 12: inventory.InventoryService.GetInventory:output_type -> inventory.GetInventoryResponse
-	4,  // 13: inventory.InventoryService.ListInventory:output_type -> inventory.ListInventoryResponse
-	6,  // 14: inventory.InventoryService.UpdateStock:output_type -> inventory.UpdateStockResponse
-	8,  // 15: inventory.InventoryService.ReserveStock:output_type -> inventory.ReserveStockResponse
-	10, // 16: inventory.InventoryService.FulfillReservation:output_type -> inventory.FulfillReservationResponse
-	12, // 17: inventory.InventoryService.ReleaseReservation:output_type -> inventory.ReleaseReservationResponse
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	1,   // 0: inventory.InventoryItem.locations:type_name -> inventory.InventoryItem
+	1,   // 1: inventory.GetInventoryResponse.item:type_name -> inventory.InventoryItem
+	1,   // 2: inventory.ListInventoryResponse.items:type_name -> inventory.InventoryItem
+	1,   // 3: inventory.UpdateStockResponse.item:type_name -> inventory.InventoryItem
+	0,   // 4: inventory.AdjustStockRequest.unit_cost:type_name -> inventory.Money
+	1,   // 5: inventory.AdjustStockResponse.item:type_name -> inventory.InventoryItem
+	1,   // 6: inventory.SetReorderPointResponse.item:type_name -> inventory.InventoryItem
+	1,   // 7: inventory.ListLowStockResponse.items:type_name -> inventory.InventoryItem
+	0,   // 8: inventory.Reservation.cost_of_goods_sold:type_name -> inventory.Money
+	15,  // 9: inventory.ReserveStockRequest.destination:type_name -> inventory.Coordinates
+	1,   // 10: inventory.ReserveStockResponse.item:type_name -> inventory.InventoryItem
+	14,  // 11: inventory.ReserveStockResponse.reservation:type_name -> inventory.Reservation
+	1,   // 12: inventory.FulfillReservationResponse.item:type_name -> inventory.InventoryItem
+	14,  // 13: inventory.FulfillReservationResponse.reservation:type_name -> inventory.Reservation
+	120, // 14: inventory.FulfillReservationResponse.lots:type_name -> inventory.LotConsumption
+	124, // 15: inventory.FulfillReservationResponse.serials:type_name -> inventory.SerialConsumption
+	0,   // 16: inventory.FulfillReservationResponse.cost_of_goods_sold:type_name -> inventory.Money
+	1,   // 17: inventory.ReleaseReservationResponse.item:type_name -> inventory.InventoryItem
+	14,  // 18: inventory.ReleaseReservationResponse.reservation:type_name -> inventory.Reservation
+	14,  // 19: inventory.GetReservationResponse.reservation:type_name -> inventory.Reservation
+	14,  // 20: inventory.ListReservationsResponse.reservations:type_name -> inventory.Reservation
+	119, // 21: inventory.ReceiveReturnRequest.lots:type_name -> inventory.LotQuantity
+	1,   // 22: inventory.ReceiveReturnResponse.item:type_name -> inventory.InventoryItem
+	29,  // 23: inventory.BatchFailure.failures:type_name -> inventory.LineFailure
+	28,  // 24: inventory.ReserveStockBatchRequest.lines:type_name -> inventory.StockLine
+	15,  // 25: inventory.ReserveStockBatchRequest.destination:type_name -> inventory.Coordinates
+	1,   // 26: inventory.ReserveStockBatchResponse.items:type_name -> inventory.InventoryItem
+	14,  // 27: inventory.ReserveStockBatchResponse.reservations:type_name -> inventory.Reservation
+	28,  // 28: inventory.FulfillReservationBatchRequest.lines:type_name -> inventory.StockLine
+	1,   // 29: inventory.FulfillReservationBatchResponse.items:type_name -> inventory.InventoryItem
+	14,  // 30: inventory.FulfillReservationBatchResponse.reservations:type_name -> inventory.Reservation
+	120, // 31: inventory.FulfillReservationBatchResponse.lots:type_name -> inventory.LotConsumption
+	124, // 32: inventory.FulfillReservationBatchResponse.serials:type_name -> inventory.SerialConsumption
+	28,  // 33: inventory.ReleaseReservationBatchRequest.lines:type_name -> inventory.StockLine
+	1,   // 34: inventory.ReleaseReservationBatchResponse.items:type_name -> inventory.InventoryItem
+	14,  // 35: inventory.ReleaseReservationBatchResponse.reservations:type_name -> inventory.Reservation
+	0,   // 36: inventory.Movement.unit_cost:type_name -> inventory.Money
+	0,   // 37: inventory.Movement.value:type_name -> inventory.Money
+	37,  // 38: inventory.ListMovementsResponse.movements:type_name -> inventory.Movement
+	40,  // 39: inventory.ListLocationsResponse.locations:type_name -> inventory.Location
+	40,  // 40: inventory.GetLocationResponse.location:type_name -> inventory.Location
+	40,  // 41: inventory.CreateLocationResponse.location:type_name -> inventory.Location
+	119, // 42: inventory.TransferLine.lots:type_name -> inventory.LotQuantity
+	47,  // 43: inventory.Transfer.lines:type_name -> inventory.TransferLine
+	48,  // 44: inventory.ListTransfersResponse.transfers:type_name -> inventory.Transfer
+	48,  // 45: inventory.GetTransferResponse.transfer:type_name -> inventory.Transfer
+	47,  // 46: inventory.CreateTransferRequest.lines:type_name -> inventory.TransferLine
+	48,  // 47: inventory.CreateTransferResponse.transfer:type_name -> inventory.Transfer
+	48,  // 48: inventory.ShipTransferResponse.transfer:type_name -> inventory.Transfer
+	1,   // 49: inventory.ShipTransferResponse.items:type_name -> inventory.InventoryItem
+	48,  // 50: inventory.ReceiveTransferResponse.transfer:type_name -> inventory.Transfer
+	1,   // 51: inventory.ReceiveTransferResponse.items:type_name -> inventory.InventoryItem
+	59,  // 52: inventory.StocktakeLine.counts:type_name -> inventory.StocktakeCount
+	60,  // 53: inventory.Stocktake.lines:type_name -> inventory.StocktakeLine
+	61,  // 54: inventory.ListStocktakesResponse.stocktakes:type_name -> inventory.Stocktake
+	61,  // 55: inventory.GetStocktakeResponse.stocktake:type_name -> inventory.Stocktake
+	61,  // 56: inventory.CreateStocktakeResponse.stocktake:type_name -> inventory.Stocktake
+	68,  // 57: inventory.SubmitStocktakeCountsRequest.lines:type_name -> inventory.StocktakeCountLine
+	61,  // 58: inventory.SubmitStocktakeCountsResponse.stocktake:type_name -> inventory.Stocktake
+	61,  // 59: inventory.ReviewStocktakeResponse.stocktake:type_name -> inventory.Stocktake
+	61,  // 60: inventory.PostStocktakeResponse.stocktake:type_name -> inventory.Stocktake
+	1,   // 61: inventory.PostStocktakeResponse.items:type_name -> inventory.InventoryItem
+	61,  // 62: inventory.CancelStocktakeResponse.stocktake:type_name -> inventory.Stocktake
+	77,  // 63: inventory.GetShrinkageReportResponse.lines:type_name -> inventory.ShrinkageLine
+	80,  // 64: inventory.ImportInventoryRequest.rows:type_name -> inventory.ImportRow
+	82,  // 65: inventory.ImportInventoryResponse.errors:type_name -> inventory.ImportError
+	0,   // 66: inventory.CostLayer.unit_cost:type_name -> inventory.Money
+	0,   // 67: inventory.CostLayer.value:type_name -> inventory.Money
+	0,   // 68: inventory.ProductCosting.value:type_name -> inventory.Money
+	0,   // 69: inventory.ProductCosting.unit_cost:type_name -> inventory.Money
+	84,  // 70: inventory.ProductCosting.layers:type_name -> inventory.CostLayer
+	85,  // 71: inventory.GetCostingResponse.costing:type_name -> inventory.ProductCosting
+	85,  // 72: inventory.SetCostingMethodResponse.costing:type_name -> inventory.ProductCosting
+	0,   // 73: inventory.ValuationLine.value:type_name -> inventory.Money
+	0,   // 74: inventory.ValuationLine.unit_cost:type_name -> inventory.Money
+	0,   // 75: inventory.ValuationCategory.value:type_name -> inventory.Money
+	90,  // 76: inventory.GetValuationResponse.products:type_name -> inventory.ValuationLine
+	91,  // 77: inventory.GetValuationResponse.categories:type_name -> inventory.ValuationCategory
+	94,  // 78: inventory.ListSuppliersResponse.suppliers:type_name -> inventory.Supplier
+	94,  // 79: inventory.GetSupplierResponse.supplier:type_name -> inventory.Supplier
+	94,  // 80: inventory.CreateSupplierResponse.supplier:type_name -> inventory.Supplier
+	0,   // 81: inventory.PurchaseOrderLine.unit_cost:type_name -> inventory.Money
+	101, // 82: inventory.PurchaseOrder.lines:type_name -> inventory.PurchaseOrderLine
+	0,   // 83: inventory.ReceiptLine.unit_cost:type_name -> inventory.Money
+	0,   // 84: inventory.Receipt.unit_cost:type_name -> inventory.Money
+	102, // 85: inventory.ListPurchaseOrdersResponse.purchase_orders:type_name -> inventory.PurchaseOrder
+	102, // 86: inventory.GetPurchaseOrderResponse.purchase_order:type_name -> inventory.PurchaseOrder
+	104, // 87: inventory.GetPurchaseOrderResponse.receipts:type_name -> inventory.Receipt
+	101, // 88: inventory.CreatePurchaseOrderRequest.lines:type_name -> inventory.PurchaseOrderLine
+	102, // 89: inventory.CreatePurchaseOrderResponse.purchase_order:type_name -> inventory.PurchaseOrder
+	103, // 90: inventory.ReceivePurchaseOrderRequest.lines:type_name -> inventory.ReceiptLine
+	102, // 91: inventory.ReceivePurchaseOrderResponse.purchase_order:type_name -> inventory.PurchaseOrder
+	104, // 92: inventory.ReceivePurchaseOrderResponse.receipts:type_name -> inventory.Receipt
+	1,   // 93: inventory.ReceivePurchaseOrderResponse.items:type_name -> inventory.InventoryItem
+	102, // 94: inventory.CancelPurchaseOrderResponse.purchase_order:type_name -> inventory.PurchaseOrder
+	115, // 95: inventory.ListIncomingResponse.incoming:type_name -> inventory.IncomingStock
+	118, // 96: inventory.ListLotsResponse.lots:type_name -> inventory.Lot
+	1,   // 97: inventory.SetSerialTrackingResponse.item:type_name -> inventory.InventoryItem
+	123, // 98: inventory.ListSerialsResponse.serials:type_name -> inventory.SerialUnit
+	123, // 99: inventory.ReserveSerialsResponse.serials:type_name -> inventory.SerialUnit
+	2,   // 100: inventory.InventoryService.GetInventory:input_type -> inventory.GetInventoryRequest
+	4,   // 101: inventory.InventoryService.ListInventory:input_type -> inventory.ListInventoryRequest
+	6,   // 102: inventory.InventoryService.UpdateStock:input_type -> inventory.UpdateStockRequest
+	8,   // 103: inventory.InventoryService.AdjustStock:input_type -> inventory.AdjustStockRequest
+	10,  // 104: inventory.InventoryService.SetReorderPoint:input_type -> inventory.SetReorderPointRequest
+	12,  // 105: inventory.InventoryService.ListLowStock:input_type -> inventory.ListLowStockRequest
+	16,  // 106: inventory.InventoryService.ReserveStock:input_type -> inventory.ReserveStockRequest
+	18,  // 107: inventory.InventoryService.FulfillReservation:input_type -> inventory.FulfillReservationRequest
+	20,  // 108: inventory.InventoryService.ReleaseReservation:input_type -> inventory.ReleaseReservationRequest
+	26,  // 109: inventory.InventoryService.ReceiveReturn:input_type -> inventory.ReceiveReturnRequest
+	22,  // 110: inventory.InventoryService.GetReservation:input_type -> inventory.GetReservationRequest
+	24,  // 111: inventory.InventoryService.ListReservations:input_type -> inventory.ListReservationsRequest
+	38,  // 112: inventory.InventoryService.ListMovements:input_type -> inventory.ListMovementsRequest
+	41,  // 113: inventory.InventoryService.ListLocations:input_type -> inventory.ListLocationsRequest
+	43,  // 114: inventory.InventoryService.GetLocation:input_type -> inventory.GetLocationRequest
+	45,  // 115: inventory.InventoryService.CreateLocation:input_type -> inventory.CreateLocationRequest
+	49,  // 116: inventory.InventoryService.ListTransfers:input_type -> inventory.ListTransfersRequest
+	51,  // 117: inventory.InventoryService.GetTransfer:input_type -> inventory.GetTransferRequest
+	53,  // 118: inventory.InventoryService.CreateTransfer:input_type -> inventory.CreateTransferRequest
+	55,  // 119: inventory.InventoryService.ShipTransfer:input_type -> inventory.ShipTransferRequest
+	57,  // 120: inventory.InventoryService.ReceiveTransfer:input_type -> inventory.ReceiveTransferRequest
+	62,  // 121: inventory.InventoryService.ListStocktakes:input_type -> inventory.ListStocktakesRequest
+	64,  // 122: inventory.InventoryService.GetStocktake:input_type -> inventory.GetStocktakeRequest
+	66,  // 123: inventory.InventoryService.CreateStocktake:input_type -> inventory.CreateStocktakeRequest
+	69,  // 124: inventory.InventoryService.SubmitStocktakeCounts:input_type -> inventory.SubmitStocktakeCountsRequest
+	71,  // 125: inventory.InventoryService.ReviewStocktake:input_type -> inventory.ReviewStocktakeRequest
+	73,  // 126: inventory.InventoryService.PostStocktake:input_type -> inventory.PostStocktakeRequest
+	75,  // 127: inventory.InventoryService.CancelStocktake:input_type -> inventory.CancelStocktakeRequest
+	78,  // 128: inventory.InventoryService.GetShrinkageReport:input_type -> inventory.GetShrinkageReportRequest
+	95,  // 129: inventory.InventoryService.ListSuppliers:input_type -> inventory.ListSuppliersRequest
+	97,  // 130: inventory.InventoryService.GetSupplier:input_type -> inventory.GetSupplierRequest
+	99,  // 131: inventory.InventoryService.CreateSupplier:input_type -> inventory.CreateSupplierRequest
+	105, // 132: inventory.InventoryService.ListPurchaseOrders:input_type -> inventory.ListPurchaseOrdersRequest
+	107, // 133: inventory.InventoryService.GetPurchaseOrder:input_type -> inventory.GetPurchaseOrderRequest
+	109, // 134: inventory.InventoryService.CreatePurchaseOrder:input_type -> inventory.CreatePurchaseOrderRequest
+	111, // 135: inventory.InventoryService.ReceivePurchaseOrder:input_type -> inventory.ReceivePurchaseOrderRequest
+	113, // 136: inventory.InventoryService.CancelPurchaseOrder:input_type -> inventory.CancelPurchaseOrderRequest
+	116, // 137: inventory.InventoryService.ListIncoming:input_type -> inventory.ListIncomingRequest
+	121, // 138: inventory.InventoryService.ListLots:input_type -> inventory.ListLotsRequest
+	125, // 139: inventory.InventoryService.SetSerialTracking:input_type -> inventory.SetSerialTrackingRequest
+	127, // 140: inventory.InventoryService.ListSerials:input_type -> inventory.ListSerialsRequest
+	129, // 141: inventory.InventoryService.ReserveSerials:input_type -> inventory.ReserveSerialsRequest
+	81,  // 142: inventory.InventoryService.ImportInventory:input_type -> inventory.ImportInventoryRequest
+	86,  // 143: inventory.InventoryService.GetCosting:input_type -> inventory.GetCostingRequest
+	88,  // 144: inventory.InventoryService.SetCostingMethod:input_type -> inventory.SetCostingMethodRequest
+	92,  // 145: inventory.InventoryService.GetValuation:input_type -> inventory.GetValuationRequest
+	31,  // 146: inventory.InventoryService.ReserveStockBatch:input_type -> inventory.ReserveStockBatchRequest
+	33,  // 147: inventory.InventoryService.FulfillReservationBatch:input_type -> inventory.FulfillReservationBatchRequest
+	35,  // 148: inventory.InventoryService.ReleaseReservationBatch:input_type -> inventory.ReleaseReservationBatchRequest
+	3,   // 149: inventory.InventoryService.GetInventory:output_type -> inventory.GetInventoryResponse
+	5,   // 150: inventory.InventoryService.ListInventory:output_type -> inventory.ListInventoryResponse
+	7,   // 151: inventory.InventoryService.UpdateStock:output_type -> inventory.UpdateStockResponse
+	9,   // 152: inventory.InventoryService.AdjustStock:output_type -> inventory.AdjustStockResponse
+	11,  // 153: inventory.InventoryService.SetReorderPoint:output_type -> inventory.SetReorderPointResponse
+	13,  // 154: inventory.InventoryService.ListLowStock:output_type -> inventory.ListLowStockResponse
+	17,  // 155: inventory.InventoryService.ReserveStock:output_type -> inventory.ReserveStockResponse
+	19,  // 156: inventory.InventoryService.FulfillReservation:output_type -> inventory.FulfillReservationResponse
+	21,  // 157: inventory.InventoryService.ReleaseReservation:output_type -> inventory.ReleaseReservationResponse
+	27,  // 158: inventory.InventoryService.ReceiveReturn:output_type -> inventory.ReceiveReturnResponse
+	23,  // 159: inventory.InventoryService.GetReservation:output_type -> inventory.GetReservationResponse
+	25,  // 160: inventory.InventoryService.ListReservations:output_type -> inventory.ListReservationsResponse
+	39,  // 161: inventory.InventoryService.ListMovements:output_type -> inventory.ListMovementsResponse
+	42,  // 162: inventory.InventoryService.ListLocations:output_type -> inventory.ListLocationsResponse
+	44,  // 163: inventory.InventoryService.GetLocation:output_type -> inventory.GetLocationResponse
+	46,  // 164: inventory.InventoryService.CreateLocation:output_type -> inventory.CreateLocationResponse
+	50,  // 165: inventory.InventoryService.ListTransfers:output_type -> inventory.ListTransfersResponse
+	52,  // 166: inventory.InventoryService.GetTransfer:output_type -> inventory.GetTransferResponse
+	54,  // 167: inventory.InventoryService.CreateTransfer:output_type -> inventory.CreateTransferResponse
+	56,  // 168: inventory.InventoryService.ShipTransfer:output_type -> inventory.ShipTransferResponse
+	58,  // 169: inventory.InventoryService.ReceiveTransfer:output_type -> inventory.ReceiveTransferResponse
+	63,  // 170: inventory.InventoryService.ListStocktakes:output_type -> inventory.ListStocktakesResponse
+	65,  // 171: inventory.InventoryService.GetStocktake:output_type -> inventory.GetStocktakeResponse
+	67,  // 172: inventory.InventoryService.CreateStocktake:output_type -> inventory.CreateStocktakeResponse
+	70,  // 173: inventory.InventoryService.SubmitStocktakeCounts:output_type -> inventory.SubmitStocktakeCountsResponse
+	72,  // 174: inventory.InventoryService.ReviewStocktake:output_type -> inventory.ReviewStocktakeResponse
+	74,  // 175: inventory.InventoryService.PostStocktake:output_type -> inventory.PostStocktakeResponse
+	76,  // 176: inventory.InventoryService.CancelStocktake:output_type -> inventory.CancelStocktakeResponse
+	79,  // 177: inventory.InventoryService.GetShrinkageReport:output_type -> inventory.GetShrinkageReportResponse
+	96,  // 178: inventory.InventoryService.ListSuppliers:output_type -> inventory.ListSuppliersResponse
+	98,  // 179: inventory.InventoryService.GetSupplier:output_type -> inventory.GetSupplierResponse
+	100, // 180: inventory.InventoryService.CreateSupplier:output_type -> inventory.CreateSupplierResponse
+	106, // 181: inventory.InventoryService.ListPurchaseOrders:output_type -> inventory.ListPurchaseOrdersResponse
+	108, // 182: inventory.InventoryService.GetPurchaseOrder:output_type -> inventory.GetPurchaseOrderResponse
+	110, // 183: inventory.InventoryService.CreatePurchaseOrder:output_type -> inventory.CreatePurchaseOrderResponse
+	112, // 184: inventory.InventoryService.ReceivePurchaseOrder:output_type -> inventory.ReceivePurchaseOrderResponse
+	114, // 185: inventory.InventoryService.CancelPurchaseOrder:output_type -> inventory.CancelPurchaseOrderResponse
+	117, // 186: inventory.InventoryService.ListIncoming:output_type -> inventory.ListIncomingResponse
+	122, // 187: inventory.InventoryService.ListLots:output_type -> inventory.ListLotsResponse
+	126, // 188: inventory.InventoryService.SetSerialTracking:output_type -> inventory.SetSerialTrackingResponse
+	128, // 189: inventory.InventoryService.ListSerials:output_type -> inventory.ListSerialsResponse
+	130, // 190: inventory.InventoryService.ReserveSerials:output_type -> inventory.ReserveSerialsResponse
+	83,  // 191: inventory.InventoryService.ImportInventory:output_type -> inventory.ImportInventoryResponse
+	87,  // 192: inventory.InventoryService.GetCosting:output_type -> inventory.GetCostingResponse
+	89,  // 193: inventory.InventoryService.SetCostingMethod:output_type -> inventory.SetCostingMethodResponse
+	93,  // 194: inventory.InventoryService.GetValuation:output_type -> inventory.GetValuationResponse
+	32,  // 195: inventory.InventoryService.ReserveStockBatch:output_type -> inventory.ReserveStockBatchResponse
+	34,  // 196: inventory.InventoryService.FulfillReservationBatch:output_type -> inventory.FulfillReservationBatchResponse
+	36,  // 197: inventory.InventoryService.ReleaseReservationBatch:output_type -> inventory.ReleaseReservationBatchResponse
+	149, // [149:198] is the sub-list for method output_type
+	100, // [100:149] is the sub-list for method input_type
+	100, // [100:100] is the sub-list for extension type_name
+	100, // [100:100] is the sub-list for extension extendee
+	0,   // [0:100] is the sub-list for field type_name
 }
 
 func init() { file_proto_inventory_inventory_proto_init() }
@@ -748,7 +9160,7 @@ func file_proto_inventory_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_inventory_inventory_proto_rawDesc), len(file_proto_inventory_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   131,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,17 +21,86 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type OrderItem struct {
+// exact amount of money, in minor units (e.g. cents) of an ISO 4217 currency
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_orders_orders_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity          int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FulfilledQuantity int32                  `protobuf:"varint,3,opt,name=fulfilled_quantity,json=fulfilledQuantity,proto3" json:"fulfilled_quantity,omitempty"`
+	ReturnedQuantity  int32                  `protobuf:"varint,4,opt,name=returned_quantity,json=returnedQuantity,proto3" json:"returned_quantity,omitempty"`
+	// use the *_money fields, the float amounts are only kept for older clients
+	//
+	// Deprecated: Marked as deprecated in proto/orders/orders.proto.
+	UnitPrice float64 `protobuf:"fixed64,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	// Deprecated: Marked as deprecated in proto/orders/orders.proto.
+	LineTotal float64 `protobuf:"fixed64,6,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	// unit price of the product when the order was placed
+	UnitPriceMoney *Money `protobuf:"bytes,7,opt,name=unit_price_money,json=unitPriceMoney,proto3" json:"unit_price_money,omitempty"`
+	LineTotalMoney *Money `protobuf:"bytes,8,opt,name=line_total_money,json=lineTotalMoney,proto3" json:"line_total_money,omitempty"`
+	// inventory lots the fulfilled units were taken from
+	Lots []*OrderItemLot `protobuf:"bytes,9,rep,name=lots,proto3" json:"lots,omitempty"`
+	// serialized units shipped for the line; in a FulfillOrderRequest, the units to ship
+	// of a serial-tracked product, one per unit
+	SerialNumbers []string `protobuf:"bytes,10,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_proto_orders_orders_proto_msgTypes[0]
+	mi := &file_proto_orders_orders_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -43,7 +112,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[0]
+	mi := &file_proto_orders_orders_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,7 +125,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{0}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *OrderItem) GetProductId() int32 {
@@ -73,21 +142,144 @@ func (x *OrderItem) GetQuantity() int32 {
 	return 0
 }
 
-type Order struct {
+func (x *OrderItem) GetFulfilledQuantity() int32 {
+	if x != nil {
+		return x.FulfilledQuantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetReturnedQuantity() int32 {
+	if x != nil {
+		return x.ReturnedQuantity
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/orders/orders.proto.
+func (x *OrderItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/orders/orders.proto.
+func (x *OrderItem) GetLineTotal() float64 {
+	if x != nil {
+		return x.LineTotal
+	}
+	return 0
+}
+
+func (x *OrderItem) GetUnitPriceMoney() *Money {
+	if x != nil {
+		return x.UnitPriceMoney
+	}
+	return nil
+}
+
+func (x *OrderItem) GetLineTotalMoney() *Money {
+	if x != nil {
+		return x.LineTotalMoney
+	}
+	return nil
+}
+
+func (x *OrderItem) GetLots() []*OrderItemLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *OrderItem) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type OrderItemLot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CustomerId    int32                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,1,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *OrderItemLot) Reset() {
+	*x = OrderItemLot{}
+	mi := &file_proto_orders_orders_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemLot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemLot) ProtoMessage() {}
+
+func (x *OrderItemLot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemLot.ProtoReflect.Descriptor instead.
+func (*OrderItemLot) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderItemLot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *OrderItemLot) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *OrderItemLot) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Order struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId int32                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Items      []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// use total_amount_money, the float amount is only kept for older clients
+	//
+	// Deprecated: Marked as deprecated in proto/orders/orders.proto.
+	TotalAmount        float64 `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	CreatedAt          string  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CancellationReason string  `protobuf:"bytes,7,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	TotalAmountMoney   *Money  `protobuf:"bytes,8,opt,name=total_amount_money,json=totalAmountMoney,proto3" json:"total_amount_money,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_proto_orders_orders_proto_msgTypes[1]
+	mi := &file_proto_orders_orders_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -99,7 +291,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[1]
+	mi := &file_proto_orders_orders_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -112,7 +304,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{1}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Order) GetId() int32 {
@@ -143,6 +335,7 @@ func (x *Order) GetStatus() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/orders/orders.proto.
 func (x *Order) GetTotalAmount() float64 {
 	if x != nil {
 		return x.TotalAmount
@@ -157,6 +350,20 @@ func (x *Order) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Order) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Order) GetTotalAmountMoney() *Money {
+	if x != nil {
+		return x.TotalAmountMoney
+	}
+	return nil
+}
+
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -166,7 +373,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_proto_orders_orders_proto_msgTypes[2]
+	mi := &file_proto_orders_orders_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -178,7 +385,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[2]
+	mi := &file_proto_orders_orders_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,7 +398,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{2}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetOrderRequest) GetId() int32 {
@@ -210,7 +417,7 @@ type GetOrderResponse struct {
 
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_proto_orders_orders_proto_msgTypes[3]
+	mi := &file_proto_orders_orders_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +429,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[3]
+	mi := &file_proto_orders_orders_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +442,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{3}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetOrderResponse) GetOrder() *Order {
@@ -253,7 +460,7 @@ type ListOrdersRequest struct {
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_proto_orders_orders_proto_msgTypes[4]
+	mi := &file_proto_orders_orders_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -265,7 +472,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[4]
+	mi := &file_proto_orders_orders_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +485,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{4}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{6}
 }
 
 type ListOrdersResponse struct {
@@ -290,7 +497,7 @@ type ListOrdersResponse struct {
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_proto_orders_orders_proto_msgTypes[5]
+	mi := &file_proto_orders_orders_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -302,7 +509,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[5]
+	mi := &file_proto_orders_orders_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +522,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{5}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -335,7 +542,7 @@ type CreateOrderRequest struct {
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_proto_orders_orders_proto_msgTypes[6]
+	mi := &file_proto_orders_orders_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +554,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[6]
+	mi := &file_proto_orders_orders_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +567,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{6}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateOrderRequest) GetCustomerId() int32 {
@@ -386,7 +593,7 @@ type CreateOrderResponse struct {
 
 func (x *CreateOrderResponse) Reset() {
 	*x = CreateOrderResponse{}
-	mi := &file_proto_orders_orders_proto_msgTypes[7]
+	mi := &file_proto_orders_orders_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -398,7 +605,7 @@ func (x *CreateOrderResponse) String() string {
 func (*CreateOrderResponse) ProtoMessage() {}
 
 func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[7]
+	mi := &file_proto_orders_orders_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +618,7 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{7}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateOrderResponse) GetOrder() *Order {
@@ -422,15 +629,17 @@ func (x *CreateOrderResponse) GetOrder() *Order {
 }
 
 type FulfillOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// lines (product_id and quantity) to fulfill; every unfulfilled quantity when empty
+	Items         []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FulfillOrderRequest) Reset() {
 	*x = FulfillOrderRequest{}
-	mi := &file_proto_orders_orders_proto_msgTypes[8]
+	mi := &file_proto_orders_orders_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -442,7 +651,7 @@ func (x *FulfillOrderRequest) String() string {
 func (*FulfillOrderRequest) ProtoMessage() {}
 
 func (x *FulfillOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[8]
+	mi := &file_proto_orders_orders_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +664,7 @@ func (x *FulfillOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FulfillOrderRequest.ProtoReflect.Descriptor instead.
 func (*FulfillOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{8}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *FulfillOrderRequest) GetId() int32 {
@@ -465,6 +674,13 @@ func (x *FulfillOrderRequest) GetId() int32 {
 	return 0
 }
 
+func (x *FulfillOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 type FulfillOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -474,7 +690,7 @@ type FulfillOrderResponse struct {
 
 func (x *FulfillOrderResponse) Reset() {
 	*x = FulfillOrderResponse{}
-	mi := &file_proto_orders_orders_proto_msgTypes[9]
+	mi := &file_proto_orders_orders_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +702,7 @@ func (x *FulfillOrderResponse) String() string {
 func (*FulfillOrderResponse) ProtoMessage() {}
 
 func (x *FulfillOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_orders_orders_proto_msgTypes[9]
+	mi := &file_proto_orders_orders_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +715,7 @@ func (x *FulfillOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FulfillOrderResponse.ProtoReflect.Descriptor instead.
 func (*FulfillOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_orders_orders_proto_rawDescGZIP(), []int{9}
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FulfillOrderResponse) GetOrder() *Order {
@@ -509,47 +725,1600 @@ func (x *FulfillOrderResponse) GetOrder() *Order {
 	return nil
 }
 
-var File_proto_orders_orders_proto protoreflect.FileDescriptor
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_orders_orders_proto_rawDesc = "" +
-	"\n" +
-	"\x19proto/orders/orders.proto\x12\x06orders\"F\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xbb\x01\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
-	"\vcustomer_id\x18\x02 \x01(\x05R\n" +
-	"customerId\x12'\n" +
-	"\x05items\x18\x03 \x03(\v2\x11.orders.OrderItemR\x05items\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
-	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\"!\n" +
-	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"7\n" +
-	"\x10GetOrderResponse\x12#\n" +
-	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"\x13\n" +
-	"\x11ListOrdersRequest\";\n" +
-	"\x12ListOrdersResponse\x12%\n" +
-	"\x06orders\x18\x01 \x03(\v2\r.orders.OrderR\x06orders\"^\n" +
-	"\x12CreateOrderRequest\x12\x1f\n" +
-	"\vcustomer_id\x18\x01 \x01(\x05R\n" +
-	"customerId\x12'\n" +
-	"\x05items\x18\x02 \x03(\v2\x11.orders.OrderItemR\x05items\":\n" +
-	"\x13CreateOrderResponse\x12#\n" +
-	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"%\n" +
-	"\x13FulfillOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\";\n" +
-	"\x14FulfillOrderResponse\x12#\n" +
-	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order2\xa5\x02\n" +
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelOrderRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// items whose stock is still reserved after the cancellation: releases the release
+	// worker retries, and items without a recorded reservation to release manually
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *CancelOrderResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type UpdateOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type OrderStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusChange) Reset() {
+	*x = OrderStatusChange{}
+	mi := &file_proto_orders_orders_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChange) ProtoMessage() {}
+
+func (x *OrderStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChange.ProtoReflect.Descriptor instead.
+func (*OrderStatusChange) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrderStatusChange) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetOrderHistoryRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*OrderStatusChange   `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOrderHistoryResponse) GetHistory() []*OrderStatusChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type ReturnItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Restocked     int32                  `protobuf:"varint,3,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	Damaged       int32                  `protobuf:"varint,4,opt,name=damaged,proto3" json:"damaged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_proto_orders_orders_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReturnItem) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetRestocked() int32 {
+	if x != nil {
+		return x.Restocked
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetDamaged() int32 {
+	if x != nil {
+		return x.Damaged
+	}
+	return 0
+}
+
+type Return struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       int32                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*ReturnItem          `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReceivedAt    string                 `protobuf:"bytes,7,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_proto_orders_orders_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Return) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Return) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Return) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Return) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Return) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+type CreateReturnRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// lines (product_id and quantity) to return; every returnable quantity when empty
+	Items         []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason        string       `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnRequest) Reset() {
+	*x = CreateReturnRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnRequest) ProtoMessage() {}
+
+func (x *CreateReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnRequest.ProtoReflect.Descriptor instead.
+func (*CreateReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateReturnRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreateReturnRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnResponse) Reset() {
+	*x = CreateReturnResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnResponse) ProtoMessage() {}
+
+func (x *CreateReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnResponse.ProtoReflect.Descriptor instead.
+func (*CreateReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListReturnsRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+type ReceiveReturnRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OrderId  int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReturnId int32                  `protobuf:"varint,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	// quantities (product_id and quantity) that cannot be restocked
+	Damaged []*OrderItem `protobuf:"bytes,3,rep,name=damaged,proto3" json:"damaged,omitempty"`
+	// serialized units that came back and are restocked, shipped with the lines of the
+	// return; one for each restocked unit of a line that shipped serial numbers
+	SerialNumbers []string `protobuf:"bytes,4,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	// serialized units that came back damaged; one for each damaged unit of a line that
+	// shipped serial numbers
+	DamagedSerialNumbers []string `protobuf:"bytes,5,rep,name=damaged_serial_numbers,json=damagedSerialNumbers,proto3" json:"damaged_serial_numbers,omitempty"`
+	// lots the restocked units came from, shipped with the lines of the return; a line
+	// shipped from a single lot goes back to it when no lot of its product is named
+	Lots          []*ReturnLot `protobuf:"bytes,6,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnRequest) Reset() {
+	*x = ReceiveReturnRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnRequest) ProtoMessage() {}
+
+func (x *ReceiveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReceiveReturnRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetReturnId() int32 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *ReceiveReturnRequest) GetDamaged() []*OrderItem {
+	if x != nil {
+		return x.Damaged
+	}
+	return nil
+}
+
+func (x *ReceiveReturnRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiveReturnRequest) GetDamagedSerialNumbers() []string {
+	if x != nil {
+		return x.DamagedSerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiveReturnRequest) GetLots() []*ReturnLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type ReturnLot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,2,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnLot) Reset() {
+	*x = ReturnLot{}
+	mi := &file_proto_orders_orders_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnLot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnLot) ProtoMessage() {}
+
+func (x *ReturnLot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnLot.ProtoReflect.Descriptor instead.
+func (*ReturnLot) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReturnLot) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReturnLot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *ReturnLot) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReceiveReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnResponse) Reset() {
+	*x = ReceiveReturnResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnResponse) ProtoMessage() {}
+
+func (x *ReceiveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReceiveReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type LotShipment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CustomerId    int32                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ProductId     int32                  `protobuf:"varint,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,5,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotShipment) Reset() {
+	*x = LotShipment{}
+	mi := &file_proto_orders_orders_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotShipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotShipment) ProtoMessage() {}
+
+func (x *LotShipment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotShipment.ProtoReflect.Descriptor instead.
+func (*LotShipment) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LotShipment) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *LotShipment) GetCustomerId() int32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *LotShipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LotShipment) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *LotShipment) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *LotShipment) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ListLotShipmentsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	LotNumber string                 `protobuf:"bytes,1,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	// the lot of this product; lots with this number of any product when 0
+	ProductId     int32 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotShipmentsRequest) Reset() {
+	*x = ListLotShipmentsRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotShipmentsRequest) ProtoMessage() {}
+
+func (x *ListLotShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListLotShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListLotShipmentsRequest) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *ListLotShipmentsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type ListLotShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*LotShipment         `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotShipmentsResponse) Reset() {
+	*x = ListLotShipmentsResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotShipmentsResponse) ProtoMessage() {}
+
+func (x *ListLotShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListLotShipmentsResponse) GetShipments() []*LotShipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+type OrderSerial struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderSerial) Reset() {
+	*x = OrderSerial{}
+	mi := &file_proto_orders_orders_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderSerial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSerial) ProtoMessage() {}
+
+func (x *OrderSerial) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSerial.ProtoReflect.Descriptor instead.
+func (*OrderSerial) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *OrderSerial) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OrderSerial) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+type ListOrderSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderSerialsRequest) Reset() {
+	*x = ListOrderSerialsRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderSerialsRequest) ProtoMessage() {}
+
+func (x *ListOrderSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListOrderSerialsRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type ListOrderSerialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serials       []*OrderSerial         `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderSerialsResponse) Reset() {
+	*x = ListOrderSerialsResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderSerialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderSerialsResponse) ProtoMessage() {}
+
+func (x *ListOrderSerialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderSerialsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderSerialsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListOrderSerialsResponse) GetSerials() []*OrderSerial {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+type SerialShipment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CustomerId    int32                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ProductId     int32                  `protobuf:"varint,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerialShipment) Reset() {
+	*x = SerialShipment{}
+	mi := &file_proto_orders_orders_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialShipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialShipment) ProtoMessage() {}
+
+func (x *SerialShipment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialShipment.ProtoReflect.Descriptor instead.
+func (*SerialShipment) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SerialShipment) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *SerialShipment) GetCustomerId() int32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *SerialShipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SerialShipment) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SerialShipment) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+type ListSerialShipmentsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// the unit of this product; units with this serial number of any product when 0
+	ProductId     int32 `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialShipmentsRequest) Reset() {
+	*x = ListSerialShipmentsRequest{}
+	mi := &file_proto_orders_orders_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialShipmentsRequest) ProtoMessage() {}
+
+func (x *ListSerialShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListSerialShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListSerialShipmentsRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ListSerialShipmentsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type ListSerialShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*SerialShipment      `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialShipmentsResponse) Reset() {
+	*x = ListSerialShipmentsResponse{}
+	mi := &file_proto_orders_orders_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialShipmentsResponse) ProtoMessage() {}
+
+func (x *ListSerialShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_orders_orders_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListSerialShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_orders_orders_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListSerialShipmentsResponse) GetShipments() []*SerialShipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+var File_proto_orders_orders_proto protoreflect.FileDescriptor
+
+const file_proto_orders_orders_proto_rawDesc = "" +
+	"\n" +
+	"\x19proto/orders/orders.proto\x12\x06orders\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xab\x03\n" +
+	"\tOrderItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12-\n" +
+	"\x12fulfilled_quantity\x18\x03 \x01(\x05R\x11fulfilledQuantity\x12+\n" +
+	"\x11returned_quantity\x18\x04 \x01(\x05R\x10returnedQuantity\x12!\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\x01B\x02\x18\x01R\tunitPrice\x12!\n" +
+	"\n" +
+	"line_total\x18\x06 \x01(\x01B\x02\x18\x01R\tlineTotal\x127\n" +
+	"\x10unit_price_money\x18\a \x01(\v2\r.orders.MoneyR\x0eunitPriceMoney\x127\n" +
+	"\x10line_total_money\x18\b \x01(\v2\r.orders.MoneyR\x0elineTotalMoney\x12(\n" +
+	"\x04lots\x18\t \x03(\v2\x14.orders.OrderItemLotR\x04lots\x12%\n" +
+	"\x0eserial_numbers\x18\n" +
+	" \x03(\tR\rserialNumbers\"h\n" +
+	"\fOrderItemLot\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x01 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\xad\x02\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x05R\n" +
+	"customerId\x12'\n" +
+	"\x05items\x18\x03 \x03(\v2\x11.orders.OrderItemR\x05items\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\ftotal_amount\x18\x05 \x01(\x01B\x02\x18\x01R\vtotalAmount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12/\n" +
+	"\x13cancellation_reason\x18\a \x01(\tR\x12cancellationReason\x12;\n" +
+	"\x12total_amount_money\x18\b \x01(\v2\r.orders.MoneyR\x10totalAmountMoney\"!\n" +
+	"\x0fGetOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"7\n" +
+	"\x10GetOrderResponse\x12#\n" +
+	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"\x13\n" +
+	"\x11ListOrdersRequest\";\n" +
+	"\x12ListOrdersResponse\x12%\n" +
+	"\x06orders\x18\x01 \x03(\v2\r.orders.OrderR\x06orders\"^\n" +
+	"\x12CreateOrderRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x05R\n" +
+	"customerId\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.orders.OrderItemR\x05items\":\n" +
+	"\x13CreateOrderResponse\x12#\n" +
+	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"N\n" +
+	"\x13FulfillOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.orders.OrderItemR\x05items\";\n" +
+	"\x14FulfillOrderResponse\x12#\n" +
+	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"<\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"V\n" +
+	"\x13CancelOrderResponse\x12#\n" +
+	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"Z\n" +
+	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"@\n" +
+	"\x19UpdateOrderStatusResponse\x12#\n" +
+	"\x05order\x18\x01 \x01(\v2\r.orders.OrderR\x05order\"\x9e\x01\n" +
+	"\x11OrderStatusChange\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\tR\tchangedAt\"(\n" +
+	"\x16GetOrderHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"N\n" +
+	"\x17GetOrderHistoryResponse\x123\n" +
+	"\ahistory\x18\x01 \x03(\v2\x19.orders.OrderStatusChangeR\ahistory\"\x7f\n" +
+	"\n" +
+	"ReturnItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\trestocked\x18\x03 \x01(\x05R\trestocked\x12\x18\n" +
+	"\adamaged\x18\x04 \x01(\x05R\adamaged\"\xcd\x01\n" +
+	"\x06Return\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x05R\aorderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12(\n" +
+	"\x05items\x18\x05 \x03(\v2\x12.orders.ReturnItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vreceived_at\x18\a \x01(\tR\n" +
+	"receivedAt\"q\n" +
+	"\x13CreateReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.orders.OrderItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\">\n" +
+	"\x14CreateReturnResponse\x12&\n" +
+	"\x06return\x18\x01 \x01(\v2\x0e.orders.ReturnR\x06return\"/\n" +
+	"\x12ListReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\"?\n" +
+	"\x13ListReturnsResponse\x12(\n" +
+	"\areturns\x18\x01 \x03(\v2\x0e.orders.ReturnR\areturns\"\xff\x01\n" +
+	"\x14ReceiveReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x1b\n" +
+	"\treturn_id\x18\x02 \x01(\x05R\breturnId\x12+\n" +
+	"\adamaged\x18\x03 \x03(\v2\x11.orders.OrderItemR\adamaged\x12%\n" +
+	"\x0eserial_numbers\x18\x04 \x03(\tR\rserialNumbers\x124\n" +
+	"\x16damaged_serial_numbers\x18\x05 \x03(\tR\x14damagedSerialNumbers\x12%\n" +
+	"\x04lots\x18\x06 \x03(\v2\x11.orders.ReturnLotR\x04lots\"e\n" +
+	"\tReturnLot\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x02 \x01(\tR\tlotNumber\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"?\n" +
+	"\x15ReceiveReturnResponse\x12&\n" +
+	"\x06return\x18\x01 \x01(\v2\x0e.orders.ReturnR\x06return\"\xbb\x01\n" +
+	"\vLotShipment\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x05R\n" +
+	"customerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\x05R\tproductId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x05 \x01(\tR\tlotNumber\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\"W\n" +
+	"\x17ListLotShipmentsRequest\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x01 \x01(\tR\tlotNumber\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\"M\n" +
+	"\x18ListLotShipmentsResponse\x121\n" +
+	"\tshipments\x18\x01 \x03(\v2\x13.orders.LotShipmentR\tshipments\"Q\n" +
+	"\vOrderSerial\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\"4\n" +
+	"\x17ListOrderSerialsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\"I\n" +
+	"\x18ListOrderSerialsResponse\x12-\n" +
+	"\aserials\x18\x01 \x03(\v2\x13.orders.OrderSerialR\aserials\"\xa8\x01\n" +
+	"\x0eSerialShipment\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x05R\n" +
+	"customerId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\x05R\tproductId\x12#\n" +
+	"\rserial_number\x18\x05 \x01(\tR\fserialNumber\"`\n" +
+	"\x1aListSerialShipmentsRequest\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\"S\n" +
+	"\x1bListSerialShipmentsResponse\x124\n" +
+	"\tshipments\x18\x01 \x03(\v2\x16.orders.SerialShipmentR\tshipments2\x8a\b\n" +
 	"\fOrderService\x12=\n" +
 	"\bGetOrder\x12\x17.orders.GetOrderRequest\x1a\x18.orders.GetOrderResponse\x12C\n" +
 	"\n" +
 	"ListOrders\x12\x19.orders.ListOrdersRequest\x1a\x1a.orders.ListOrdersResponse\x12F\n" +
 	"\vCreateOrder\x12\x1a.orders.CreateOrderRequest\x1a\x1b.orders.CreateOrderResponse\x12I\n" +
-	"\fFulfillOrder\x12\x1b.orders.FulfillOrderRequest\x1a\x1c.orders.FulfillOrderResponseB\x1dZ\x1borders-service/proto/ordersb\x06proto3"
+	"\fFulfillOrder\x12\x1b.orders.FulfillOrderRequest\x1a\x1c.orders.FulfillOrderResponse\x12F\n" +
+	"\vCancelOrder\x12\x1a.orders.CancelOrderRequest\x1a\x1b.orders.CancelOrderResponse\x12X\n" +
+	"\x11UpdateOrderStatus\x12 .orders.UpdateOrderStatusRequest\x1a!.orders.UpdateOrderStatusResponse\x12R\n" +
+	"\x0fGetOrderHistory\x12\x1e.orders.GetOrderHistoryRequest\x1a\x1f.orders.GetOrderHistoryResponse\x12I\n" +
+	"\fCreateReturn\x12\x1b.orders.CreateReturnRequest\x1a\x1c.orders.CreateReturnResponse\x12F\n" +
+	"\vListReturns\x12\x1a.orders.ListReturnsRequest\x1a\x1b.orders.ListReturnsResponse\x12L\n" +
+	"\rReceiveReturn\x12\x1c.orders.ReceiveReturnRequest\x1a\x1d.orders.ReceiveReturnResponse\x12U\n" +
+	"\x10ListLotShipments\x12\x1f.orders.ListLotShipmentsRequest\x1a .orders.ListLotShipmentsResponse\x12U\n" +
+	"\x10ListOrderSerials\x12\x1f.orders.ListOrderSerialsRequest\x1a .orders.ListOrderSerialsResponse\x12^\n" +
+	"\x13ListSerialShipments\x12\".orders.ListSerialShipmentsRequest\x1a#.orders.ListSerialShipmentsResponseB\x1dZ\x1borders-service/proto/ordersb\x06proto3"
 
 var (
 	file_proto_orders_orders_proto_rawDescOnce sync.Once
@@ -563,39 +2332,102 @@ func file_proto_orders_orders_proto_rawDescGZIP() []byte {
 	return file_proto_orders_orders_proto_rawDescData
 }
 
-var file_proto_orders_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_orders_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_proto_orders_orders_proto_goTypes = []any{
-	(*OrderItem)(nil),            // 0: orders.OrderItem
-	(*Order)(nil),                // 1: orders.Order
-	(*GetOrderRequest)(nil),      // 2: orders.GetOrderRequest
-	(*GetOrderResponse)(nil),     // 3: orders.GetOrderResponse
-	(*ListOrdersRequest)(nil),    // 4: orders.ListOrdersRequest
-	(*ListOrdersResponse)(nil),   // 5: orders.ListOrdersResponse
-	(*CreateOrderRequest)(nil),   // 6: orders.CreateOrderRequest
-	(*CreateOrderResponse)(nil),  // 7: orders.CreateOrderResponse
-	(*FulfillOrderRequest)(nil),  // 8: orders.FulfillOrderRequest
-	(*FulfillOrderResponse)(nil), // 9: orders.FulfillOrderResponse
+	(*Money)(nil),                       // 0: orders.Money
+	(*OrderItem)(nil),                   // 1: orders.OrderItem
+	(*OrderItemLot)(nil),                // 2: orders.OrderItemLot
+	(*Order)(nil),                       // 3: orders.Order
+	(*GetOrderRequest)(nil),             // 4: orders.GetOrderRequest
+	(*GetOrderResponse)(nil),            // 5: orders.GetOrderResponse
+	(*ListOrdersRequest)(nil),           // 6: orders.ListOrdersRequest
+	(*ListOrdersResponse)(nil),          // 7: orders.ListOrdersResponse
+	(*CreateOrderRequest)(nil),          // 8: orders.CreateOrderRequest
+	(*CreateOrderResponse)(nil),         // 9: orders.CreateOrderResponse
+	(*FulfillOrderRequest)(nil),         // 10: orders.FulfillOrderRequest
+	(*FulfillOrderResponse)(nil),        // 11: orders.FulfillOrderResponse
+	(*CancelOrderRequest)(nil),          // 12: orders.CancelOrderRequest
+	(*CancelOrderResponse)(nil),         // 13: orders.CancelOrderResponse
+	(*UpdateOrderStatusRequest)(nil),    // 14: orders.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),   // 15: orders.UpdateOrderStatusResponse
+	(*OrderStatusChange)(nil),           // 16: orders.OrderStatusChange
+	(*GetOrderHistoryRequest)(nil),      // 17: orders.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),     // 18: orders.GetOrderHistoryResponse
+	(*ReturnItem)(nil),                  // 19: orders.ReturnItem
+	(*Return)(nil),                      // 20: orders.Return
+	(*CreateReturnRequest)(nil),         // 21: orders.CreateReturnRequest
+	(*CreateReturnResponse)(nil),        // 22: orders.CreateReturnResponse
+	(*ListReturnsRequest)(nil),          // 23: orders.ListReturnsRequest
+	(*ListReturnsResponse)(nil),         // 24: orders.ListReturnsResponse
+	(*ReceiveReturnRequest)(nil),        // 25: orders.ReceiveReturnRequest
+	(*ReturnLot)(nil),                   // 26: orders.ReturnLot
+	(*ReceiveReturnResponse)(nil),       // 27: orders.ReceiveReturnResponse
+	(*LotShipment)(nil),                 // 28: orders.LotShipment
+	(*ListLotShipmentsRequest)(nil),     // 29: orders.ListLotShipmentsRequest
+	(*ListLotShipmentsResponse)(nil),    // 30: orders.ListLotShipmentsResponse
+	(*OrderSerial)(nil),                 // 31: orders.OrderSerial
+	(*ListOrderSerialsRequest)(nil),     // 32: orders.ListOrderSerialsRequest
+	(*ListOrderSerialsResponse)(nil),    // 33: orders.ListOrderSerialsResponse
+	(*SerialShipment)(nil),              // 34: orders.SerialShipment
+	(*ListSerialShipmentsRequest)(nil),  // 35: orders.ListSerialShipmentsRequest
+	(*ListSerialShipmentsResponse)(nil), // 36: orders.ListSerialShipmentsResponse
 }
 var file_proto_orders_orders_proto_depIdxs = []int32{
-	0,  // 0: orders.Order.items:type_name -> orders.OrderItem
-	1,  // 1: orders.GetOrderResponse.order:type_name -> orders.Order
-	1,  // 2: orders.ListOrdersResponse.orders:type_name -> orders.Order
-	0,  // 3: orders.CreateOrderRequest.items:type_name -> orders.OrderItem
-	1,  // 4: orders.CreateOrderResponse.order:type_name -> orders.Order
-	1,  // 5: orders.FulfillOrderResponse.order:type_name -> orders.Order
-	2,  // 6: orders.OrderService.GetOrder:input_type -> orders.GetOrderRequest
-	4,  // 7: orders.OrderService.ListOrders:input_type -> orders.ListOrdersRequest
-	6,  // 8: orders.OrderService.CreateOrder:input_type -> orders.CreateOrderRequest
-	8,  // 9: orders.OrderService.FulfillOrder:input_type -> orders.FulfillOrderRequest
-	3,  // 10: orders.OrderService.GetOrder:output_type -> orders.GetOrderResponse
-	5,  // 11: orders.OrderService.ListOrders:output_type -> orders.ListOrdersResponse
-	7,  // 12: orders.OrderService.CreateOrder:output_type -> orders.CreateOrderResponse
-	9,  // 13: orders.OrderService.FulfillOrder:output_type -> orders.FulfillOrderResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	0,  // 0: orders.OrderItem.unit_price_money:type_name -> orders.Money
+	0,  // 1: orders.OrderItem.line_total_money:type_name -> orders.Money
+	2,  // 2: orders.OrderItem.lots:type_name -> orders.OrderItemLot
+	1,  // 3: orders.Order.items:type_name -> orders.OrderItem
+	0,  // 4: orders.Order.total_amount_money:type_name -> orders.Money
+	3,  // 5: orders.GetOrderResponse.order:type_name -> orders.Order
+	3,  // 6: orders.ListOrdersResponse.orders:type_name -> orders.Order
+	1,  // 7: orders.CreateOrderRequest.items:type_name -> orders.OrderItem
+	3,  // 8: orders.CreateOrderResponse.order:type_name -> orders.Order
+	1,  // 9: orders.FulfillOrderRequest.items:type_name -> orders.OrderItem
+	3,  // 10: orders.FulfillOrderResponse.order:type_name -> orders.Order
+	3,  // 11: orders.CancelOrderResponse.order:type_name -> orders.Order
+	3,  // 12: orders.UpdateOrderStatusResponse.order:type_name -> orders.Order
+	16, // 13: orders.GetOrderHistoryResponse.history:type_name -> orders.OrderStatusChange
+	19, // 14: orders.Return.items:type_name -> orders.ReturnItem
+	1,  // 15: orders.CreateReturnRequest.items:type_name -> orders.OrderItem
+	20, // 16: orders.CreateReturnResponse.return:type_name -> orders.Return
+	20, // 17: orders.ListReturnsResponse.returns:type_name -> orders.Return
+	1,  // 18: orders.ReceiveReturnRequest.damaged:type_name -> orders.OrderItem
+	26, // 19: orders.ReceiveReturnRequest.lots:type_name -> orders.ReturnLot
+	20, // 20: orders.ReceiveReturnResponse.return:type_name -> orders.Return
+	28, // 21: orders.ListLotShipmentsResponse.shipments:type_name -> orders.LotShipment
+	31, // 22: orders.ListOrderSerialsResponse.serials:type_name -> orders.OrderSerial
+	34, // 23: orders.ListSerialShipmentsResponse.shipments:type_name -> orders.SerialShipment
+	4,  // 24: orders.OrderService.GetOrder:input_type -> orders.GetOrderRequest
+	6,  // 25: orders.OrderService.ListOrders:input_type -> orders.ListOrdersRequest
+	8,  // 26: orders.OrderService.CreateOrder:input_type -> orders.CreateOrderRequest
+	10, // 27: orders.OrderService.FulfillOrder:input_type -> orders.FulfillOrderRequest
+	12, // 28: orders.OrderService.CancelOrder:input_type -> orders.CancelOrderRequest
+	14, // 29: orders.OrderService.UpdateOrderStatus:input_type -> orders.UpdateOrderStatusRequest
+	17, // 30: orders.OrderService.GetOrderHistory:input_type -> orders.GetOrderHistoryRequest
+	21, // 31: orders.OrderService.CreateReturn:input_type -> orders.CreateReturnRequest
+	23, // 32: orders.OrderService.ListReturns:input_type -> orders.ListReturnsRequest
+	25, // 33: orders.OrderService.ReceiveReturn:input_type -> orders.ReceiveReturnRequest
+	29, // 34: orders.OrderService.ListLotShipments:input_type -> orders.ListLotShipmentsRequest
+	32, // 35: orders.OrderService.ListOrderSerials:input_type -> orders.ListOrderSerialsRequest
+	35, // 36: orders.OrderService.ListSerialShipments:input_type -> orders.ListSerialShipmentsRequest
+	5,  // 37: orders.OrderService.GetOrder:output_type -> orders.GetOrderResponse
+	7,  // 38: orders.OrderService.ListOrders:output_type -> orders.ListOrdersResponse
+	9,  // 39: orders.OrderService.CreateOrder:output_type -> orders.CreateOrderResponse
+	11, // 40: orders.OrderService.FulfillOrder:output_type -> orders.FulfillOrderResponse
+	13, // 41: orders.OrderService.CancelOrder:output_type -> orders.CancelOrderResponse
+	15, // 42: orders.OrderService.UpdateOrderStatus:output_type -> orders.UpdateOrderStatusResponse
+	18, // 43: orders.OrderService.GetOrderHistory:output_type -> orders.GetOrderHistoryResponse
+	22, // 44: orders.OrderService.CreateReturn:output_type -> orders.CreateReturnResponse
+	24, // 45: orders.OrderService.ListReturns:output_type -> orders.ListReturnsResponse
+	27, // 46: orders.OrderService.ReceiveReturn:output_type -> orders.ReceiveReturnResponse
+	30, // 47: orders.OrderService.ListLotShipments:output_type -> orders.ListLotShipmentsResponse
+	33, // 48: orders.OrderService.ListOrderSerials:output_type -> orders.ListOrderSerialsResponse
+	36, // 49: orders.OrderService.ListSerialShipments:output_type -> orders.ListSerialShipmentsResponse
+	37, // [37:50] is the sub-list for method output_type
+	24, // [24:37] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_proto_orders_orders_proto_init() }
@@ -609,7 +2441,7 @@ func file_proto_orders_orders_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_orders_orders_proto_rawDesc), len(file_proto_orders_orders_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -131,12 +131,16 @@ func main() {
 			w.WriteHeader(http.StatusOK)
 		})).ServeHTTP(w, r)
 	})
+	// GET several products by productId (?ids=1,2,3), matched before GET all products
+	r.Handle("/products", products_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_ByProductIDs))).Methods(http.MethodGet).Queries("ids", "{ids}")
 	// GET all products
 	r.Handle("/products", products_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_All))).Methods(http.MethodGet)
 	// GET product by productId
 	r.Handle("/products/{productId}", products_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Get_ByProductID))).Methods(http.MethodGet)
 	// POST create product
 	r.Handle("/products", products_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Create_Product))).Methods(http.MethodPost)
+	// PUT update product (If-Match: "<version>" to reject stale writes)
+	r.Handle("/products/{productId}", products_handler_http.AddCORSHeaders(http.HandlerFunc(handler.Update_Product))).Methods(http.MethodPut)
 	// Health check endpoint
 	r.Handle("/health", products_handler_http.AddCORSHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
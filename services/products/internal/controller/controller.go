@@ -2,14 +2,20 @@ package products_controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
+	internal "products-service/internal"
 	dmodel "products-service/pkg"
+	"shared/money"
 )
 
 type if_repo_inventory interface {
 	Get_All(_ context.Context) ([]*dmodel.Product, error)
 	Get_ByProductID(_ context.Context, productID int) (*dmodel.Product, error)
+	Get_ByProductIDs(_ context.Context, productIDs []int) ([]*dmodel.Product, error)
 	Create_Product(_ context.Context, product *dmodel.Product) (*dmodel.Product, error)
+	Update_Product(_ context.Context, product *dmodel.Product, expectedVersion int) (*dmodel.Product, error)
 }
 
 type Controller_Products struct {
@@ -42,7 +48,56 @@ func (c *Controller_Products) Get_ByProductID(ctx context.Context, productID int
 	return res, nil
 }
 
+// Get_ByProductIDs looks up several products at once
+// the IDs without a product are returned as missing, in the order they were requested
+func (c *Controller_Products) Get_ByProductIDs(ctx context.Context, productIDs []int) ([]*dmodel.Product, []int, error) {
+	if len(productIDs) == 0 {
+		return nil, nil, internal.ErrNoProductIDs
+	}
+
+	res, err := c.repo.Get_ByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[int]bool, len(res))
+	for _, product := range res {
+		found[product.ID] = true
+	}
+
+	var missing []int
+	for _, id := range productIDs {
+		if !found[id] && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+
+	return res, missing, nil
+}
+
+// a price without currency is left for the caller to default (creation) or to keep in the
+// stored currency (update)
+func validatePrice(product *dmodel.Product) error {
+	if product.Currency != "" && !money.ValidCurrency(product.Currency) {
+		return fmt.Errorf("%w: unknown currency %q", internal.ErrInvalidPrice, product.Currency)
+	}
+	if product.Price.IsNegative() {
+		return fmt.Errorf("%w: price cannot be negative", internal.ErrInvalidPrice)
+	}
+	product.Price.Currency = product.Currency
+
+	return nil
+}
+
+// Create_Product stores a new product; a price without currency is in the default currency
 func (c *Controller_Products) Create_Product(ctx context.Context, product *dmodel.Product) (*dmodel.Product, error) {
+	if product.Currency == "" {
+		product.Currency = money.DefaultCurrency
+	}
+	if err := validatePrice(product); err != nil {
+		return nil, err
+	}
+
 	res, err := c.repo.Create_Product(ctx, product)
 
 	if err != nil {
@@ -51,3 +106,24 @@ func (c *Controller_Products) Create_Product(ctx context.Context, product *dmode
 
 	return res, nil
 }
+
+// Update_Product replaces the fields of a product; a price without currency keeps the
+// product's currency
+// with a non zero expectedVersion the update fails with ErrVersionMismatch when the
+// product was changed since that version, instead of overwriting the change
+func (c *Controller_Products) Update_Product(ctx context.Context, product *dmodel.Product, expectedVersion int) (*dmodel.Product, error) {
+	if expectedVersion < 0 {
+		return nil, internal.ErrVersionMismatch
+	}
+	if err := validatePrice(product); err != nil {
+		return nil, err
+	}
+
+	res, err := c.repo.Update_Product(ctx, product, expectedVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
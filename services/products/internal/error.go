@@ -4,4 +4,8 @@ import "errors"
 
 var (
 	ErrItemNotFound = errors.New("item (product) not found")
+	ErrInvalidPrice = errors.New("invalid product price")
+	ErrNoProductIDs = errors.New("no product IDs requested")
+
+	ErrVersionMismatch = errors.New("product was modified since the expected version")
 )
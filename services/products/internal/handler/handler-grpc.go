@@ -2,6 +2,7 @@ package products_handler_http
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -10,6 +11,7 @@ import (
 	products_controller "products-service/internal/controller"
 	products_dmodel "products-service/pkg"
 	pb "products-service/proto/products"
+	"shared/money"
 )
 
 type Handler_Products_GRPC struct {
@@ -33,13 +35,7 @@ func (h *Handler_Products_GRPC) GetProduct(ctx context.Context, req *pb.GetProdu
 	}
 
 	return &pb.GetProductResponse{
-		Product: &pb.Product{
-			Id:          int32(product.ID),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Category:    product.Category,
-		},
+		Product: toPBProduct(product),
 	}, nil
 }
 
@@ -51,13 +47,7 @@ func (h *Handler_Products_GRPC) ListProducts(ctx context.Context, req *pb.ListPr
 
 	pbProducts := make([]*pb.Product, len(products))
 	for i, product := range products {
-		pbProducts[i] = &pb.Product{
-			Id:          int32(product.ID),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Category:    product.Category,
-		}
+		pbProducts[i] = toPBProduct(product)
 	}
 
 	return &pb.ListProductsResponse{
@@ -65,26 +55,110 @@ func (h *Handler_Products_GRPC) ListProducts(ctx context.Context, req *pb.ListPr
 	}, nil
 }
 
+func (h *Handler_Products_GRPC) BatchGetProducts(ctx context.Context, req *pb.BatchGetProductsRequest) (*pb.BatchGetProductsResponse, error) {
+	productIDs := make([]int, len(req.Ids))
+	for i, id := range req.Ids {
+		productIDs[i] = int(id)
+	}
+
+	products, missing, err := h.controller.Get_ByProductIDs(ctx, productIDs)
+	if err != nil {
+		if errors.Is(err, internal.ErrNoProductIDs) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	pbProducts := make([]*pb.Product, len(products))
+	for i, product := range products {
+		pbProducts[i] = toPBProduct(product)
+	}
+
+	missingIDs := make([]int32, len(missing))
+	for i, id := range missing {
+		missingIDs[i] = int32(id)
+	}
+
+	return &pb.BatchGetProductsResponse{
+		Products:   pbProducts,
+		MissingIds: missingIDs,
+	}, nil
+}
+
 func (h *Handler_Products_GRPC) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
 	product := &products_dmodel.Product{
 		Name:        req.Name,
 		Description: req.Description,
-		Price:       req.Price,
 		Category:    req.Category,
 	}
+	// clients that predate price_money only send the float price
+	if req.PriceMoney != nil {
+		product.Price = money.New(req.PriceMoney.Amount, req.PriceMoney.Currency)
+		product.Currency = req.PriceMoney.Currency
+	} else {
+		product.Price = money.FromFloat(req.Price, money.DefaultCurrency)
+	}
 
 	createdProduct, err := h.controller.Create_Product(ctx, product)
+	if errors.Is(err, internal.ErrInvalidPrice) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal server error")
 	}
 
 	return &pb.CreateProductResponse{
-		Product: &pb.Product{
-			Id:          int32(createdProduct.ID),
-			Name:        createdProduct.Name,
-			Description: createdProduct.Description,
-			Price:       createdProduct.Price,
-			Category:    createdProduct.Category,
-		},
+		Product: toPBProduct(createdProduct),
+	}, nil
+}
+
+func (h *Handler_Products_GRPC) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	if req.PriceMoney == nil {
+		return nil, status.Error(codes.InvalidArgument, "price_money is required")
+	}
+	product := &products_dmodel.Product{
+		ID:          int(req.Id),
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    req.Category,
+		Price:       money.New(req.PriceMoney.Amount, req.PriceMoney.Currency),
+		Currency:    req.PriceMoney.Currency,
+	}
+
+	updatedProduct, err := h.controller.Update_Product(ctx, product, int(req.ExpectedVersion))
+	if errors.Is(err, internal.ErrInvalidPrice) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err == internal.ErrItemNotFound {
+		return nil, status.Errorf(codes.NotFound, "product not found")
+	}
+	if err == internal.ErrVersionMismatch {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &pb.UpdateProductResponse{
+		Product: toPBProduct(updatedProduct),
 	}, nil
 }
+
+func toPBProduct(product *products_dmodel.Product) *pb.Product {
+	return &pb.Product{
+		Id:          int32(product.ID),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price.Float64(),
+		PriceMoney:  toPBMoney(product.Price),
+		Category:    product.Category,
+		Version:     int32(product.Version),
+	}
+}
+
+func toPBMoney(m money.Money) *pb.Money {
+	return &pb.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
@@ -2,11 +2,16 @@ package products_handler_http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
+	internal "products-service/internal"
 	products_controller "products-service/internal/controller"
 	dmodel "products-service/pkg"
 )
@@ -15,7 +20,8 @@ func AddCORSHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, If-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 		// CORS preflight request (OPTIONS) handling
 		if r.Method == http.MethodOptions {
@@ -27,6 +33,25 @@ func AddCORSHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// entity tag of a version of a product
+func etag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// version required by the If-Match header of a request, 0 when any version matches
+// (no header, or "*")
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match header %q", value)
+	}
+	return version, nil
+}
+
 type Handler_Products struct {
 	controller *products_controller.Controller_Products
 }
@@ -75,6 +100,7 @@ func (h *Handler_Products) Get_ByProductID(w http.ResponseWriter, r *http.Reques
 		http.Error(w, "Item (product) not found", http.StatusNotFound)
 		return
 	}
+	w.Header().Set("ETag", etag(item.Version))
 
 	// encoding the response to JSON
 	err = json.NewEncoder(w).Encode(item)
@@ -84,6 +110,38 @@ func (h *Handler_Products) Get_ByProductID(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// Get_ByProductIDs returns the products listed in the ids query parameter (e.g. ?ids=1,2,3)
+// IDs without a product are left out of the response
+func (h *Handler_Products) Get_ByProductIDs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var productIDs []int
+	for _, field := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		productID, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			http.Error(w, "Invalid product ID", http.StatusBadRequest)
+			return
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	// getting the controller's response
+	items, _, err := h.controller.Get_ByProductIDs(ctx, productIDs)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(items)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *Handler_Products) Create_Product(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -97,6 +155,10 @@ func (h *Handler_Products) Create_Product(w http.ResponseWriter, r *http.Request
 
 	// getting the controller's response
 	createdItem, err := h.controller.Create_Product(ctx, &product)
+	if errors.Is(err, internal.ErrInvalidPrice) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
@@ -111,3 +173,60 @@ func (h *Handler_Products) Create_Product(w http.ResponseWriter, r *http.Request
 		return
 	}
 }
+
+// Update_Product replaces the fields of a product
+// with an If-Match header the update is rejected with 412 when the product changed since
+// that version (the ETag returned by GET), so concurrent edits are not silently lost
+func (h *Handler_Products) Update_Product(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	productId, err := strconv.Atoi(vars["productId"])
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		http.Error(w, "Invalid If-Match header", http.StatusBadRequest)
+		return
+	}
+
+	var product dmodel.Product
+	if err := json.NewDecoder(r.Body).Decode(&product); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	product.ID = productId
+
+	// getting the controller's response
+	updatedItem, err := h.controller.Update_Product(ctx, &product, expectedVersion)
+	if errors.Is(err, internal.ErrInvalidPrice) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err == internal.ErrItemNotFound {
+		http.Error(w, "Item (product) not found", http.StatusNotFound)
+		return
+	}
+	if err == internal.ErrVersionMismatch {
+		http.Error(w, "Product was modified, fetch it again", http.StatusPreconditionFailed)
+		return
+	}
+	if err != nil {
+		log.Printf("Error updating product: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", etag(updatedItem.Version))
+
+	// encoding the response to JSON
+	err = json.NewEncoder(w).Encode(updatedItem)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
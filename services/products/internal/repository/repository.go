@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"products-service/internal"
 	dmodel "products-service/pkg"
+
+	"github.com/lib/pq"
 )
 
 // -------------------------------------------------------------------
@@ -31,7 +33,7 @@ func New(db *sql.DB) *DataRepo_Products {
 
 // retrieving all items
 func (dr *DataRepo_Products) Get_All(ctx context.Context) ([]*dmodel.Product, error) {
-	query := `SELECT id, name, description, price, category FROM products`
+	query := `SELECT id, name, description, price, currency, category, version FROM products`
 	rows, err := dr.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -41,9 +43,10 @@ func (dr *DataRepo_Products) Get_All(ctx context.Context) ([]*dmodel.Product, er
 	var products []*dmodel.Product
 	for rows.Next() {
 		var p dmodel.Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Category); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Currency, &p.Category, &p.Version); err != nil {
 			return nil, err
 		}
+		p.Price.Currency = p.Currency
 		products = append(products, &p)
 	}
 
@@ -52,28 +55,83 @@ func (dr *DataRepo_Products) Get_All(ctx context.Context) ([]*dmodel.Product, er
 
 // retrieving item by ID
 func (dr *DataRepo_Products) Get_ByProductID(ctx context.Context, id int) (*dmodel.Product, error) {
-	query := `SELECT id, name, description, price, category FROM products WHERE id = $1`
+	query := `SELECT id, name, description, price, currency, category, version FROM products WHERE id = $1`
 	var p dmodel.Product
 
-	err := dr.db.QueryRowContext(ctx, query, id).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Category)
+	err := dr.db.QueryRowContext(ctx, query, id).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Currency, &p.Category, &p.Version)
 	if err == sql.ErrNoRows {
 		return nil, internal.ErrItemNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+	p.Price.Currency = p.Currency
 
 	return &p, nil
 }
 
+// retrieving the items with the given IDs in a single query
+// IDs without a product are left out of the result
+func (dr *DataRepo_Products) Get_ByProductIDs(ctx context.Context, ids []int) ([]*dmodel.Product, error) {
+	query := `SELECT id, name, description, price, currency, category, version FROM products WHERE id = ANY($1) ORDER BY id`
+
+	productIDs := make([]int64, len(ids))
+	for i, id := range ids {
+		productIDs[i] = int64(id)
+	}
+
+	rows, err := dr.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*dmodel.Product{}
+	for rows.Next() {
+		var p dmodel.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Currency, &p.Category, &p.Version); err != nil {
+			return nil, err
+		}
+		p.Price.Currency = p.Currency
+		products = append(products, &p)
+	}
+
+	return products, rows.Err()
+}
+
 // creating a new product
 func (dr *DataRepo_Products) Create_Product(ctx context.Context, product *dmodel.Product) (*dmodel.Product, error) {
-	query := `INSERT INTO products (name, description, price, category) VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO products (name, description, price, currency, category) VALUES ($1, $2, $3, $4, $5) RETURNING id, version`
+
+	err := dr.db.QueryRowContext(ctx, query, product.Name, product.Description, product.Price, product.Currency, product.Category).Scan(&product.ID, &product.Version)
+	if err != nil {
+		return nil, err
+	}
 
-	err := dr.db.QueryRowContext(ctx, query, product.Name, product.Description, product.Price, product.Category).Scan(&product.ID)
+	return product, nil
+}
+
+// updating a product, only when it is still at the expected version (any version when 0)
+func (dr *DataRepo_Products) Update_Product(ctx context.Context, product *dmodel.Product, expectedVersion int) (*dmodel.Product, error) {
+	query := `
+		UPDATE products
+		SET name = $1, description = $2, price = $3, currency = COALESCE(NULLIF($4, ''), currency), category = $5, version = version + 1
+		WHERE id = $6 AND ($7 = 0 OR version = $7)
+		RETURNING currency, version`
+
+	err := dr.db.QueryRowContext(ctx, query, product.Name, product.Description, product.Price, product.Currency, product.Category,
+		product.ID, expectedVersion).Scan(&product.Currency, &product.Version)
+	if err == sql.ErrNoRows {
+		// either there is no such product or it moved past the expected version
+		if _, err := dr.Get_ByProductID(ctx, product.ID); err != nil {
+			return nil, err
+		}
+		return nil, internal.ErrVersionMismatch
+	}
 	if err != nil {
 		return nil, err
 	}
+	product.Price.Currency = product.Currency
 
 	return product, nil
 }
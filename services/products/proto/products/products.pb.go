@@ -21,20 +21,79 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Product struct {
+// exact amount of money, in minor units (e.g. cents) of an ISO 4217 currency
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_products_products_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_products_products_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_products_products_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Product struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// use price_money, the float price is only kept for older clients
+	//
+	// Deprecated: Marked as deprecated in proto/products/products.proto.
+	Price      float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Category   string  `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	PriceMoney *Money  `protobuf:"bytes,6,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
+	// incremented by every update of the product
+	Version       int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
 	*x = Product{}
-	mi := &file_proto_products_products_proto_msgTypes[0]
+	mi := &file_proto_products_products_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +105,7 @@ func (x *Product) String() string {
 func (*Product) ProtoMessage() {}
 
 func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[0]
+	mi := &file_proto_products_products_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +118,7 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Product.ProtoReflect.Descriptor instead.
 func (*Product) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{0}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Product) GetId() int32 {
@@ -83,6 +142,7 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/products/products.proto.
 func (x *Product) GetPrice() float64 {
 	if x != nil {
 		return x.Price
@@ -97,6 +157,20 @@ func (x *Product) GetCategory() string {
 	return ""
 }
 
+func (x *Product) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
+func (x *Product) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type GetProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -106,7 +180,7 @@ type GetProductRequest struct {
 
 func (x *GetProductRequest) Reset() {
 	*x = GetProductRequest{}
-	mi := &file_proto_products_products_proto_msgTypes[1]
+	mi := &file_proto_products_products_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -118,7 +192,7 @@ func (x *GetProductRequest) String() string {
 func (*GetProductRequest) ProtoMessage() {}
 
 func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[1]
+	mi := &file_proto_products_products_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -131,7 +205,7 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{1}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetProductRequest) GetId() int32 {
@@ -150,7 +224,7 @@ type GetProductResponse struct {
 
 func (x *GetProductResponse) Reset() {
 	*x = GetProductResponse{}
-	mi := &file_proto_products_products_proto_msgTypes[2]
+	mi := &file_proto_products_products_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -162,7 +236,7 @@ func (x *GetProductResponse) String() string {
 func (*GetProductResponse) ProtoMessage() {}
 
 func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[2]
+	mi := &file_proto_products_products_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +249,7 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
 func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{2}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetProductResponse) GetProduct() *Product {
@@ -193,7 +267,7 @@ type ListProductsRequest struct {
 
 func (x *ListProductsRequest) Reset() {
 	*x = ListProductsRequest{}
-	mi := &file_proto_products_products_proto_msgTypes[3]
+	mi := &file_proto_products_products_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -205,7 +279,7 @@ func (x *ListProductsRequest) String() string {
 func (*ListProductsRequest) ProtoMessage() {}
 
 func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[3]
+	mi := &file_proto_products_products_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +292,7 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
 func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{3}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{4}
 }
 
 type ListProductsResponse struct {
@@ -230,7 +304,7 @@ type ListProductsResponse struct {
 
 func (x *ListProductsResponse) Reset() {
 	*x = ListProductsResponse{}
-	mi := &file_proto_products_products_proto_msgTypes[4]
+	mi := &file_proto_products_products_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +316,7 @@ func (x *ListProductsResponse) String() string {
 func (*ListProductsResponse) ProtoMessage() {}
 
 func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[4]
+	mi := &file_proto_products_products_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +329,7 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{4}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListProductsResponse) GetProducts() []*Product {
@@ -265,19 +339,120 @@ func (x *ListProductsResponse) GetProducts() []*Product {
 	return nil
 }
 
-type CreateProductRequest struct {
+type BatchGetProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Ids           []int32                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsRequest) Reset() {
+	*x = BatchGetProductsRequest{}
+	mi := &file_proto_products_products_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsRequest) ProtoMessage() {}
+
+func (x *BatchGetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_products_products_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_products_products_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetProductsRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// requested IDs without a product
+	MissingIds    []int32 `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsResponse) Reset() {
+	*x = BatchGetProductsResponse{}
+	mi := &file_proto_products_products_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsResponse) ProtoMessage() {}
+
+func (x *BatchGetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_products_products_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_products_products_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchGetProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *BatchGetProductsResponse) GetMissingIds() []int32 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// use price_money, price is only read when price_money is not set
+	//
+	// Deprecated: Marked as deprecated in proto/products/products.proto.
+	Price         float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Category      string  `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	PriceMoney    *Money  `protobuf:"bytes,5,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateProductRequest) Reset() {
 	*x = CreateProductRequest{}
-	mi := &file_proto_products_products_proto_msgTypes[5]
+	mi := &file_proto_products_products_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -289,7 +464,7 @@ func (x *CreateProductRequest) String() string {
 func (*CreateProductRequest) ProtoMessage() {}
 
 func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[5]
+	mi := &file_proto_products_products_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,7 +477,7 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
 func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{5}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateProductRequest) GetName() string {
@@ -319,6 +494,7 @@ func (x *CreateProductRequest) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/products/products.proto.
 func (x *CreateProductRequest) GetPrice() float64 {
 	if x != nil {
 		return x.Price
@@ -333,6 +509,13 @@ func (x *CreateProductRequest) GetCategory() string {
 	return ""
 }
 
+func (x *CreateProductRequest) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
 type CreateProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
@@ -342,7 +525,7 @@ type CreateProductResponse struct {
 
 func (x *CreateProductResponse) Reset() {
 	*x = CreateProductResponse{}
-	mi := &file_proto_products_products_proto_msgTypes[6]
+	mi := &file_proto_products_products_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -354,7 +537,7 @@ func (x *CreateProductResponse) String() string {
 func (*CreateProductResponse) ProtoMessage() {}
 
 func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_products_products_proto_msgTypes[6]
+	mi := &file_proto_products_products_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +550,7 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
 func (*CreateProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_products_products_proto_rawDescGZIP(), []int{6}
+	return file_proto_products_products_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateProductResponse) GetProduct() *Product {
@@ -377,36 +560,191 @@ func (x *CreateProductResponse) GetProduct() *Product {
 	return nil
 }
 
+type UpdateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Category    string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	PriceMoney  *Money                 `protobuf:"bytes,5,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
+	// when set, the update fails with ABORTED unless the product is still at this version
+	ExpectedVersion int32 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_proto_products_products_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_products_products_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_products_products_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_proto_products_products_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_products_products_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_products_products_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
 var File_proto_products_products_proto protoreflect.FileDescriptor
 
 const file_proto_products_products_proto_rawDesc = "" +
 	"\n" +
-	"\x1dproto/products/products.proto\x12\bproducts\"\x81\x01\n" +
+	"\x1dproto/products/products.proto\x12\bproducts\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xd1\x01\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bcategory\x18\x05 \x01(\tR\bcategory\"#\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\x05price\x18\x04 \x01(\x01B\x02\x18\x01R\x05price\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x120\n" +
+	"\vprice_money\x18\x06 \x01(\v2\x0f.products.MoneyR\n" +
+	"priceMoney\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"A\n" +
 	"\x12GetProductResponse\x12+\n" +
 	"\aproduct\x18\x01 \x01(\v2\x11.products.ProductR\aproduct\"\x15\n" +
 	"\x13ListProductsRequest\"E\n" +
 	"\x14ListProductsResponse\x12-\n" +
-	"\bproducts\x18\x01 \x03(\v2\x11.products.ProductR\bproducts\"~\n" +
+	"\bproducts\x18\x01 \x03(\v2\x11.products.ProductR\bproducts\"+\n" +
+	"\x17BatchGetProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\"j\n" +
+	"\x18BatchGetProductsResponse\x12-\n" +
+	"\bproducts\x18\x01 \x03(\v2\x11.products.ProductR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x05R\n" +
+	"missingIds\"\xb4\x01\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bcategory\x18\x04 \x01(\tR\bcategory\"D\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x01B\x02\x18\x01R\x05price\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x120\n" +
+	"\vprice_money\x18\x05 \x01(\v2\x0f.products.MoneyR\n" +
+	"priceMoney\"D\n" +
 	"\x15CreateProductResponse\x12+\n" +
-	"\aproduct\x18\x01 \x01(\v2\x11.products.ProductR\aproduct2\xfa\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.products.ProductR\aproduct\"\xd5\x01\n" +
+	"\x14UpdateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x120\n" +
+	"\vprice_money\x18\x05 \x01(\v2\x0f.products.MoneyR\n" +
+	"priceMoney\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x05R\x0fexpectedVersion\"D\n" +
+	"\x15UpdateProductResponse\x12+\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.products.ProductR\aproduct2\xa7\x03\n" +
 	"\x0eProductService\x12G\n" +
 	"\n" +
 	"GetProduct\x12\x1b.products.GetProductRequest\x1a\x1c.products.GetProductResponse\x12M\n" +
-	"\fListProducts\x12\x1d.products.ListProductsRequest\x1a\x1e.products.ListProductsResponse\x12P\n" +
-	"\rCreateProduct\x12\x1e.products.CreateProductRequest\x1a\x1f.products.CreateProductResponseB!Z\x1fproducts-service/proto/productsb\x06proto3"
+	"\fListProducts\x12\x1d.products.ListProductsRequest\x1a\x1e.products.ListProductsResponse\x12Y\n" +
+	"\x10BatchGetProducts\x12!.products.BatchGetProductsRequest\x1a\".products.BatchGetProductsResponse\x12P\n" +
+	"\rCreateProduct\x12\x1e.products.CreateProductRequest\x1a\x1f.products.CreateProductResponse\x12P\n" +
+	"\rUpdateProduct\x12\x1e.products.UpdateProductRequest\x1a\x1f.products.UpdateProductResponseB!Z\x1fproducts-service/proto/productsb\x06proto3"
 
 var (
 	file_proto_products_products_proto_rawDescOnce sync.Once
@@ -420,31 +758,45 @@ func file_proto_products_products_proto_rawDescGZIP() []byte {
 	return file_proto_products_products_proto_rawDescData
 }
 
-var file_proto_products_products_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_products_products_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_proto_products_products_proto_goTypes = []any{
-	(*Product)(nil),               // 0: products.Product
-	(*GetProductRequest)(nil),     // 1: products.GetProductRequest
-	(*GetProductResponse)(nil),    // 2: products.GetProductResponse
-	(*ListProductsRequest)(nil),   // 3: products.ListProductsRequest
-	(*ListProductsResponse)(nil),  // 4: products.ListProductsResponse
-	(*CreateProductRequest)(nil),  // 5: products.CreateProductRequest
-	(*CreateProductResponse)(nil), // 6: products.CreateProductResponse
+	(*Money)(nil),                    // 0: products.Money
+	(*Product)(nil),                  // 1: products.Product
+	(*GetProductRequest)(nil),        // 2: products.GetProductRequest
+	(*GetProductResponse)(nil),       // 3: products.GetProductResponse
+	(*ListProductsRequest)(nil),      // 4: products.ListProductsRequest
+	(*ListProductsResponse)(nil),     // 5: products.ListProductsResponse
+	(*BatchGetProductsRequest)(nil),  // 6: products.BatchGetProductsRequest
+	(*BatchGetProductsResponse)(nil), // 7: products.BatchGetProductsResponse
+	(*CreateProductRequest)(nil),     // 8: products.CreateProductRequest
+	(*CreateProductResponse)(nil),    // 9: products.CreateProductResponse
+	(*UpdateProductRequest)(nil),     // 10: products.UpdateProductRequest
+	(*UpdateProductResponse)(nil),    // 11: products.UpdateProductResponse
 }
 var file_proto_products_products_proto_depIdxs = []int32{
-	0, // 0: products.GetProductResponse.product:type_name -> products.Product
-	0, // 1: products.ListProductsResponse.products:type_name -> products.Product
-	0, // 2: products.CreateProductResponse.product:type_name -> products.Product
-	1, // 3: products.ProductService.GetProduct:input_type -> products.GetProductRequest
-	3, // 4: products.ProductService.ListProducts:input_type -> products.ListProductsRequest
-	5, // 5: products.ProductService.CreateProduct:input_type -> products.CreateProductRequest
-	2, // 6: products.ProductService.GetProduct:output_type -> products.GetProductResponse
-	4, // 7: products.ProductService.ListProducts:output_type -> products.ListProductsResponse
-	6, // 8: products.ProductService.CreateProduct:output_type -> products.CreateProductResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: products.Product.price_money:type_name -> products.Money
+	1,  // 1: products.GetProductResponse.product:type_name -> products.Product
+	1,  // 2: products.ListProductsResponse.products:type_name -> products.Product
+	1,  // 3: products.BatchGetProductsResponse.products:type_name -> products.Product
+	0,  // 4: products.CreateProductRequest.price_money:type_name -> products.Money
+	1,  // 5: products.CreateProductResponse.product:type_name -> products.Product
+	0,  // 6: products.UpdateProductRequest.price_money:type_name -> products.Money
+	1,  // 7: products.UpdateProductResponse.product:type_name -> products.Product
+	2,  // 8: products.ProductService.GetProduct:input_type -> products.GetProductRequest
+	4,  // 9: products.ProductService.ListProducts:input_type -> products.ListProductsRequest
+	6,  // 10: products.ProductService.BatchGetProducts:input_type -> products.BatchGetProductsRequest
+	8,  // 11: products.ProductService.CreateProduct:input_type -> products.CreateProductRequest
+	10, // 12: products.ProductService.UpdateProduct:input_type -> products.UpdateProductRequest
+	3,  // 13: products.ProductService.GetProduct:output_type -> products.GetProductResponse
+	5,  // 14: products.ProductService.ListProducts:output_type -> products.ListProductsResponse
+	7,  // 15: products.ProductService.BatchGetProducts:output_type -> products.BatchGetProductsResponse
+	9,  // 16: products.ProductService.CreateProduct:output_type -> products.CreateProductResponse
+	11, // 17: products.ProductService.UpdateProduct:output_type -> products.UpdateProductResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_proto_products_products_proto_init() }
@@ -458,7 +810,7 @@ func file_proto_products_products_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_products_products_proto_rawDesc), len(file_proto_products_products_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
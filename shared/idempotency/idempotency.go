@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+const MaxKeyLength = 255
+
+var (
+	ErrInvalidKey = errors.New("invalid idempotency key")
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+	ErrKeyReused  = errors.New("idempotency key was already used for a different request")
+)
+
+// Store
+// keeps the idempotency keys of a service and the responses stored for them
+type Store interface {
+	// claim a key for a request: (nil, nil) when the caller owns the key and must execute
+	// the request, the stored response when it was already executed; a key still in
+	// progress is taken over once its lease has expired
+	Begin_IdempotentRequest(_ context.Context, scope, key, requestHash string, expiredBefore time.Time, lease time.Duration) ([]byte, error)
+	Complete_IdempotentRequest(_ context.Context, scope, key string, response []byte) error
+	Delete_IdempotentRequest(_ context.Context, scope, key string) error
+}
+
+// Run executes run at most once per (scope, key) within the retention window ttl and
+// returns the stored result of that execution to repeated requests (replayed is then true)
+// failed executions are not stored, so the client may retry them with the same key; an
+// execution interrupted before its result was stored (a crash) holds the key for lease,
+// after which a retry executes the request again
+func Run[T any](ctx context.Context, store Store, ttl, lease time.Duration, scope, key string, request any, run func() (T, error)) (T, bool, error) {
+	var zero T
+
+	if key == "" {
+		res, err := run()
+		return res, false, err
+	}
+	if len(key) > MaxKeyLength {
+		return zero, false, ErrInvalidKey
+	}
+
+	hash, err := requestHash(request)
+	if err != nil {
+		return zero, false, err
+	}
+
+	stored, err := store.Begin_IdempotentRequest(ctx, scope, key, hash, time.Now().Add(-ttl), lease)
+	if err != nil {
+		return zero, false, err
+	}
+	if stored != nil {
+		var res T
+		if err := json.Unmarshal(stored, &res); err != nil {
+			return zero, false, err
+		}
+		return res, true, nil
+	}
+
+	// the outcome must be recorded even if the caller goes away
+	recordCtx := context.WithoutCancel(ctx)
+
+	res, err := run()
+	if err != nil {
+		if err := store.Delete_IdempotentRequest(recordCtx, scope, key); err != nil {
+			log.Printf("Error releasing idempotency key %q (%s): %v", key, scope, err)
+		}
+		return zero, false, err
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		return zero, false, err
+	}
+	if err := store.Complete_IdempotentRequest(recordCtx, scope, key, body); err != nil {
+		// the request succeeded; a retry sees the key as in progress until its lease expires
+		log.Printf("Error storing response for idempotency key %q (%s): %v", key, scope, err)
+	}
+
+	return res, false, nil
+}
+
+// fingerprint of a request, used to detect a key reused with a different request
+func requestHash(request any) (string, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
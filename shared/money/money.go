@@ -0,0 +1,197 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// every amount is kept in minor units (cents), matching the DECIMAL(10, 2) columns
+const minorDigits = 2
+
+// currency of the amounts stored before currencies were recorded
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Money
+// exact amount of money, as an integer number of minor units of a currency
+// it is encoded in JSON as a decimal number (e.g. 12.34), the currency being
+// carried by the entity holding the amount
+type Money struct {
+	Amount   int64  // minor units
+	Currency string // ISO 4217 code
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse reads a decimal amount (e.g. "12.34") without going through a float
+// amounts with more decimals than the currency's minor units are rejected
+func Parse(s, currency string) (Money, error) {
+	amount, err := parseMinor(s)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// FromFloat converts an amount given as a float, rounding it to the nearest minor unit
+// only meant for clients still sending floats
+func FromFloat(f float64, currency string) Money {
+	return Money{Amount: int64(math.Round(f * math.Pow10(minorDigits))), Currency: currency}
+}
+
+// Float64 returns the amount as a float, for clients still reading floats
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(minorDigits)
+}
+
+// String formats the amount as a decimal number, without the currency
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	unit := int64(math.Pow10(minorDigits))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, minorDigits, amount%unit)
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Mul returns the amount multiplied by a quantity, in the same currency
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Add returns the sum of two amounts of the same currency
+// a zero amount without currency (e.g. a new total) takes the other amount's currency
+func (m Money) Add(other Money) (Money, error) {
+	currency := m.Currency
+	switch {
+	case m.Currency == "":
+		currency = other.Currency
+	case other.Currency != "" && other.Currency != m.Currency:
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}, nil
+}
+
+// -------------------------------------------------------------------
+
+// ValidCurrency reports whether code looks like an ISO 4217 code (three upper case letters)
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// -------------------------------------------------------------------
+// encoding
+// -------------------------------------------------------------------
+
+// the amount is written as a plain JSON number, so clients reading floats keep working
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// the currency is left untouched, it is set by the entity holding the amount
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	amount, err := parseMinor(string(data))
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+
+	return nil
+}
+
+// the amount is written to DECIMAL columns as its decimal text
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// the amount is read from DECIMAL columns, the currency is set by the repository
+func (m *Money) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+
+	amount, err := parseMinor(s)
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+func parseMinor(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(digits, ".")
+	frac = strings.TrimRight(frac, "0")
+	if whole == "" || len(frac) > minorDigits || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	frac += strings.Repeat("0", minorDigits-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}